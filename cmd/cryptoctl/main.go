@@ -0,0 +1,12 @@
+package main
+
+import (
+	"os"
+
+	"Cryptoproject/internal/app"
+)
+
+func main() {
+	c := app.NewCtl()
+	os.Exit(c.Run(os.Args[1:]))
+}
@@ -2,39 +2,373 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
+const (
+	// EnvPrefix prefixes the environment variable of every setting, e.g. CRYPTOPROJECT_SRV_PORT for srv-port.
+	EnvPrefix = "CRYPTOPROJECT"
+
+	defaultConfigFile = "/app/config/cfg.yaml"
+	configFileEnv     = "CONFIG_FILE_PATH"
+	configFileFlag    = "config"
+)
+
+var ErrInvalidConfig = errors.New("invalid configuration")
+
 type Config struct {
-	SrvPort string `mapstructure:"srv-port"`
-	PgUser  string `mapstructure:"pg-user"`
-	PgPswd  string `mapstructure:"pg-pswd"`
-	PgDB    string `mapstructure:"pg-db"`
-	PgHost  string `mapstructure:"pg-host"`
-	PgPort  string `mapstructure:"pg-port"`
+	SrvPort   string `mapstructure:"srv-port"`
+	PgUser    string `mapstructure:"pg-user"`
+	PgPswd    string `mapstructure:"pg-pswd"`
+	PgDB      string `mapstructure:"pg-db"`
+	PgHost    string `mapstructure:"pg-host"`
+	PgPort    string `mapstructure:"pg-port"`
+	PgSSLMode string `mapstructure:"pg-sslmode"`
+	// ConnStr overrides the connection string assembled from the Pg* settings when set.
 	ConnStr string `mapstructure:"conn-str"`
+	// MinSampleSpacing is the width of the buckets a coin keeps one stored rate per source in.
+	MinSampleSpacing time.Duration `mapstructure:"min-sample-spacing"`
+
+	LogLevel  string `mapstructure:"log-level"`
+	LogFormat string `mapstructure:"log-format"`
+
+	UpdateInterval time.Duration `mapstructure:"update-interval"`
+	TrackedCoins   []string      `mapstructure:"tracked-coins"`
+
+	QualityScanInterval time.Duration `mapstructure:"quality-scan-interval"`
+	QualityWindow       time.Duration `mapstructure:"quality-window"`
+	QualityJumpSigma    float64       `mapstructure:"quality-jump-sigma"`
+
+	OutlierMaxDeviation float64       `mapstructure:"outlier-max-deviation"`
+	OutlierWindow       time.Duration `mapstructure:"outlier-window"`
+
+	GrpcPort         string        `mapstructure:"grpc-port"`
+	ShutdownTimeout  time.Duration `mapstructure:"shutdown-timeout"`
+	MaxStaleness     time.Duration `mapstructure:"max-staleness"`
+	StreamBufferSize int           `mapstructure:"stream-buffer-size"`
+	AuthMode         string        `mapstructure:"auth-mode"`
+	AdminToken       string        `mapstructure:"admin-token"`
+	JWKSSource       string        `mapstructure:"jwks-source"`
+	JWTIssuer        string        `mapstructure:"jwt-issuer"`
+	JWTAudience      string        `mapstructure:"jwt-audience"`
+
+	RequestTimeout     time.Duration            `mapstructure:"request-timeout"`
+	RouteTimeouts      map[string]time.Duration `mapstructure:"route-timeouts"`
+	MaxBodyBytes       int64                    `mapstructure:"max-body-bytes"`
+	CORSAllowedOrigins []string                 `mapstructure:"cors-allowed-origins"`
+	CORSAllowedMethods []string                 `mapstructure:"cors-allowed-methods"`
+	CORSAllowedHeaders []string                 `mapstructure:"cors-allowed-headers"`
+	CORSMaxAge         time.Duration            `mapstructure:"cors-max-age"`
+
+	TracingEnabled     bool    `mapstructure:"tracing-enabled"`
+	TracingEndpoint    string  `mapstructure:"tracing-endpoint"`
+	TracingInsecure    bool    `mapstructure:"tracing-insecure"`
+	TracingSampleRatio float64 `mapstructure:"tracing-sample-ratio"`
+}
+
+// defaults lists every setting with its default value. The type of the value decides the type of its flag.
+var defaults = map[string]any{
+	"srv-port":   ":8080",
+	"grpc-port":  ":9090",
+	"pg-user":    "user",
+	"pg-pswd":    "",
+	"pg-db":      "coinsdatabase",
+	"pg-host":    "localhost",
+	"pg-port":    "5432",
+	"pg-sslmode": "disable",
+	"conn-str":   "",
+
+	"min-sample-spacing": time.Minute,
+
+	"log-level":  "info",
+	"log-format": "text",
+
+	"update-interval": 5 * time.Minute,
+	"tracked-coins":   []string{},
+
+	"quality-scan-interval": 15 * time.Minute,
+	"quality-window":        24 * time.Hour,
+	"quality-jump-sigma":    4.0,
+
+	"outlier-max-deviation": 0.5,
+	"outlier-window":        time.Hour,
+
+	"shutdown-timeout":   30 * time.Second,
+	"max-staleness":      time.Hour,
+	"stream-buffer-size": 16,
+	"auth-mode":          "api-key",
+	"admin-token":        "",
+	"jwks-source":        "",
+	"jwt-issuer":         "",
+	"jwt-audience":       "",
+
+	"request-timeout":      30 * time.Second,
+	"route-timeouts":       map[string]time.Duration{},
+	"max-body-bytes":       int64(1 << 20),
+	"cors-allowed-origins": []string{},
+	"cors-allowed-methods": []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+	"cors-allowed-headers": []string{"Authorization", "Content-Type", "X-API-Key", "X-Admin-Token", "X-Request-Id", "If-None-Match", "If-Modified-Since"},
+	"cors-max-age":         10 * time.Minute,
+
+	"tracing-enabled":      false,
+	"tracing-endpoint":     "localhost:4317",
+	"tracing-insecure":     true,
+	"tracing-sample-ratio": 1.0,
+}
+
+// secrets are not exposed as flags, they would be visible in the process list.
+var secrets = map[string]bool{"pg-pswd": true, "admin-token": true}
+
+// placeholderTokens are sample values that must never guard the admin routes of a deployment.
+var placeholderTokens = map[string]bool{
+	"change-me": true, "changeme": true, "admin": true, "admin-token": true, "token": true, "password": true,
+}
+
+// legacyEnv are the variables already set for the database container, accepted next to the prefixed ones.
+var legacyEnv = map[string]string{
+	"pg-user": "POSTGRES_USER",
+	"pg-pswd": "POSTGRES_PASSWORD",
+	"pg-db":   "POSTGRES_DB",
+	"pg-host": "POSTGRES_HOST",
+	"pg-port": "POSTGRES_PORT",
 }
 
+// LoadCfg loads the configuration from the command line arguments of the process.
 func LoadCfg() (*Config, error) {
-	cfg := &Config{}
+	return Load(os.Args[1:])
+}
 
-	filePath := os.Getenv("CONFIG_FILE_PATH")
-	if filePath == "" {
-		filePath = "/app/config/cfg.yaml"
+// Load resolves every setting from, in order of precedence, command line flags, environment variables,
+// the YAML config file and the defaults, then validates the result. The config file is taken from the
+// --config flag or CONFIG_FILE_PATH and is optional unless one of them names it.
+func Load(args []string) (*Config, error) {
+	v, err := newViper(args)
+	if err != nil {
+		return nil, err
 	}
+	return decode(v)
+}
 
-	viper.SetConfigFile(filePath)
-	err := viper.ReadInConfig()
+// LoadCLI resolves the settings like Load for the cryptoctl admin CLI. The CLI serves no requests, so the
+// authentication settings of the servers, such as the admin token, are not required.
+func LoadCLI(args []string) (*Config, error) {
+	v, err := newViper(args)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := unmarshal(v)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+		return nil, err
+	}
+	if err := cfg.validate(false); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func newViper(args []string) (*viper.Viper, error) {
+	v := viper.New()
+	for key, value := range defaults {
+		v.SetDefault(key, value)
 	}
 
-	err = viper.Unmarshal(cfg)
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	v.AutomaticEnv()
+	for key, name := range legacyEnv {
+		if err := v.BindEnv(key, EnvPrefix+"_"+strings.ToUpper(strings.ReplaceAll(key, "-", "_")), name); err != nil {
+			return nil, fmt.Errorf("failed to bind environment variable %s: %w", name, err)
+		}
+	}
+
+	flags := newFlagSet()
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if err := v.BindPFlags(flags); err != nil {
+		return nil, fmt.Errorf("failed to bind flags: %w", err)
+	}
+
+	filePath, _ := flags.GetString(configFileFlag)
+	if filePath == "" {
+		filePath = os.Getenv(configFileEnv)
+	}
+	required := filePath != ""
+	if !required {
+		filePath = defaultConfigFile
+	}
+	if _, err := os.Stat(filePath); err == nil || required {
+		v.SetConfigFile(filePath)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read configuration file: %w", err)
+		}
+	}
+	return v, nil
+}
+
+func decode(v *viper.Viper) (*Config, error) {
+	cfg, err := unmarshal(v)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal configuration: %w", err)
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
+	return cfg, nil
+}
 
+func unmarshal(v *viper.Viper) (*Config, error) {
+	cfg := &Config{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal configuration: %w", err)
+	}
 	return cfg, nil
 }
+
+func newFlagSet() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("cryptoproject", pflag.ContinueOnError)
+	flags.String(configFileFlag, "", "path to the YAML config file, "+defaultConfigFile+" if it exists")
+	for key, value := range defaults {
+		if secrets[key] {
+			continue
+		}
+		usage := "overrides " + key + ", also set by " + EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+		switch value := value.(type) {
+		case string:
+			flags.String(key, value, usage)
+		case int:
+			flags.Int(key, value, usage)
+		case int64:
+			flags.Int64(key, value, usage)
+		case float64:
+			flags.Float64(key, value, usage)
+		case bool:
+			flags.Bool(key, value, usage)
+		case time.Duration:
+			flags.Duration(key, value, usage)
+		case []string:
+			flags.StringSlice(key, value, usage)
+		}
+	}
+	flags.SortFlags = true
+	return flags
+}
+
+// DSN returns ConnStr if set, otherwise a PostgreSQL URL assembled from the Pg* settings.
+func (c *Config) DSN() string {
+	if c.ConnStr != "" {
+		return c.ConnStr
+	}
+
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.PgUser, c.PgPswd),
+		Host:   net.JoinHostPort(c.PgHost, c.PgPort),
+		Path:   "/" + c.PgDB,
+	}
+	if c.PgPswd == "" {
+		dsn.User = url.User(c.PgUser)
+	}
+	if c.PgSSLMode != "" {
+		dsn.RawQuery = url.Values{"sslmode": {c.PgSSLMode}}.Encode()
+	}
+	return dsn.String()
+}
+
+// Validate reports every missing or invalid setting at once, wrapped in ErrInvalidConfig.
+func (c *Config) Validate() error {
+	return c.validate(true)
+}
+
+// validate checks the authentication settings only when serving, see LoadCLI.
+func (c *Config) validate(serving bool) error {
+	var problems []string
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(validAddr(c.SrvPort), "srv-port %q must be a [host]:port address", c.SrvPort)
+	check(validAddr(c.GrpcPort), "grpc-port %q must be a [host]:port address", c.GrpcPort)
+
+	if c.ConnStr == "" {
+		check(c.PgHost != "", "pg-host is required unless conn-str is set")
+		check(c.PgUser != "", "pg-user is required unless conn-str is set")
+		check(c.PgDB != "", "pg-db is required unless conn-str is set")
+		check(validPort(c.PgPort), "pg-port %q must be a port number", c.PgPort)
+	}
+
+	check(oneOf(c.LogLevel, "debug", "info", "warn", "error"), "log-level %q must be one of debug, info, warn, error", c.LogLevel)
+	check(oneOf(c.LogFormat, "text", "json"), "log-format %q must be one of text, json", c.LogFormat)
+
+	check(c.MinSampleSpacing >= time.Microsecond, "min-sample-spacing must be at least 1µs")
+	check(c.UpdateInterval > 0, "update-interval must be greater than zero")
+	check(c.QualityScanInterval > 0, "quality-scan-interval must be greater than zero")
+	check(c.QualityWindow > 0, "quality-window must be greater than zero")
+	check(c.QualityJumpSigma > 0, "quality-jump-sigma must be greater than zero")
+	check(c.OutlierMaxDeviation >= 0, "outlier-max-deviation cannot be negative")
+	if c.OutlierMaxDeviation > 0 {
+		check(c.OutlierWindow > 0, "outlier-window must be greater than zero")
+	}
+	check(c.ShutdownTimeout > 0, "shutdown-timeout must be greater than zero")
+	check(c.MaxStaleness > 0, "max-staleness must be greater than zero")
+	check(c.StreamBufferSize > 0, "stream-buffer-size must be greater than zero")
+	check(c.RequestTimeout > 0, "request-timeout must be greater than zero")
+	for route, timeout := range c.RouteTimeouts {
+		check(timeout > 0, "route-timeouts of %s must be greater than zero", route)
+	}
+	check(c.MaxBodyBytes > 0, "max-body-bytes must be greater than zero")
+	check(c.CORSMaxAge >= 0, "cors-max-age cannot be negative")
+
+	switch {
+	case !serving:
+	case c.AuthMode == "none":
+	case c.AuthMode == "api-key":
+		token := strings.TrimSpace(c.AdminToken)
+		check(token != "", "admin-token is required in api-key auth mode")
+		check(token == "" || !placeholderTokens[strings.ToLower(token)], "admin-token must not be a placeholder value")
+	case c.AuthMode == "jwt":
+		check(c.JWKSSource != "", "jwks-source is required in jwt auth mode")
+	default:
+		check(false, "auth-mode %q must be one of none, api-key, jwt", c.AuthMode)
+	}
+
+	if c.TracingEnabled {
+		check(c.TracingEndpoint != "", "tracing-endpoint is required when tracing is enabled")
+	}
+	check(c.TracingSampleRatio >= 0 && c.TracingSampleRatio <= 1, "tracing-sample-ratio must be between 0 and 1")
+
+	if len(problems) > 0 {
+		return errors.Wrap(ErrInvalidConfig, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validAddr(addr string) bool {
+	_, port, err := net.SplitHostPort(addr)
+	return err == nil && validPort(port)
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n >= 0 && n <= 65535
+}
+
+func oneOf(value string, allowed ...string) bool {
+	for _, candidate := range allowed {
+		if strings.EqualFold(value, candidate) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// liveKeys are the settings applied without a restart. Changes of any other setting, such as the listen
+// addresses or the database connection, are logged and ignored until the next start. Rate limits are not
+// file settings: they are kept per API key in the database and changes through the admin API apply at once.
+// Provider priorities do not exist while CryptoCompare is the only rate provider.
+var liveKeys = map[string]bool{
+	"log-level":       true,
+	"update-interval": true,
+	"tracked-coins":   true,
+}
+
+var ErrNoConfigFile = errors.New("no configuration file to watch")
+
+// Watcher reloads the config file when it changes and passes the new configuration to its subscribers.
+type Watcher struct {
+	v           *viper.Viper
+	logger      *slog.Logger
+	fsWatcher   *fsnotify.Watcher
+	done        chan struct{}
+	closeOnce   sync.Once
+	mu          sync.Mutex
+	current     *Config
+	subscribers []func(*Config)
+}
+
+type WatcherOption func(*Watcher)
+
+func WithLogger(logger *slog.Logger) WatcherOption {
+	return func(w *Watcher) {
+		w.logger = logger
+	}
+}
+
+// Watch starts watching the config file resolved from args like Load does. current is the configuration
+// the application was started with, a file that fails to load or validate leaves it in place. The file is
+// watched until Close.
+func Watch(args []string, current *Config, opts ...WatcherOption) (*Watcher, error) {
+	if current == nil {
+		return nil, errors.Wrap(ErrInvalidConfig, "current configuration not provided")
+	}
+
+	v, err := newViper(args)
+	if err != nil {
+		return nil, err
+	}
+	if v.ConfigFileUsed() == "" {
+		return nil, ErrNoConfigFile
+	}
+
+	w := &Watcher{
+		v:       v,
+		logger:  slog.Default(),
+		current: current,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.logger == nil {
+		return nil, errors.Wrap(ErrInvalidConfig, "logger cannot be nil")
+	}
+
+	// The directory is watched rather than the file, editors and mounted config maps replace the file.
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create configuration file watcher")
+	}
+	path := filepath.Clean(v.ConfigFileUsed())
+	if err := fsWatcher.Add(filepath.Dir(path)); err != nil {
+		_ = fsWatcher.Close()
+		return nil, errors.Wrap(err, "failed to watch configuration directory")
+	}
+	w.fsWatcher = fsWatcher
+	w.done = make(chan struct{})
+
+	go w.watch(path)
+	w.logger.Info("Watching configuration file", "path", path)
+	return w, nil
+}
+
+// Close stops watching the config file and waits for a reload in progress to finish.
+func (w *Watcher) Close(ctx context.Context) error {
+	var err error
+	w.closeOnce.Do(func() {
+		err = w.fsWatcher.Close()
+	})
+	select {
+	case <-w.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return errors.Wrap(err, "failed to close configuration file watcher")
+}
+
+func (w *Watcher) watch(path string) {
+	defer close(w.done)
+
+	realPath, _ := filepath.EvalSymlinks(path)
+	for {
+		select {
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			// A config map swaps the symlink target instead of writing the file.
+			currentPath, _ := filepath.EvalSymlinks(path)
+			written := filepath.Clean(event.Name) == path && event.Has(fsnotify.Write|fsnotify.Create)
+			if written || (currentPath != "" && currentPath != realPath) {
+				realPath = currentPath
+				w.reload()
+			}
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Error("Configuration file watcher failed", "err", err)
+		}
+	}
+}
+
+// Subscribe registers fn to be called with the configuration after every applied change. Calls are
+// sequential, fn should only pick up the live settings it is interested in.
+func (w *Watcher) Subscribe(fn func(*Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Current returns the configuration with all changes applied so far.
+func (w *Watcher) Current() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+func (w *Watcher) reload() {
+	if err := w.v.ReadInConfig(); err != nil {
+		w.logger.Error("Failed to reload configuration file", "path", w.v.ConfigFileUsed(), "err", err)
+		return
+	}
+	next, err := decode(w.v)
+	if err != nil {
+		w.logger.Error("Rejected configuration change", "path", w.v.ConfigFileUsed(), "err", err)
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	changed := w.keepRestartSettings(next)
+	if len(changed) == 0 {
+		return
+	}
+	w.current = next
+	w.logger.Info("Configuration reloaded", "changed", changed)
+	for _, fn := range w.subscribers {
+		fn(next)
+	}
+}
+
+// keepRestartSettings reverts next to the current value of every setting that needs a restart and returns
+// the live settings that changed. Only setting names are logged, values may be secrets.
+func (w *Watcher) keepRestartSettings(next *Config) []string {
+	var changed []string
+	current := reflect.ValueOf(w.current).Elem()
+	updated := reflect.ValueOf(next).Elem()
+	for i := range current.NumField() {
+		if reflect.DeepEqual(current.Field(i).Interface(), updated.Field(i).Interface()) {
+			continue
+		}
+		key := current.Type().Field(i).Tag.Get("mapstructure")
+		if !liveKeys[key] {
+			w.logger.Warn("Configuration change requires a restart, keeping the running value", "setting", key)
+			updated.Field(i).Set(current.Field(i))
+			continue
+		}
+		changed = append(changed, key)
+	}
+	return changed
+}
@@ -0,0 +1,107 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/config"
+)
+
+const watchedConfig = `
+srv-port: ":8080"
+admin-token: "secret"
+log-level: "info"
+update-interval: "5m"
+`
+
+func TestWatch_AppliesLiveSettings(t *testing.T) {
+	isolate(t)
+
+	path := filepath.Join(t.TempDir(), "cfg.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(watchedConfig), 0o600))
+	args := []string{"--config", path}
+
+	cfg, err := config.Load(args)
+	require.NoError(t, err)
+
+	watcher, err := config.Watch(args, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, watcher.Close(context.Background())) })
+
+	updates := make(chan *config.Config, 8)
+	watcher.Subscribe(func(cfg *config.Config) { updates <- cfg })
+
+	// An invalid file is rejected as a whole.
+	require.NoError(t, os.WriteFile(path, []byte(watchedConfig+`update-interval: "-1m"`+"\n"), 0o600))
+	select {
+	case <-updates:
+		t.Fatal("invalid configuration was applied")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+srv-port: ":8081"
+admin-token: "secret"
+log-level: "debug"
+update-interval: "1m"
+tracked-coins: ["BTC", "ETH"]
+`), 0o600))
+
+	var updated *config.Config
+	select {
+	case updated = <-updates:
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration change was not applied")
+	}
+
+	require.Equal(t, "debug", updated.LogLevel)
+	require.Equal(t, time.Minute, updated.UpdateInterval)
+	require.Equal(t, []string{"BTC", "ETH"}, updated.TrackedCoins)
+	require.Equal(t, ":8080", updated.SrvPort, "listen address changes need a restart")
+	require.Equal(t, updated, watcher.Current())
+}
+
+func TestWatch_Close(t *testing.T) {
+	isolate(t)
+
+	path := filepath.Join(t.TempDir(), "cfg.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(watchedConfig), 0o600))
+	args := []string{"--config", path}
+
+	cfg, err := config.Load(args)
+	require.NoError(t, err)
+
+	watcher, err := config.Watch(args, cfg)
+	require.NoError(t, err)
+
+	updates := make(chan *config.Config, 8)
+	watcher.Subscribe(func(cfg *config.Config) { updates <- cfg })
+
+	require.NoError(t, watcher.Close(context.Background()))
+	require.NoError(t, watcher.Close(context.Background()), "closing twice is harmless")
+
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(watchedConfig, `"info"`, `"debug"`, 1)), 0o600))
+	select {
+	case <-updates:
+		t.Fatal("configuration change was applied after Close")
+	case <-time.After(300 * time.Millisecond):
+	}
+	require.Equal(t, cfg, watcher.Current())
+}
+
+func TestWatch_NoConfigFile(t *testing.T) {
+	isolate(t)
+	t.Setenv("CRYPTOPROJECT_ADMIN_TOKEN", "secret")
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+
+	_, err = config.Watch(nil, cfg)
+	require.ErrorIs(t, err, config.ErrNoConfigFile)
+}
@@ -0,0 +1,172 @@
+package broker
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const defaultBufferSize = 16
+
+var ErrSlowConsumer = errors.New("subscriber disconnected: buffer overflow")
+
+type Subscription struct {
+	titles map[string]struct{}
+	events chan entities.Coin
+	err    error
+}
+
+// Events returns the channel of published coins matching the subscription titles.
+// The channel is closed when the subscription is cancelled or dropped as a slow consumer.
+func (s *Subscription) Events() <-chan entities.Coin {
+	return s.events
+}
+
+// Err reports why the events channel was closed, it is nil after a regular unsubscribe.
+// It must only be called after the events channel is closed.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+func (s *Subscription) sortedTitles() []string {
+	titles := make([]string, 0, len(s.titles))
+	for title := range s.titles {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+	return titles
+}
+
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	bufferSize  int
+	logger      *slog.Logger
+}
+
+type BrokerOption func(*Broker)
+
+func WithBufferSize(bufferSize int) BrokerOption {
+	return func(b *Broker) {
+		b.bufferSize = bufferSize
+	}
+}
+
+func WithLogger(logger *slog.Logger) BrokerOption {
+	return func(b *Broker) {
+		b.logger = logger
+	}
+}
+
+func NewBroker(opts ...BrokerOption) (*Broker, error) {
+	b := &Broker{
+		subscribers: make(map[*Subscription]struct{}),
+		bufferSize:  defaultBufferSize,
+		logger:      slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if b.bufferSize <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "buffer size must be greater than zero")
+	}
+	if b.logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "logger cannot be nil")
+	}
+
+	b.logger.Info("Broker initialized", "buffer_size", b.bufferSize)
+	return b, nil
+}
+
+func (b *Broker) Subscribe(titles []string) *Subscription {
+	sub := &Subscription{
+		titles: make(map[string]struct{}, len(titles)),
+		events: make(chan entities.Coin, b.bufferSize),
+	}
+	for _, title := range titles {
+		sub.titles[title] = struct{}{}
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	total := len(b.subscribers)
+	b.mu.Unlock()
+
+	b.logger.Debug("Subscriber added", "titles", titles, "number_of_subscribers", total)
+	return sub
+}
+
+// AddTitles extends the subscription with titles and returns the resulting set of titles.
+func (b *Broker) AddTitles(sub *Subscription, titles []string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, title := range titles {
+		sub.titles[title] = struct{}{}
+	}
+	return sub.sortedTitles()
+}
+
+// RemoveTitles removes titles from the subscription and returns the remaining set of titles.
+func (b *Broker) RemoveTitles(sub *Subscription, titles []string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, title := range titles {
+		delete(sub.titles, title)
+	}
+	return sub.sortedTitles()
+}
+
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.drop(sub, nil)
+}
+
+func (b *Broker) Publish(ctx context.Context, coins []entities.Coin) {
+	now := time.Now().UTC()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, coin := range coins {
+		if coin.ActualAt.IsZero() {
+			coin.ActualAt = now
+		}
+
+		for sub := range b.subscribers {
+			if _, wanted := sub.titles[coin.Title]; !wanted {
+				continue
+			}
+
+			select {
+			case sub.events <- coin:
+			default:
+				b.logger.Error("Dropping slow subscriber", "buffer_size", b.bufferSize)
+				b.drop(sub, ErrSlowConsumer)
+			}
+		}
+	}
+
+	b.logger.Debug("Rates published", "number_of_coins", len(coins), "number_of_subscribers", len(b.subscribers))
+}
+
+// drop removes the subscription and closes its events channel, b.mu must be held.
+func (b *Broker) drop(sub *Subscription, reason error) {
+	if _, exists := b.subscribers[sub]; !exists {
+		return
+	}
+
+	delete(b.subscribers, sub)
+	sub.err = reason
+	close(sub.events)
+}
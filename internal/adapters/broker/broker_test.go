@@ -0,0 +1,104 @@
+package broker_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/broker"
+	"Cryptoproject/internal/entities"
+)
+
+func TestBroker_Publish_FiltersByTitle(t *testing.T) {
+	t.Parallel()
+
+	b, err := broker.NewBroker()
+	require.NoError(t, err)
+
+	sub := b.Subscribe([]string{"BTC"})
+	defer b.Unsubscribe(sub)
+
+	b.Publish(context.Background(), []entities.Coin{
+		{Title: "ETH", Cost: 3000},
+		{Title: "BTC", Cost: 50000},
+	})
+
+	coin := <-sub.Events()
+	require.Equal(t, "BTC", coin.Title)
+	require.Equal(t, float64(50000), coin.Cost)
+	require.False(t, coin.ActualAt.IsZero())
+	require.Empty(t, sub.Events())
+}
+
+func TestBroker_Publish_DropsSlowConsumer(t *testing.T) {
+	t.Parallel()
+
+	b, err := broker.NewBroker(broker.WithBufferSize(1))
+	require.NoError(t, err)
+
+	slow := b.Subscribe([]string{"BTC"})
+	fast := b.Subscribe([]string{"BTC"})
+
+	b.Publish(context.Background(), []entities.Coin{{Title: "BTC", Cost: 50000}})
+	<-fast.Events()
+	b.Publish(context.Background(), []entities.Coin{{Title: "BTC", Cost: 51000}})
+
+	<-slow.Events()
+	_, ok := <-slow.Events()
+	require.False(t, ok)
+	require.ErrorIs(t, slow.Err(), broker.ErrSlowConsumer)
+
+	coin := <-fast.Events()
+	require.Equal(t, float64(51000), coin.Cost)
+
+	b.Unsubscribe(fast)
+	_, ok = <-fast.Events()
+	require.False(t, ok)
+	require.NoError(t, fast.Err())
+}
+
+func TestBroker_Unsubscribe_Twice(t *testing.T) {
+	t.Parallel()
+
+	b, err := broker.NewBroker()
+	require.NoError(t, err)
+
+	sub := b.Subscribe([]string{"BTC"})
+	b.Unsubscribe(sub)
+	b.Unsubscribe(sub)
+
+	b.Publish(context.Background(), []entities.Coin{{Title: "BTC", Cost: 50000}})
+	_, ok := <-sub.Events()
+	require.False(t, ok)
+}
+
+func TestNewBroker_InvalidBufferSize(t *testing.T) {
+	t.Parallel()
+
+	b, err := broker.NewBroker(broker.WithBufferSize(0))
+	require.Nil(t, b)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestBroker_AddRemoveTitles(t *testing.T) {
+	t.Parallel()
+
+	b, err := broker.NewBroker()
+	require.NoError(t, err)
+
+	sub := b.Subscribe(nil)
+	defer b.Unsubscribe(sub)
+
+	require.Equal(t, []string{"BTC", "ETH"}, b.AddTitles(sub, []string{"ETH", "BTC"}))
+	require.Equal(t, []string{"ETH"}, b.RemoveTitles(sub, []string{"BTC"}))
+
+	b.Publish(context.Background(), []entities.Coin{
+		{Title: "BTC", Cost: 50000},
+		{Title: "ETH", Cost: 3000},
+	})
+
+	coin := <-sub.Events()
+	require.Equal(t, "ETH", coin.Title)
+	require.Empty(t, sub.Events())
+}
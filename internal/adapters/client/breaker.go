@@ -0,0 +1,81 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// breaker opens after threshold consecutive failures and rejects calls until cooldown has passed.
+// It then lets a single trial call through: success closes the circuit, failure opens it again.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	open      bool
+	trial     bool
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return true
+	}
+	if b.trial || time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+func (b *breaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.failures = 0
+		b.open = false
+		b.trial = false
+		return
+	}
+
+	b.failures++
+	if b.trial || b.failures >= b.threshold {
+		b.open = true
+		b.trial = false
+		b.openedAt = time.Now()
+	}
+}
+
+// release ends a call without a verdict on the provider, such as one abandoned by its caller. A trial call
+// released this way lets the next call try again.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+func (b *breaker) state() entities.CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case !b.open:
+		return entities.CircuitClosed
+	case b.trial || time.Since(b.openedAt) >= b.cooldown:
+		return entities.CircuitHalfOpen
+	default:
+		return entities.CircuitOpen
+	}
+}
@@ -3,7 +3,6 @@ package client
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -15,19 +14,31 @@ import (
 	"log/slog"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Source names CryptoCompare as the source of the rates returned by the client.
+const Source = "cryptocompare"
+
 const (
-	baseURL         = "https://min-api.cryptocompare.com/data/"
+	defaultBaseURL  = "https://min-api.cryptocompare.com/data/"
 	priceMulti      = "pricemulti"
 	defaultCurrency = "USD"
 	fsymsQuery      = "fsyms"
 	tsymsQuery      = "tsyms"
+	tracerName      = "Cryptoproject/internal/adapters/client"
 )
 
 type Client struct {
+	baseURL    string
 	httpClient *http.Client
 	costIn     string
+	breaker    *breaker
+	logger     *slog.Logger
 }
 
 type ClientOption func(*Client)
@@ -38,6 +49,34 @@ func WithCustomCostIn(costIn string) ClientOption {
 	}
 }
 
+// WithBaseURL points the client at another CryptoCompare compatible API, such as a test server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient replaces the instrumented default HTTP client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithCircuitBreaker makes the client fail fast for cooldown after threshold consecutive failed calls.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) ClientOption {
+	return func(c *Client) {
+		c.breaker.threshold = threshold
+		c.breaker.cooldown = cooldown
+	}
+}
+
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
 func (c *Client) setOption(opts ...ClientOption) {
 	for _, opt := range opts {
 		opt(c)
@@ -46,8 +85,15 @@ func (c *Client) setOption(opts ...ClientOption) {
 
 func NewClient(opts ...ClientOption) (*Client, error) {
 	c := &Client{
-		httpClient: http.DefaultClient,
+		baseURL: defaultBaseURL,
+		// The instrumented transport opens a span per request and propagates the trace context to the provider.
+		httpClient: &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
 		costIn:     defaultCurrency,
+		breaker: &breaker{
+			threshold: defaultBreakerThreshold,
+			cooldown:  defaultBreakerCooldown,
+		},
+		logger: slog.Default(),
 	}
 
 	c.setOption(opts...)
@@ -55,40 +101,127 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 	if c.costIn == "" {
 		return nil, errors.Wrap(entities.ErrInvalidParam, "CostIn cannot be empty")
 	}
+	if _, err := url.ParseRequestURI(c.baseURL); err != nil || !strings.HasSuffix(c.baseURL, "/") {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "base URL must be an absolute URL ending with a slash")
+	}
+	if c.httpClient == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "HTTP client cannot be nil")
+	}
+	if c.breaker.threshold <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "circuit breaker threshold must be greater than zero")
+	}
+	if c.breaker.cooldown <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "circuit breaker cooldown must be greater than zero")
+	}
+	if c.logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "logger cannot be nil")
+	}
 
-	slog.Info("Client initialized", "cost_in", c.costIn)
+	c.logger.Info("Client initialized", "cost_in", c.costIn)
 
 	return c, nil
 }
-func (c *Client) GetActualRates(ctx context.Context, titles []string) ([]entities.Coin, error) {
-	slog.Info("Fetching actual coin rates", "titles", titles)
+func (c *Client) GetActualRates(ctx context.Context, titles []string) (_ []entities.Coin, err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "Client.GetActualRates",
+		trace.WithAttributes(attribute.StringSlice("coin.titles", titles), attribute.String("coin.cost_in", c.costIn)))
+	defer func() { endSpan(span, err) }()
+
+	c.logger.DebugContext(ctx, "Fetching actual coin rates", "titles", titles)
+
+	query := url.Values{}
+	query.Set(fsymsQuery, strings.Join(titles, ","))
+	query.Set(tsymsQuery, c.costIn)
 
-	u, err := url.Parse(fmt.Sprintf("%s%s", baseURL, priceMulti))
+	var result map[string]map[string]interface{}
+	err = c.fetch(ctx, priceMulti, query, func(body []byte) error {
+		// Requests for unknown coins are answered with 200 and an error document instead of prices.
+		var rejection providerError
+		if json.Unmarshal(body, &rejection) == nil && rejection.Response == "Error" {
+			c.logger.ErrorContext(ctx, "Provider rejected rates request", "titles", titles, "message", rejection.Message)
+			return errors.Wrapf(entities.ErrInvalidParam, "provider rejected rates request: %s", rejection.Message)
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			c.logger.ErrorContext(ctx, "Couldn't parse response body", "err", err)
+			return errors.Wrap(err, "couldn't parse response body")
+		}
+		return nil
+	})
 	if err != nil {
-		slog.Error("Failed to parse URL", "err", err)
-		return nil, errors.Wrap(err, "failed to parse URL")
+		return nil, err
+	}
+
+	coinResults := make([]entities.Coin, 0, len(result))
+	for title, priceMap := range result {
+		cost, exists := priceMap[c.costIn]
+		if !exists {
+			c.logger.InfoContext(ctx, "Price data missing for currency", "currency", title)
+			continue
+		}
+
+		floatCost, ok := cost.(float64)
+		if !ok {
+			c.logger.ErrorContext(ctx, "Unexpected format of price data", "data", cost)
+			continue
+		}
+
+		coin, err := entities.NewCoin(title, floatCost)
+		if err != nil {
+			c.logger.ErrorContext(ctx, "Failed to create coin entity", "err", err)
+			return nil, errors.Wrap(err, "failed to create coin entity")
+		}
+		coin.Source = Source
+		coinResults = append(coinResults, *coin)
 	}
 
-	q := u.Query()
-	q.Set(fsymsQuery, strings.Join(titles, ","))
-	q.Set(tsymsQuery, c.costIn)
+	c.logger.DebugContext(ctx, "Fetched coin rates successfully", "number_of_coins", len(coinResults))
 
-	u.RawQuery = q.Encode()
-	requestUrl := u.String()
+	return coinResults, nil
+}
+
+// providerError is the document the provider answers rejected requests with.
+type providerError struct {
+	Response string `json:"Response"`
+	Message  string `json:"Message"`
+}
+
+// fetch performs a GET request to endpoint and hands the response body to decode. Calls are refused
+// while the circuit is open, only transport errors, 5xx responses and unreadable bodies count against
+// the provider. Calls abandoned by the caller's context say nothing about the provider and are not counted.
+func (c *Client) fetch(ctx context.Context, endpoint string, query url.Values, decode func(body []byte) error) (err error) {
+	if !c.breaker.allow() {
+		c.logger.WarnContext(ctx, "Provider circuit is open, skipping request", "endpoint", endpoint)
+		return errors.Wrap(entities.ErrUnavailable, "provider circuit breaker is open")
+	}
+	callerCtx := ctx
+	providerHealthy := false
+	defer func() {
+		if !providerHealthy && callerCtx.Err() != nil {
+			c.breaker.release()
+			return
+		}
+		c.breaker.record(providerHealthy)
+	}()
+
+	u, err := url.Parse(c.baseURL + endpoint)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "Failed to parse URL", "err", err)
+		return errors.Wrap(err, "failed to parse URL")
+	}
+	u.RawQuery = query.Encode()
 
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		slog.Error("Failed to build request", "err", err)
-		return nil, errors.Wrap(err, "failed to build request")
+		c.logger.ErrorContext(ctx, "Failed to build request", "err", err)
+		return errors.Wrap(err, "failed to build request")
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		slog.Error("HTTP request failed", "err", err)
-		return nil, errors.Wrap(err, "HTTP request failed")
+		c.logger.ErrorContext(ctx, "HTTP request failed", "err", err)
+		return errors.Wrap(err, "HTTP request failed")
 	}
 	defer func() {
 		if cerr := resp.Body.Close(); cerr != nil {
@@ -98,46 +231,35 @@ func (c *Client) GetActualRates(ctx context.Context, titles []string) ([]entitie
 
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		slog.Error("Failed to read response body", "err", err)
-		return nil, errors.Wrap(err, "failed to read response body")
+		c.logger.ErrorContext(ctx, "Failed to read response body", "err", err)
+		return errors.Wrap(err, "failed to read response body")
 	}
 
 	if resp.StatusCode >= http.StatusBadRequest {
+		providerHealthy = resp.StatusCode < http.StatusInternalServerError
 		message := string(bodyBytes)
-		slog.Error("API returned an error", "status_code", resp.StatusCode, "response_body", message)
-		return nil, errors.Errorf("API returned an error (%d): %s", resp.StatusCode, message)
+		c.logger.ErrorContext(ctx, "API returned an error", "status_code", resp.StatusCode, "response_body", message)
+		return errors.Errorf("API returned an error (%d): %s", resp.StatusCode, message)
 	}
 
-	var result map[string]map[string]interface{}
-	err = json.Unmarshal(bodyBytes, &result)
-	if err != nil {
-		slog.Error("Couldn't parse response body", "err", err)
-		return nil, errors.Wrap(err, "couldn't parse response body")
+	if err := decode(bodyBytes); err != nil {
+		// A request the provider rejected in a well-formed response says nothing about its health.
+		providerHealthy = errors.Is(err, entities.ErrInvalidParam)
+		return err
 	}
+	providerHealthy = true
+	return nil
+}
 
-	coinResults := make([]entities.Coin, 0, len(result))
-	for title, priceMap := range result {
-		cost, exists := priceMap[c.costIn]
-		if !exists {
-			slog.Info("Price data missing for currency", "currency", title)
-			continue
-		}
-
-		floatCost, ok := cost.(float64)
-		if !ok {
-			slog.Error("Unexpected format of price data", "data", cost)
-			continue
-		}
-
-		coin, err := entities.NewCoin(title, floatCost)
-		if err != nil {
-			slog.Error("Failed to create coin entity", "err", err)
-			return nil, errors.Wrap(err, "failed to create coin entity")
-		}
-		coinResults = append(coinResults, *coin)
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
+	span.End()
+}
 
-	slog.Info("Fetched coin rates successfully", "number_of_coins", len(coinResults))
-
-	return coinResults, nil
+// CircuitState reports whether calls to the provider are currently let through.
+func (c *Client) CircuitState() entities.CircuitState {
+	return c.breaker.state()
 }
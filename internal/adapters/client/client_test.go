@@ -0,0 +1,171 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/client"
+	"Cryptoproject/internal/entities"
+)
+
+// stubTransport answers every request with the current status and counts the calls. Like a real
+// transport it fails requests whose context is done.
+type stubTransport struct {
+	status atomic.Int32
+	calls  atomic.Int32
+	body   atomic.Pointer[string]
+}
+
+func (t *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls.Add(1)
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	body := `{"BTC":{"USD":50000}}`
+	if status := int(t.status.Load()); status != http.StatusOK {
+		body = "provider failure"
+	}
+	if override := t.body.Load(); override != nil {
+		body = *override
+	}
+	return &http.Response{
+		StatusCode: int(t.status.Load()),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+func setupClient(t *testing.T, status int, cooldown time.Duration) (*client.Client, *stubTransport) {
+	t.Helper()
+
+	transport := &stubTransport{}
+	transport.status.Store(int32(status))
+	c, err := client.NewClient(
+		client.WithHTTPClient(&http.Client{Transport: transport}),
+		client.WithCircuitBreaker(2, cooldown),
+	)
+	require.NoError(t, err)
+	return c, transport
+}
+
+func TestClient_GetActualRates(t *testing.T) {
+	t.Parallel()
+
+	c, _ := setupClient(t, http.StatusOK, time.Minute)
+
+	coins, err := c.GetActualRates(context.Background(), []string{"BTC"})
+
+	require.NoError(t, err)
+	require.Equal(t, []entities.Coin{{Title: "BTC", Cost: 50000, Source: client.Source}}, coins)
+	require.Equal(t, entities.CircuitClosed, c.CircuitState())
+}
+
+func TestClient_CircuitOpensAfterFailures(t *testing.T) {
+	t.Parallel()
+
+	c, transport := setupClient(t, http.StatusBadGateway, time.Minute)
+
+	for range 2 {
+		_, err := c.GetActualRates(context.Background(), []string{"BTC"})
+		require.Error(t, err)
+	}
+	require.Equal(t, entities.CircuitOpen, c.CircuitState())
+
+	_, err := c.GetActualRates(context.Background(), []string{"BTC"})
+	require.ErrorIs(t, err, entities.ErrUnavailable)
+	require.Equal(t, int32(2), transport.calls.Load())
+}
+
+func TestClient_ClientErrorsKeepCircuitClosed(t *testing.T) {
+	t.Parallel()
+
+	c, _ := setupClient(t, http.StatusBadRequest, time.Minute)
+
+	for range 3 {
+		_, err := c.GetActualRates(context.Background(), []string{"BTC"})
+		require.Error(t, err)
+		require.NotErrorIs(t, err, entities.ErrUnavailable)
+	}
+	require.Equal(t, entities.CircuitClosed, c.CircuitState())
+}
+
+func TestClient_UnknownCoinsKeepCircuitClosed(t *testing.T) {
+	t.Parallel()
+
+	c, transport := setupClient(t, http.StatusOK, time.Minute)
+	rejection := `{"Response":"Error","Message":"cccagg_or_exchange market does not exist for this coin pair (XYZ-USD)","Type":2}`
+	transport.body.Store(&rejection)
+
+	for range 3 {
+		_, err := c.GetActualRates(context.Background(), []string{"XYZ"})
+		require.ErrorIs(t, err, entities.ErrInvalidParam)
+		require.ErrorContains(t, err, "market does not exist")
+	}
+	require.Equal(t, entities.CircuitClosed, c.CircuitState(), "rejected coins say nothing about the provider health")
+}
+
+func TestClient_CanceledCallsKeepCircuitClosed(t *testing.T) {
+	t.Parallel()
+
+	c, transport := setupClient(t, http.StatusOK, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for range 3 {
+		_, err := c.GetActualRates(ctx, []string{"BTC"})
+		require.ErrorIs(t, err, context.Canceled)
+	}
+	require.Equal(t, int32(3), transport.calls.Load())
+	require.Equal(t, entities.CircuitClosed, c.CircuitState(), "calls abandoned by the caller are not provider failures")
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	for range 3 {
+		_, err := c.GetActualRates(expired, []string{"BTC"})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+	require.Equal(t, entities.CircuitClosed, c.CircuitState())
+}
+
+func TestClient_CircuitRecoversAfterCooldown(t *testing.T) {
+	t.Parallel()
+
+	c, transport := setupClient(t, http.StatusServiceUnavailable, 20*time.Millisecond)
+
+	for range 2 {
+		_, _ = c.GetActualRates(context.Background(), []string{"BTC"})
+	}
+	require.Equal(t, entities.CircuitOpen, c.CircuitState())
+
+	time.Sleep(30 * time.Millisecond)
+	require.Equal(t, entities.CircuitHalfOpen, c.CircuitState())
+
+	// A failed trial call opens the circuit again right away.
+	_, err := c.GetActualRates(context.Background(), []string{"BTC"})
+	require.NotErrorIs(t, err, entities.ErrUnavailable)
+	require.Equal(t, entities.CircuitOpen, c.CircuitState())
+
+	time.Sleep(30 * time.Millisecond)
+	transport.status.Store(http.StatusOK)
+	_, err = c.GetActualRates(context.Background(), []string{"BTC"})
+	require.NoError(t, err)
+	require.Equal(t, entities.CircuitClosed, c.CircuitState())
+}
+
+func TestNewClient_InvalidBreaker(t *testing.T) {
+	t.Parallel()
+
+	_, err := client.NewClient(client.WithCircuitBreaker(0, time.Minute))
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = client.NewClient(client.WithCircuitBreaker(1, 0))
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	histoHour = "v2/histohour"
+	histoDay  = "v2/histoday"
+	fsymQuery = "fsym"
+	tsymQuery = "tsym"
+	// maxHistoryPoints is the largest page the history endpoints return.
+	maxHistoryPoints = 2000
+)
+
+var historyEndpoints = map[entities.Resolution]string{
+	entities.ResolutionHour: histoHour,
+	entities.ResolutionDay:  histoDay,
+}
+
+type historyResponse struct {
+	Response string `json:"Response"`
+	Message  string `json:"Message"`
+	Data     struct {
+		TimeFrom int64          `json:"TimeFrom"`
+		TimeTo   int64          `json:"TimeTo"`
+		Data     []historyPoint `json:"Data"`
+	} `json:"Data"`
+}
+
+type historyPoint struct {
+	Time  int64   `json:"time"`
+	Close float64 `json:"close"`
+}
+
+// GetHistoricalRates returns the close rate of every bucket starting in [from, to), oldest first. The
+// range is fetched backwards from to in pages of at most 2000 buckets, buckets without trading are left out.
+func (c *Client) GetHistoricalRates(ctx context.Context, title string, resolution entities.Resolution, from, to time.Time) (_ []entities.Coin, err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "Client.GetHistoricalRates",
+		trace.WithAttributes(attribute.String("coin.title", title), attribute.String("coin.resolution", string(resolution))))
+	defer func() { endSpan(span, err) }()
+
+	endpoint, exists := historyEndpoints[resolution]
+	if !exists {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "unsupported resolution %q", resolution)
+	}
+	if title == "" {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "title cannot be empty")
+	}
+	if !from.Before(to) {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "from must be before to")
+	}
+
+	c.logger.DebugContext(ctx, "Fetching historical coin rates", "title", title, "resolution", resolution, "from", from, "to", to)
+
+	bucket := resolution.Bucket()
+	first := from.Truncate(bucket)
+	last := to.Add(-time.Nanosecond).Truncate(bucket)
+
+	var pages [][]entities.Coin
+	total := 0
+	for !last.Before(first) {
+		limit := int(last.Sub(first) / bucket)
+		if limit > maxHistoryPoints {
+			limit = maxHistoryPoints
+		}
+		page, err := c.fetchHistoryPage(ctx, endpoint, title, last, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(page.Data.Data) == 0 {
+			break
+		}
+
+		coins := make([]entities.Coin, 0, len(page.Data.Data))
+		for _, point := range page.Data.Data {
+			actualAt := time.Unix(point.Time, 0).UTC()
+			if point.Close <= 0 || actualAt.Before(from) || !actualAt.Before(to) {
+				continue
+			}
+			coins = append(coins, entities.Coin{Title: title, Cost: point.Close, ActualAt: actualAt, Source: Source})
+		}
+		pages = append(pages, coins)
+		total += len(coins)
+
+		last = time.Unix(page.Data.TimeFrom, 0).UTC().Add(-bucket)
+	}
+
+	result := make([]entities.Coin, 0, total)
+	for i := len(pages) - 1; i >= 0; i-- {
+		result = append(result, pages[i]...)
+	}
+
+	c.logger.DebugContext(ctx, "Fetched historical coin rates successfully", "title", title, "number_of_coins", len(result))
+	return result, nil
+}
+
+// fetchHistoryPage requests the limit+1 buckets ending with the one starting at last.
+func (c *Client) fetchHistoryPage(ctx context.Context, endpoint, title string, last time.Time, limit int) (*historyResponse, error) {
+	query := url.Values{}
+	query.Set(fsymQuery, title)
+	query.Set(tsymQuery, c.costIn)
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("toTs", strconv.FormatInt(last.Unix(), 10))
+
+	page := &historyResponse{}
+	err := c.fetch(ctx, endpoint, query, func(body []byte) error {
+		if err := json.Unmarshal(body, page); err != nil {
+			c.logger.ErrorContext(ctx, "Couldn't parse response body", "err", err)
+			return errors.Wrap(err, "couldn't parse response body")
+		}
+		if page.Response == "Error" {
+			c.logger.ErrorContext(ctx, "Provider rejected history request", "title", title, "message", page.Message)
+			return errors.Wrapf(entities.ErrInvalidParam, "provider rejected history request: %s", page.Message)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
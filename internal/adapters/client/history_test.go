@@ -0,0 +1,142 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/client"
+	"Cryptoproject/internal/entities"
+)
+
+// historyServer stands in for the CryptoCompare histohour and histoday endpoints. Buckets before
+// listedAt have no trading and are answered with a zero close, like the real API does.
+type historyServer struct {
+	listedAt time.Time
+	pages    atomic.Int32
+}
+
+func (s *historyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket := time.Hour
+	if r.URL.Path == "/v2/histoday" {
+		bucket = 24 * time.Hour
+	} else if r.URL.Path != "/v2/histohour" {
+		http.NotFound(w, r)
+		return
+	}
+	s.pages.Add(1)
+
+	query := r.URL.Query()
+	if query.Get("fsym") == "UNKNOWN" {
+		_ = json.NewEncoder(w).Encode(map[string]any{"Response": "Error", "Message": "fsym UNKNOWN does not exist", "Data": map[string]any{}})
+		return
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	toTs, _ := strconv.ParseInt(query.Get("toTs"), 10, 64)
+
+	to := time.Unix(toTs, 0).UTC()
+	from := to.Add(-time.Duration(limit) * bucket)
+	points := make([]map[string]any, 0, limit+1)
+	for at := from; !at.After(to); at = at.Add(bucket) {
+		closeRate := 0.0
+		if !at.Before(s.listedAt) {
+			closeRate = float64(at.Unix()) / 1000
+		}
+		points = append(points, map[string]any{"time": at.Unix(), "close": closeRate, "open": closeRate})
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"Response": "Success",
+		"Data":     map[string]any{"TimeFrom": from.Unix(), "TimeTo": to.Unix(), "Data": points},
+	})
+}
+
+func setupHistoryClient(t *testing.T, listedAt time.Time) (*client.Client, *historyServer) {
+	t.Helper()
+
+	handler := &historyServer{listedAt: listedAt}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(
+		client.WithBaseURL(server.URL+"/"),
+		client.WithHTTPClient(server.Client()),
+		client.WithCircuitBreaker(2, time.Minute),
+	)
+	require.NoError(t, err)
+	return c, handler
+}
+
+func TestClient_GetHistoricalRates_Pages(t *testing.T) {
+	t.Parallel()
+
+	c, server := setupHistoryClient(t, time.Time{})
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2500 * time.Hour)
+
+	coins, err := c.GetHistoricalRates(context.Background(), "BTC", entities.ResolutionHour, from, to)
+
+	require.NoError(t, err)
+	require.Len(t, coins, 2500)
+	require.Equal(t, int32(2), server.pages.Load())
+	for i, coin := range coins {
+		actualAt := from.Add(time.Duration(i) * time.Hour)
+		require.Equal(t, entities.Coin{Title: "BTC", Cost: float64(actualAt.Unix()) / 1000, ActualAt: actualAt, Source: client.Source}, coin)
+	}
+}
+
+func TestClient_GetHistoricalRates_SkipsBucketsWithoutTrading(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	listedAt := from.Add(5 * 24 * time.Hour)
+	c, _ := setupHistoryClient(t, listedAt)
+
+	coins, err := c.GetHistoricalRates(context.Background(), "BTC", entities.ResolutionDay, from, from.Add(10*24*time.Hour))
+
+	require.NoError(t, err)
+	require.Len(t, coins, 5)
+	require.Equal(t, listedAt, coins[0].ActualAt)
+}
+
+func TestClient_GetHistoricalRates_Rejected(t *testing.T) {
+	t.Parallel()
+
+	c, _ := setupHistoryClient(t, time.Time{})
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for range 3 {
+		_, err := c.GetHistoricalRates(context.Background(), "UNKNOWN", entities.ResolutionHour, from, from.Add(time.Hour))
+		require.ErrorIs(t, err, entities.ErrInvalidParam)
+	}
+	require.Equal(t, entities.CircuitClosed, c.CircuitState())
+}
+
+func TestClient_GetHistoricalRates_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	c, server := setupHistoryClient(t, time.Time{})
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := c.GetHistoricalRates(context.Background(), "BTC", entities.Resolution("minute"), from, from.Add(time.Hour))
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = c.GetHistoricalRates(context.Background(), "BTC", entities.ResolutionHour, from, from)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	require.Zero(t, server.pages.Load())
+}
+
+func TestNewClient_InvalidBaseURL(t *testing.T) {
+	t.Parallel()
+
+	_, err := client.NewClient(client.WithBaseURL("localhost"))
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
@@ -0,0 +1,277 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	defaultRefreshInterval = time.Minute
+	maxDocumentSize        = 1 << 20
+)
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// KeySet holds the public signing keys of a JSON Web Key Set loaded from a file or an HTTP URL.
+// Keys loaded from a URL are fetched again when an unknown key ID is requested, at most once per
+// refresh interval, so that key rotation does not require a restart.
+type KeySet struct {
+	source          string
+	httpClient      *http.Client
+	refreshInterval time.Duration
+	logger          *slog.Logger
+
+	mu          sync.RWMutex
+	keys        map[string]any
+	lastRefresh time.Time
+}
+
+type KeySetOption func(*KeySet)
+
+func WithHTTPClient(httpClient *http.Client) KeySetOption {
+	return func(ks *KeySet) {
+		ks.httpClient = httpClient
+	}
+}
+
+func WithRefreshInterval(interval time.Duration) KeySetOption {
+	return func(ks *KeySet) {
+		ks.refreshInterval = interval
+	}
+}
+
+func WithLogger(logger *slog.Logger) KeySetOption {
+	return func(ks *KeySet) {
+		ks.logger = logger
+	}
+}
+
+// NewKeySet loads the key set from source, an http(s) URL or a file path optionally prefixed with file://.
+func NewKeySet(ctx context.Context, source string, opts ...KeySetOption) (*KeySet, error) {
+	if source == "" {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "JWKS source cannot be empty")
+	}
+
+	ks := &KeySet{
+		source:          source,
+		httpClient:      http.DefaultClient,
+		refreshInterval: defaultRefreshInterval,
+		logger:          slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(ks)
+	}
+
+	if ks.httpClient == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "HTTP client cannot be nil")
+	}
+	if ks.logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "logger cannot be nil")
+	}
+
+	if err := ks.refresh(ctx); err != nil {
+		return nil, err
+	}
+	ks.lastRefresh = time.Now()
+
+	ks.logger.Info("JWKS loaded", "source", source, "number_of_keys", len(ks.keys))
+	return ks, nil
+}
+
+// Key returns the public key with the given ID. An empty ID matches the only key of a single-key set.
+func (ks *KeySet) Key(ctx context.Context, kid string) (any, error) {
+	if key, found := ks.lookup(kid); found {
+		return key, nil
+	}
+
+	if ks.isRemote() && ks.claimRefresh() {
+		if err := ks.refresh(ctx); err != nil {
+			ks.logger.Error("Failed to refresh JWKS", "source", ks.source, "err", err)
+		} else if key, found := ks.lookup(kid); found {
+			return key, nil
+		}
+	}
+
+	return nil, errors.Wrapf(entities.ErrUnauthorized, "signing key %q is unknown", kid)
+}
+
+func (ks *KeySet) lookup(kid string) (any, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, true
+		}
+	}
+	key, found := ks.keys[kid]
+	return key, found
+}
+
+// claimRefresh reports whether the caller may refetch the key set, so that tokens signed with unknown
+// keys cannot make the server fetch the key set on every request.
+func (ks *KeySet) claimRefresh() bool {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if time.Since(ks.lastRefresh) < ks.refreshInterval {
+		return false
+	}
+	ks.lastRefresh = time.Now()
+	return true
+}
+
+func (ks *KeySet) isRemote() bool {
+	return strings.HasPrefix(ks.source, "http://") || strings.HasPrefix(ks.source, "https://")
+}
+
+func (ks *KeySet) refresh(ctx context.Context) error {
+	data, err := ks.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := ks.parse(data)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.mu.Unlock()
+
+	return nil
+}
+
+func (ks *KeySet) read(ctx context.Context) ([]byte, error) {
+	if !ks.isRemote() {
+		data, err := os.ReadFile(strings.TrimPrefix(ks.source, "file://"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read JWKS file")
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.source, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create JWKS request")
+	}
+
+	resp, err := ks.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch JWKS")
+	}
+	defer resp.Body.Close() //nolint:errcheck //ok
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read JWKS response")
+	}
+	return data, nil
+}
+
+func (ks *KeySet) parse(data []byte) (map[string]any, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "malformed JWKS: %v", err)
+	}
+
+	keys := make(map[string]any, len(document.Keys))
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			ks.logger.Warn("Skipping unsupported JWK", "kid", jwk.Kid, "kty", jwk.Kty, "err", err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "JWKS contains no usable signing keys")
+	}
+	return keys, nil
+}
+
+func (jwk jsonWebKey) publicKey() (any, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := decodeInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, errors.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
+
+func decodeInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,179 @@
+package jwks_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/jwks"
+	"Cryptoproject/internal/entities"
+)
+
+func encodeInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func rsaJWK(t *testing.T, kid string) (map[string]string, *rsa.PublicKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   encodeInt(key.N),
+		"e":   encodeInt(big.NewInt(int64(key.E))),
+	}, &key.PublicKey
+}
+
+func ecJWK(t *testing.T, kid string) (map[string]string, *ecdsa.PublicKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   encodeInt(key.X),
+		"y":   encodeInt(key.Y),
+	}, &key.PublicKey
+}
+
+func document(t *testing.T, keys ...map[string]string) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+	return data
+}
+
+func TestKeySet_FromFile(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, rsaPublic := rsaJWK(t, "rsa-1")
+	ecKey, ecPublic := ecJWK(t, "ec-1")
+	encryptionKey, _ := rsaJWK(t, "enc-1")
+	encryptionKey["use"] = "enc"
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, document(t, rsaKey, ecKey, encryptionKey), 0o600))
+
+	ks, err := jwks.NewKeySet(context.Background(), "file://"+path)
+	require.NoError(t, err)
+
+	key, err := ks.Key(context.Background(), "rsa-1")
+	require.NoError(t, err)
+	require.True(t, rsaPublic.Equal(key))
+
+	key, err = ks.Key(context.Background(), "ec-1")
+	require.NoError(t, err)
+	require.True(t, ecPublic.Equal(key))
+
+	_, err = ks.Key(context.Background(), "enc-1")
+	require.ErrorIs(t, err, entities.ErrUnauthorized)
+
+	_, err = ks.Key(context.Background(), "")
+	require.ErrorIs(t, err, entities.ErrUnauthorized)
+}
+
+func TestKeySet_SingleKeyMatchesEmptyKid(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, rsaPublic := rsaJWK(t, "")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, document(t, rsaKey), 0o600))
+
+	ks, err := jwks.NewKeySet(context.Background(), path)
+	require.NoError(t, err)
+
+	key, err := ks.Key(context.Background(), "")
+	require.NoError(t, err)
+	require.True(t, rsaPublic.Equal(key))
+}
+
+func TestKeySet_InvalidSource(t *testing.T) {
+	t.Parallel()
+
+	_, err := jwks.NewKeySet(context.Background(), "")
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`), 0o600))
+	_, err = jwks.NewKeySet(context.Background(), path)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = jwks.NewKeySet(context.Background(), filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestKeySet_FromURLRefreshesOnRotation(t *testing.T) {
+	t.Parallel()
+
+	oldKey, _ := rsaJWK(t, "old")
+	newKey, newPublic := rsaJWK(t, "new")
+
+	var rotated atomic.Bool
+	var fetches atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		if rotated.Load() {
+			_, _ = w.Write(document(t, newKey))
+			return
+		}
+		_, _ = w.Write(document(t, oldKey))
+	}))
+	t.Cleanup(ts.Close)
+
+	ks, err := jwks.NewKeySet(context.Background(), ts.URL, jwks.WithRefreshInterval(0))
+	require.NoError(t, err)
+
+	_, err = ks.Key(context.Background(), "old")
+	require.NoError(t, err)
+	require.Equal(t, int32(1), fetches.Load())
+
+	rotated.Store(true)
+	key, err := ks.Key(context.Background(), "new")
+	require.NoError(t, err)
+	require.True(t, newPublic.Equal(key))
+	require.Equal(t, int32(2), fetches.Load())
+}
+
+func TestKeySet_RefreshIsThrottled(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, _ := rsaJWK(t, "only")
+
+	var fetches atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		_, _ = w.Write(document(t, rsaKey))
+	}))
+	t.Cleanup(ts.Close)
+
+	ks, err := jwks.NewKeySet(context.Background(), ts.URL, jwks.WithRefreshInterval(time.Hour))
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, err = ks.Key(context.Background(), "unknown")
+		require.ErrorIs(t, err, entities.ErrUnauthorized)
+	}
+	require.Equal(t, int32(1), fetches.Load())
+}
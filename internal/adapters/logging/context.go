@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type attrsKey struct{}
+
+// WithAttrs returns a context whose log records carry the given attributes in addition to those
+// already attached to ctx. Arguments follow the key-value convention of slog.Logger.With.
+func WithAttrs(ctx context.Context, args ...any) context.Context {
+	record := slog.NewRecord(time.Time{}, 0, "", 0)
+	record.Add(args...)
+
+	attrs := append([]slog.Attr(nil), attrsFromContext(ctx)...)
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	return context.WithValue(ctx, attrsKey{}, attrs)
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// ContextHandler adds the attributes stored by WithAttrs to records logged with a context,
+// such as by slog.InfoContext.
+type ContextHandler struct {
+	slog.Handler
+}
+
+func NewContextHandler(handler slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: handler}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs := attrsFromContext(ctx); len(attrs) > 0 {
+		record = record.Clone()
+		record.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,50 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/logging"
+)
+
+func TestContextHandler(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, nil))).With("component", "test")
+
+	ctx := logging.WithAttrs(context.Background(), "request_id", "req-1")
+	ctx = logging.WithAttrs(ctx, "key_id", 7)
+	logger.InfoContext(ctx, "handled")
+	logger.Info("without context")
+
+	decoder := json.NewDecoder(&buf)
+	var withCtx, withoutCtx map[string]any
+	require.NoError(t, decoder.Decode(&withCtx))
+	require.NoError(t, decoder.Decode(&withoutCtx))
+
+	require.Equal(t, "req-1", withCtx["request_id"])
+	require.Equal(t, float64(7), withCtx["key_id"])
+	require.Equal(t, "test", withCtx["component"])
+	require.NotContains(t, withoutCtx, "request_id")
+}
+
+func TestWithAttrs_DoesNotLeakIntoParent(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+
+	parent := logging.WithAttrs(context.Background(), "request_id", "req-1")
+	_ = logging.WithAttrs(parent, "extra", true)
+	logger.InfoContext(parent, "parent")
+
+	var record map[string]any
+	require.NoError(t, json.NewDecoder(&buf).Decode(&record))
+	require.NotContains(t, record, "extra")
+}
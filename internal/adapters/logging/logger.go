@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+
+	defaultLevel = "info"
+)
+
+type loggerConfig struct {
+	level    string
+	levelVar *slog.LevelVar
+	format   string
+	output   io.Writer
+}
+
+type LoggerOption func(*loggerConfig)
+
+// WithLevel sets the minimum level, one of "debug", "info", "warn" or "error".
+func WithLevel(level string) LoggerOption {
+	return func(c *loggerConfig) {
+		c.level = level
+	}
+}
+
+// WithLevelVar makes the logger read its minimum level from levelVar, so that it can be changed at runtime.
+// The variable is set to the level given by WithLevel.
+func WithLevelVar(levelVar *slog.LevelVar) LoggerOption {
+	return func(c *loggerConfig) {
+		c.levelVar = levelVar
+	}
+}
+
+// WithFormat selects FormatText or FormatJSON output.
+func WithFormat(format string) LoggerOption {
+	return func(c *loggerConfig) {
+		c.format = format
+	}
+}
+
+func WithOutput(output io.Writer) LoggerOption {
+	return func(c *loggerConfig) {
+		c.output = output
+	}
+}
+
+// NewLogger builds the application logger. Records carry the attributes attached to their context by WithAttrs
+// and secrets are redacted before they are written.
+func NewLogger(opts ...LoggerOption) (*slog.Logger, error) {
+	c := &loggerConfig{
+		level:    defaultLevel,
+		levelVar: new(slog.LevelVar),
+		format:   FormatText,
+		output:   os.Stdout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	level, err := ParseLevel(c.level)
+	if err != nil {
+		return nil, err
+	}
+	if c.levelVar == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "level variable cannot be nil")
+	}
+	c.levelVar.Set(level)
+	if c.output == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "log output cannot be nil")
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level:       c.levelVar,
+		ReplaceAttr: redactAttr,
+	}
+
+	var handler slog.Handler
+	switch strings.ToLower(c.format) {
+	case FormatText, "":
+		handler = slog.NewTextHandler(c.output, handlerOpts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(c.output, handlerOpts)
+	default:
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "unknown log format %q", c.format)
+	}
+
+	return slog.New(NewContextHandler(handler)), nil
+}
+
+// ParseLevel parses a level name as accepted by WithLevel, an empty name is the default level.
+func ParseLevel(name string) (slog.Level, error) {
+	if name == "" {
+		name = defaultLevel
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, errors.Wrapf(entities.ErrInvalidParam, "unknown log level %q", name)
+	}
+	return level, nil
+}
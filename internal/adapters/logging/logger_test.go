@@ -0,0 +1,127 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/logging"
+	"Cryptoproject/internal/entities"
+)
+
+func TestNewLogger_LevelAndFormat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := logging.NewLogger(
+		logging.WithLevel("warn"),
+		logging.WithFormat(logging.FormatJSON),
+		logging.WithOutput(&buf),
+	)
+	require.NoError(t, err)
+
+	logger.Info("dropped")
+	logger.WarnContext(logging.WithAttrs(context.Background(), "request_id", "req-1"), "kept")
+
+	var record map[string]any
+	require.NoError(t, json.NewDecoder(&buf).Decode(&record))
+	require.Equal(t, "kept", record["msg"])
+	require.Equal(t, "WARN", record["level"])
+	require.Equal(t, "req-1", record["request_id"])
+	require.Zero(t, buf.Len())
+}
+
+func TestNewLogger_Text(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := logging.NewLogger(logging.WithLevel("debug"), logging.WithOutput(&buf))
+	require.NoError(t, err)
+
+	logger.Debug("visible", "number_of_coins", 2)
+	require.Contains(t, buf.String(), "level=DEBUG")
+	require.Contains(t, buf.String(), "number_of_coins=2")
+}
+
+func TestNewLogger_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	for name, opt := range map[string]logging.LoggerOption{
+		"level":  logging.WithLevel("verbose"),
+		"format": logging.WithFormat("xml"),
+		"output": logging.WithOutput(nil),
+	} {
+		_, err := logging.NewLogger(opt)
+		require.ErrorIs(t, err, entities.ErrInvalidParam, name)
+	}
+}
+
+func TestNewLogger_Redaction(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := logging.NewLogger(logging.WithFormat(logging.FormatJSON), logging.WithOutput(&buf))
+	require.NoError(t, err)
+
+	logger.Info("connecting",
+		"conn_str", "postgres://user:s3cret@db:5432/coins?sslmode=disable",
+		"dsn", "host=db user=user password=s3cret dbname=coins",
+		"admin_token", "change-me",
+		"api_key", 42,
+		"err", errors.New("dial postgres://user:s3cret@db:5432/coins: refused"),
+		"title", "BTC",
+	)
+
+	output := buf.String()
+	require.NotContains(t, output, "s3cret")
+	require.NotContains(t, output, "change-me")
+
+	var record map[string]any
+	require.NoError(t, json.NewDecoder(strings.NewReader(output)).Decode(&record))
+	require.Equal(t, "postgres://user:[REDACTED]@db:5432/coins?sslmode=disable", record["conn_str"])
+	require.Equal(t, "host=db user=user password=[REDACTED] dbname=coins", record["dsn"])
+	require.Equal(t, "[REDACTED]", record["admin_token"])
+	require.Equal(t, "[REDACTED]", record["api_key"])
+	require.Equal(t, "BTC", record["title"])
+}
+
+func TestRedactDSN(t *testing.T) {
+	t.Parallel()
+
+	for input, expected := range map[string]string{
+		"postgres://user:pass@db:5432/coins":      "postgres://user:[REDACTED]@db:5432/coins",
+		"postgres://user@db:5432/coins":           "postgres://user@db:5432/coins",
+		"host=db password='p a s s' dbname=coins": "host=db password=[REDACTED] dbname=coins",
+		"nothing to hide":                         "nothing to hide",
+	} {
+		require.Equal(t, expected, logging.RedactDSN(input), input)
+	}
+}
+
+func TestNewLogger_LevelVar(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	levelVar := new(slog.LevelVar)
+	logger, err := logging.NewLogger(logging.WithLevel("error"), logging.WithLevelVar(levelVar), logging.WithOutput(&buf))
+	require.NoError(t, err)
+	require.Equal(t, slog.LevelError, levelVar.Level())
+
+	logger.Info("dropped")
+	require.Zero(t, buf.Len())
+
+	level, err := logging.ParseLevel("debug")
+	require.NoError(t, err)
+	levelVar.Set(level)
+	logger.Debug("kept")
+	require.Contains(t, buf.String(), "msg=kept")
+
+	_, err = logging.ParseLevel("loud")
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
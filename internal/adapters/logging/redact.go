@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute keys, or key suffixes after an underscore, whose values are never logged.
+var sensitiveKeys = []string{"password", "pswd", "secret", "token", "authorization", "api_key", "cookie"}
+
+var (
+	// urlPassword matches the password in the user info of a URL such as "postgres://user:secret@db:5432".
+	urlPassword = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://[^:/@\s]*:)[^@\s]*@`)
+	// dsnPassword matches the password of a key/value connection string such as "host=db password=secret".
+	dsnPassword = regexp.MustCompile(`(?i)(password\s*=\s*)('[^']*'|\S+)`)
+)
+
+// redactAttr drops the values of sensitive keys and masks passwords embedded in strings and errors,
+// such as a connection string logged by mistake or quoted in a driver error.
+func redactAttr(_ []string, attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() == slog.KindGroup {
+		return attr
+	}
+	if isSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		if value := attr.Value.String(); mayContainPassword(value) {
+			return slog.String(attr.Key, RedactDSN(value))
+		}
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok && mayContainPassword(err.Error()) {
+			return slog.String(attr.Key, RedactDSN(err.Error()))
+		}
+	}
+	return attr
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if key == sensitive || strings.HasSuffix(key, "_"+sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func mayContainPassword(value string) bool {
+	return strings.Contains(value, "@") || strings.Contains(strings.ToLower(value), "password")
+}
+
+// RedactDSN masks passwords of URL and key/value connection strings found in text, other text is returned unchanged.
+func RedactDSN(text string) string {
+	text = urlPassword.ReplaceAllString(text, "${1}"+redacted+"@")
+	return dsnPassword.ReplaceAllString(text, "${1}"+redacted)
+}
@@ -0,0 +1,192 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"Cryptoproject/internal/entities"
+)
+
+const defaultNamespace = "cryptoproject"
+
+// Metrics owns a Prometheus registry with the application metrics and the Go runtime and process collectors.
+type Metrics struct {
+	namespace string
+	registry  *prometheus.Registry
+
+	httpRequests      *prometheus.CounterVec
+	httpDuration      *prometheus.HistogramVec
+	providerDuration  *prometheus.HistogramVec
+	providerErrors    *prometheus.CounterVec
+	updateDuration    prometheus.Histogram
+	updateFailures    prometheus.Counter
+	updateLastSuccess prometheus.Gauge
+	cacheLookups      *prometheus.CounterVec
+	qualityIssues     *prometheus.GaugeVec
+	qualityLastScan   prometheus.Gauge
+}
+
+type MetricsOption func(*Metrics)
+
+func WithNamespace(namespace string) MetricsOption {
+	return func(m *Metrics) {
+		m.namespace = namespace
+	}
+}
+
+func NewMetrics(opts ...MetricsOption) (*Metrics, error) {
+	m := &Metrics{
+		namespace: defaultNamespace,
+		registry:  prometheus.NewRegistry(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if m.namespace == "" {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "namespace cannot be empty")
+	}
+
+	m.httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "status"})
+	m.httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: m.namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of HTTP requests by route, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+	m.providerDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: m.namespace,
+		Name:      "provider_request_duration_seconds",
+		Help:      "Duration of rate provider calls by provider.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider"})
+	m.providerErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "provider_errors_total",
+		Help:      "Number of failed rate provider calls by provider.",
+	}, []string{"provider"})
+	m.updateDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: m.namespace,
+		Name:      "update_rates_duration_seconds",
+		Help:      "Duration of scheduled rate updates.",
+		Buckets:   prometheus.DefBuckets,
+	})
+	m.updateFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "update_rates_failures_total",
+		Help:      "Number of failed scheduled rate updates.",
+	})
+	m.updateLastSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: m.namespace,
+		Name:      "update_rates_last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful rate update.",
+	})
+	m.cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "http_cache_lookups_total",
+		Help:      "Number of conditional GET lookups by result, a hit is answered with 304 Not Modified.",
+	}, []string{"result"})
+
+	m.qualityIssues = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: m.namespace,
+		Name:      "data_quality_issues",
+		Help:      "Number of stored rate issues by coin and kind found by the last data quality scan.",
+	}, []string{"title", "kind"})
+	m.qualityLastScan = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: m.namespace,
+		Name:      "data_quality_last_scan_timestamp_seconds",
+		Help:      "Unix time of the last data quality scan.",
+	})
+
+	err := registerAll(m.registry,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.providerDuration,
+		m.providerErrors,
+		m.updateDuration,
+		m.updateFailures,
+		m.updateLastSuccess,
+		m.cacheLookups,
+		m.qualityIssues,
+		m.qualityLastScan,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveHTTPRequest(route, method string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(route, method, statusLabel).Inc()
+	m.httpDuration.WithLabelValues(route, method, statusLabel).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveProviderCall(provider string, duration time.Duration, err error) {
+	m.providerDuration.WithLabelValues(provider).Observe(duration.Seconds())
+	if err != nil {
+		m.providerErrors.WithLabelValues(provider).Inc()
+	}
+}
+
+func (m *Metrics) ObserveUpdateRates(duration time.Duration, err error) {
+	m.updateDuration.Observe(duration.Seconds())
+	if err != nil {
+		m.updateFailures.Inc()
+		return
+	}
+	m.updateLastSuccess.SetToCurrentTime()
+}
+
+func (m *Metrics) ObserveCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheLookups.WithLabelValues(result).Inc()
+}
+
+// ObserveQualityReport replaces the issue counts of the previous scan with those of the report. Every kind
+// is reported for a coin with issues, so that a resolved kind drops to zero instead of keeping its last value.
+func (m *Metrics) ObserveQualityReport(report *entities.QualityReport) {
+	m.qualityIssues.Reset()
+	for title, counts := range report.Counts() {
+		for _, kind := range entities.QualityIssueKinds {
+			m.qualityIssues.WithLabelValues(title, string(kind)).Set(float64(counts[kind]))
+		}
+	}
+	m.qualityLastScan.SetToCurrentTime()
+}
+
+// RegisterPool exposes the statistics of a pgx connection pool.
+func (m *Metrics) RegisterPool(stat func() *pgxpool.Stat) error {
+	return registerAll(m.registry, newPoolCollector(m.namespace, stat))
+}
+
+func registerAll(registry *prometheus.Registry, cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		if err := registry.Register(c); err != nil {
+			return errors.Wrap(err, "failed to register metrics collector")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/metrics"
+	"Cryptoproject/internal/entities"
+)
+
+type stubProvider struct {
+	err error
+}
+
+func (p stubProvider) GetActualRates(_ context.Context, titles []string) ([]entities.Coin, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	coins := make([]entities.Coin, len(titles))
+	for i, title := range titles {
+		coins[i] = entities.Coin{Title: title, Cost: 1}
+	}
+	return coins, nil
+}
+
+func (p stubProvider) GetHistoricalRates(_ context.Context, title string, _ entities.Resolution, from, _ time.Time) ([]entities.Coin, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []entities.Coin{{Title: title, Cost: 1, ActualAt: from}}, nil
+}
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestNewMetrics_EmptyNamespace(t *testing.T) {
+	t.Parallel()
+
+	_, err := metrics.NewMetrics(metrics.WithNamespace(""))
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestMetrics_HTTPAndCache(t *testing.T) {
+	t.Parallel()
+
+	m, err := metrics.NewMetrics()
+	require.NoError(t, err)
+
+	m.ObserveHTTPRequest("/v1/rates/last", http.MethodGet, http.StatusOK, 10*time.Millisecond)
+	m.ObserveHTTPRequest("/v1/rates/last", http.MethodGet, http.StatusOK, 20*time.Millisecond)
+	m.ObserveCacheLookup(true)
+	m.ObserveCacheLookup(false)
+	m.ObserveCacheLookup(false)
+
+	body := scrape(t, m)
+	require.Contains(t, body, `cryptoproject_http_requests_total{method="GET",route="/v1/rates/last",status="200"} 2`)
+	require.Contains(t, body, `cryptoproject_http_request_duration_seconds_count{method="GET",route="/v1/rates/last",status="200"} 2`)
+	require.Contains(t, body, `cryptoproject_http_cache_lookups_total{result="hit"} 1`)
+	require.Contains(t, body, `cryptoproject_http_cache_lookups_total{result="miss"} 2`)
+	require.Contains(t, body, "go_goroutines")
+}
+
+func TestMetrics_InstrumentProvider(t *testing.T) {
+	t.Parallel()
+
+	m, err := metrics.NewMetrics()
+	require.NoError(t, err)
+
+	coins, err := metrics.InstrumentProvider("ok", stubProvider{}, m).GetActualRates(context.Background(), []string{"BTC"})
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+
+	failure := errors.Wrap(entities.ErrInternal, "provider down")
+	_, err = metrics.InstrumentProvider("down", stubProvider{err: failure}, m).GetActualRates(context.Background(), []string{"BTC"})
+	require.ErrorIs(t, err, entities.ErrInternal)
+
+	body := scrape(t, m)
+	require.Contains(t, body, `cryptoproject_provider_request_duration_seconds_count{provider="ok"} 1`)
+	require.Contains(t, body, `cryptoproject_provider_request_duration_seconds_count{provider="down"} 1`)
+	require.Contains(t, body, `cryptoproject_provider_errors_total{provider="down"} 1`)
+	require.NotContains(t, body, `cryptoproject_provider_errors_total{provider="ok"}`)
+}
+
+func TestMetrics_InstrumentHistoryProvider(t *testing.T) {
+	t.Parallel()
+
+	m, err := metrics.NewMetrics()
+	require.NoError(t, err)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	coins, err := metrics.InstrumentHistoryProvider("history", stubProvider{}, m).
+		GetHistoricalRates(context.Background(), "BTC", entities.ResolutionHour, from, from.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+
+	body := scrape(t, m)
+	require.Contains(t, body, `cryptoproject_provider_request_duration_seconds_count{provider="history"} 1`)
+}
+
+func TestMetrics_QualityReport(t *testing.T) {
+	t.Parallel()
+
+	m, err := metrics.NewMetrics()
+	require.NoError(t, err)
+
+	m.ObserveQualityReport(&entities.QualityReport{Issues: []entities.QualityIssue{
+		{Title: "BTC", Kind: entities.QualityGap},
+		{Title: "BTC", Kind: entities.QualityGap},
+		{Title: "ETH", Kind: entities.QualityJump},
+	}})
+	body := scrape(t, m)
+	require.Contains(t, body, `cryptoproject_data_quality_issues{kind="gap",title="BTC"} 2`)
+	require.Contains(t, body, `cryptoproject_data_quality_issues{kind="jump",title="BTC"} 0`)
+	require.Contains(t, body, `cryptoproject_data_quality_issues{kind="jump",title="ETH"} 1`)
+	require.Contains(t, body, "cryptoproject_data_quality_last_scan_timestamp_seconds")
+
+	m.ObserveQualityReport(&entities.QualityReport{Issues: []entities.QualityIssue{{Title: "ETH", Kind: entities.QualityDuplicate}}})
+	body = scrape(t, m)
+	require.NotContains(t, body, `title="BTC"`)
+	require.Contains(t, body, `cryptoproject_data_quality_issues{kind="duplicate",title="ETH"} 1`)
+}
+
+func TestMetrics_UpdateRates(t *testing.T) {
+	t.Parallel()
+
+	m, err := metrics.NewMetrics()
+	require.NoError(t, err)
+
+	m.ObserveUpdateRates(time.Second, errors.Wrap(entities.ErrInternal, "failed"))
+	body := scrape(t, m)
+	require.Contains(t, body, "cryptoproject_update_rates_failures_total 1")
+	require.Contains(t, body, "cryptoproject_update_rates_last_success_timestamp_seconds 0")
+
+	m.ObserveUpdateRates(time.Second, nil)
+	body = scrape(t, m)
+	require.Contains(t, body, "cryptoproject_update_rates_duration_seconds_count 2")
+	require.NotContains(t, body, "cryptoproject_update_rates_last_success_timestamp_seconds 0")
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector reads pgxpool statistics on every scrape instead of polling them in the background.
+type poolCollector struct {
+	stat func() *pgxpool.Stat
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquireCount      *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	emptyAcquireCount *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+}
+
+func newPoolCollector(namespace string, stat func() *pgxpool.Stat) *poolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &poolCollector{
+		stat:              stat,
+		acquiredConns:     desc("acquired_connections", "Number of connections currently in use."),
+		idleConns:         desc("idle_connections", "Number of idle connections in the pool."),
+		totalConns:        desc("total_connections", "Number of connections in the pool."),
+		maxConns:          desc("max_connections", "Maximum size of the pool."),
+		acquireCount:      desc("acquires_total", "Number of successful connection acquires."),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		emptyAcquireCount: desc("empty_acquires_total", "Number of acquires that had to wait for a connection."),
+		canceledAcquires:  desc("canceled_acquires_total", "Number of acquires canceled by their context."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquires
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.stat()
+	if stat == nil {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
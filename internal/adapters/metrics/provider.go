@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"Cryptoproject/internal/entities"
+)
+
+type ratesProvider interface {
+	GetActualRates(ctx context.Context, titles []string) ([]entities.Coin, error)
+}
+
+// Provider records the latency and failures of every call to the wrapped rates provider.
+type Provider struct {
+	name     string
+	provider ratesProvider
+	metrics  *Metrics
+}
+
+func InstrumentProvider(name string, provider ratesProvider, metrics *Metrics) *Provider {
+	return &Provider{
+		name:     name,
+		provider: provider,
+		metrics:  metrics,
+	}
+}
+
+func (p *Provider) GetActualRates(ctx context.Context, titles []string) ([]entities.Coin, error) {
+	start := time.Now()
+	coins, err := p.provider.GetActualRates(ctx, titles)
+	p.metrics.ObserveProviderCall(p.name, time.Since(start), err)
+	return coins, err
+}
+
+type historyProvider interface {
+	GetHistoricalRates(ctx context.Context, title string, resolution entities.Resolution, from, to time.Time) ([]entities.Coin, error)
+}
+
+// HistoryProvider records the latency and failures of every call to the wrapped history provider.
+type HistoryProvider struct {
+	name     string
+	provider historyProvider
+	metrics  *Metrics
+}
+
+func InstrumentHistoryProvider(name string, provider historyProvider, metrics *Metrics) *HistoryProvider {
+	return &HistoryProvider{
+		name:     name,
+		provider: provider,
+		metrics:  metrics,
+	}
+}
+
+func (p *HistoryProvider) GetHistoricalRates(ctx context.Context, title string, resolution entities.Resolution, from, to time.Time) ([]entities.Coin, error) {
+	start := time.Now()
+	coins, err := p.provider.GetHistoricalRates(ctx, title, resolution, from, to)
+	p.metrics.ObserveProviderCall(p.name, time.Since(start), err)
+	return coins, err
+}
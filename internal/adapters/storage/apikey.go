@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const apiKeyColumns = "id, name, prefix, key_hash, rate_limit, burst, daily_quota, created_at, revoked_at"
+
+func (s *Storage) CreateAPIKey(ctx context.Context, key entities.APIKey) (entities.APIKey, error) {
+	err := s.dbPool.QueryRow(ctx, `
+        INSERT INTO api_keys (name, prefix, key_hash, rate_limit, burst, daily_quota)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at
+    `, key.Name, key.Prefix, key.Hash, key.RateLimit, key.Burst, key.DailyQuota).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to insert API key", "name", key.Name, "err", err)
+		return entities.APIKey{}, errors.Wrap(err, "failed to insert API key")
+	}
+
+	s.logger.DebugContext(ctx, "API key created successfully", "api_key_id", key.ID)
+	return key, nil
+}
+
+func (s *Storage) GetAPIKeyByHash(ctx context.Context, hash string) (entities.APIKey, error) {
+	key, err := scanAPIKey(s.dbPool.QueryRow(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = $1", hash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.APIKey{}, errors.Wrap(entities.ErrNotFound, "API key not found")
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch API key", "err", err)
+		return entities.APIKey{}, errors.Wrap(err, "failed to fetch API key")
+	}
+
+	return key, nil
+}
+
+func (s *Storage) ListAPIKeys(ctx context.Context) ([]entities.APIKey, error) {
+	rows, err := s.dbPool.Query(ctx, "SELECT "+apiKeyColumns+" FROM api_keys ORDER BY id ASC")
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch API keys", "err", err)
+		return nil, errors.Wrap(err, "failed to fetch API keys")
+	}
+	defer rows.Close()
+
+	result := make([]entities.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to scan row into API key object", "err", err)
+			return nil, errors.Wrap(err, "failed to scan row into API key object")
+		}
+		result = append(result, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
+		return nil, errors.Wrap(err, "error occurred while iterating over results")
+	}
+
+	s.logger.DebugContext(ctx, "API keys fetched successfully", "number_of_keys", len(result))
+	return result, nil
+}
+
+func (s *Storage) RevokeAPIKey(ctx context.Context, id int64) error {
+	tag, err := s.dbPool.Exec(ctx, "UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL", id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to revoke API key", "api_key_id", id, "err", err)
+		return errors.Wrap(err, "failed to revoke API key")
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Wrapf(entities.ErrNotFound, "active API key %d not found", id)
+	}
+
+	s.logger.DebugContext(ctx, "API key revoked successfully", "api_key_id", id)
+	return nil
+}
+
+// ConsumeAPIKeyQuota counts a request against the usage of the key on the given day. It returns false
+// without counting when the daily quota is already used up, a zero quota is unlimited.
+func (s *Storage) ConsumeAPIKeyQuota(ctx context.Context, id int64, day time.Time, quota int64) (bool, error) {
+	var requests int64
+	err := s.dbPool.QueryRow(ctx, `
+        INSERT INTO api_key_usage (key_id, day, requests)
+        VALUES ($1, $2::DATE, 1)
+        ON CONFLICT (key_id, day) DO UPDATE SET requests = api_key_usage.requests + 1
+        WHERE $3::BIGINT = 0 OR api_key_usage.requests < $3::BIGINT
+        RETURNING requests
+    `, id, day, quota).Scan(&requests)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to record API key usage", "api_key_id", id, "err", err)
+		return false, errors.Wrap(err, "failed to record API key usage")
+	}
+
+	return true, nil
+}
+
+func (s *Storage) GetAPIKeyUsage(ctx context.Context, from, to time.Time) ([]entities.APIKeyUsage, error) {
+	rows, err := s.dbPool.Query(ctx, `
+        SELECT key_id, day, requests
+        FROM api_key_usage
+        WHERE day BETWEEN $1::DATE AND $2::DATE
+        ORDER BY day ASC, key_id ASC
+    `, from, to)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch API key usage", "err", err)
+		return nil, errors.Wrap(err, "failed to fetch API key usage")
+	}
+	defer rows.Close()
+
+	result := make([]entities.APIKeyUsage, 0)
+	for rows.Next() {
+		var usage entities.APIKeyUsage
+		if err := rows.Scan(&usage.KeyID, &usage.Day, &usage.Requests); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to scan row into API key usage object", "err", err)
+			return nil, errors.Wrap(err, "failed to scan row into API key usage object")
+		}
+		result = append(result, usage)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
+		return nil, errors.Wrap(err, "error occurred while iterating over results")
+	}
+
+	s.logger.DebugContext(ctx, "API key usage fetched successfully", "number_of_entries", len(result))
+	return result, nil
+}
+
+func scanAPIKey(row pgx.Row) (entities.APIKey, error) {
+	var key entities.APIKey
+	var revokedAt *time.Time
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, &key.RateLimit, &key.Burst, &key.DailyQuota, &key.CreatedAt, &revokedAt)
+	if err != nil {
+		return entities.APIKey{}, err
+	}
+	if revokedAt != nil {
+		key.RevokedAt = *revokedAt
+	}
+	return key, nil
+}
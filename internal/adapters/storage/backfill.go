@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// StoreHistory inserts the historical rates whose bucket, starting at their ActualAt, holds no stored rate
+// of the coin yet, and returns the number of inserted rates. Storing the same rates again inserts nothing.
+// Coverage is per coin across sources, as in CountRateBuckets and the gap report: a bucket holding a rate
+// of any provider is not a gap. The (title, source, bucket) conflict target only guards against a rate
+// stored concurrently, its min spacing bucket lies within the covered bucket.
+func (s *Storage) StoreHistory(ctx context.Context, coins []entities.Coin, bucket time.Duration) (int, error) {
+	titles := make([]string, len(coins))
+	sources := make([]string, len(coins))
+	costs := make([]float64, len(coins))
+	actualAt := make([]time.Time, len(coins))
+	for i, coin := range coins {
+		titles[i], sources[i], costs[i], actualAt[i] = coin.Title, coin.Source, coin.Cost, coin.ActualAt.UTC()
+	}
+
+	tag, err := s.dbPool.Exec(ctx, `
+        INSERT INTO coins (title, source, cost, actual_at, bucket)
+        SELECT h.title, h.source, h.cost, h.actual_at, `+sampleBucket("$6", "h.actual_at")+`
+        FROM unnest($1::VARCHAR[], $2::VARCHAR[], $3::DECIMAL[], $4::TIMESTAMP[]) AS h(title, source, cost, actual_at)
+        WHERE NOT EXISTS (
+            SELECT 1 FROM coins c
+            WHERE c.title = h.title
+              AND c.actual_at >= h.actual_at
+              AND c.actual_at < h.actual_at + $5 * INTERVAL '1 microsecond'
+        )
+        ON CONFLICT (title, source, bucket) DO NOTHING
+    `, titles, sources, costs, actualAt, bucket.Microseconds(), s.minSpacing.Microseconds())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to store historical rates", "err", err)
+		return 0, errors.Wrapf(entities.ErrInternal, "failed to store historical rates: %v", err)
+	}
+
+	s.logger.DebugContext(ctx, "Historical rates stored successfully", "number_of_coins", len(coins), "inserted", tag.RowsAffected())
+	return int(tag.RowsAffected()), nil
+}
+
+// CountRateBuckets returns how many buckets in [from, to) hold at least one stored rate of the coin, of any source.
+func (s *Storage) CountRateBuckets(ctx context.Context, title string, from, to time.Time, bucket time.Duration) (int, error) {
+	var count int
+	err := s.dbPool.QueryRow(ctx, `
+        SELECT COUNT(DISTINCT `+sampleBucket("$4", "actual_at")+`)
+        FROM coins
+        WHERE title = $1 AND actual_at >= $2 AND actual_at < $3
+    `, title, from.UTC(), to.UTC(), bucket.Microseconds()).Scan(&count)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to count covered rate buckets", "title", title, "err", err)
+		return 0, errors.Wrap(err, "failed to count covered rate buckets")
+	}
+	return count, nil
+}
+
+// GetBackfillCursor returns the cursor saved for the job, the zero time if the job never ran.
+func (s *Storage) GetBackfillCursor(ctx context.Context, job entities.BackfillJob) (time.Time, error) {
+	var cursor time.Time
+	err := s.dbPool.QueryRow(ctx, `
+        SELECT cursor FROM backfill_jobs
+        WHERE title = $1 AND resolution = $2 AND range_from = $3 AND range_to = $4
+    `, job.Title, string(job.Resolution), job.From.UTC(), job.To.UTC()).Scan(&cursor)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch backfill cursor", "title", job.Title, "err", err)
+		return time.Time{}, errors.Wrap(err, "failed to fetch backfill cursor")
+	}
+	return cursor, nil
+}
+
+func (s *Storage) SaveBackfillCursor(ctx context.Context, job entities.BackfillJob) error {
+	_, err := s.dbPool.Exec(ctx, `
+        INSERT INTO backfill_jobs (title, resolution, range_from, range_to, cursor, updated_at)
+        VALUES ($1, $2, $3, $4, $5, NOW())
+        ON CONFLICT (title, resolution, range_from, range_to) DO UPDATE SET cursor = EXCLUDED.cursor, updated_at = EXCLUDED.updated_at
+    `, job.Title, string(job.Resolution), job.From.UTC(), job.To.UTC(), job.Cursor.UTC())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to save backfill cursor", "title", job.Title, "err", err)
+		return errors.Wrap(err, "failed to save backfill cursor")
+	}
+
+	s.logger.DebugContext(ctx, "Backfill cursor saved successfully", "title", job.Title, "cursor", job.Cursor)
+	return nil
+}
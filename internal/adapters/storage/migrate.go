@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// migrationLockID identifies the advisory lock that keeps concurrent migration runs apart.
+const migrationLockID = 7_302_114
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+type migration struct {
+	version int64
+	name    string
+	up      string
+	down    string
+}
+
+// loadMigrations reads the embedded NNNNNN_name.up.sql and NNNNNN_name.down.sql pairs ordered by version.
+func loadMigrations() ([]migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, errors.Wrapf(entities.ErrInternal, "failed to read migrations: %v", err)
+	}
+
+	byVersion := make(map[int64]*migration)
+	for _, entry := range entries {
+		name := entry.Name()
+		prefix, rest, found := strings.Cut(name, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if !found || err != nil {
+			return nil, errors.Wrapf(entities.ErrInternal, "malformed migration file name %s", name)
+		}
+		content, err := fs.ReadFile(migrationFiles, path.Join("migrations", name))
+		if err != nil {
+			return nil, errors.Wrapf(entities.ErrInternal, "failed to read migration %s: %v", name, err)
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &migration{version: version}
+			byVersion[version] = m
+		}
+		switch {
+		case strings.HasSuffix(rest, ".up.sql"):
+			m.name, m.up = strings.TrimSuffix(rest, ".up.sql"), string(content)
+		case strings.HasSuffix(rest, ".down.sql"):
+			m.down = string(content)
+		default:
+			return nil, errors.Wrapf(entities.ErrInternal, "migration %s is neither up nor down", name)
+		}
+	}
+
+	result := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" || m.down == "" {
+			return nil, errors.Wrapf(entities.ErrInternal, "migration %d lacks its up or down file", m.version)
+		}
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].version < result[j].version })
+	return result, nil
+}
+
+// Migrate applies the embedded migrations that have not been applied yet and returns their names.
+// Migration files carry their own transaction and are idempotent, so a run interrupted between a file
+// and its bookkeeping row is safely repeated.
+func (s *Storage) Migrate(ctx context.Context) ([]string, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []string
+	err = s.withMigrationLock(ctx, func(conn *pgx.Conn, current map[int64]bool) error {
+		for _, m := range migrations {
+			if current[m.version] {
+				continue
+			}
+			if _, err := conn.Exec(ctx, m.up, pgx.QueryExecModeSimpleProtocol); err != nil {
+				return errors.Wrapf(entities.ErrInternal, "migration %d %s failed: %v", m.version, m.name, err)
+			}
+			if _, err := conn.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", m.version); err != nil {
+				return errors.Wrapf(entities.ErrInternal, "failed to record migration %d: %v", m.version, err)
+			}
+			s.logger.InfoContext(ctx, "Migration applied", "version", m.version, "name", m.name)
+			applied = append(applied, m.name)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Rollback reverts the latest steps applied migrations and returns their names.
+func (s *Storage) Rollback(ctx context.Context, steps int) ([]string, error) {
+	if steps <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "steps must be greater than zero")
+	}
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []string
+	err = s.withMigrationLock(ctx, func(conn *pgx.Conn, current map[int64]bool) error {
+		for i := len(migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			m := migrations[i]
+			if !current[m.version] {
+				continue
+			}
+			if _, err := conn.Exec(ctx, m.down, pgx.QueryExecModeSimpleProtocol); err != nil {
+				return errors.Wrapf(entities.ErrInternal, "rollback of migration %d %s failed: %v", m.version, m.name, err)
+			}
+			if _, err := conn.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", m.version); err != nil {
+				return errors.Wrapf(entities.ErrInternal, "failed to record rollback of migration %d: %v", m.version, err)
+			}
+			s.logger.InfoContext(ctx, "Migration rolled back", "version", m.version, "name", m.name)
+			reverted = append(reverted, m.name)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// bucketMigration is the migration that introduced the min spacing buckets of stored rates.
+const bucketMigration = 7
+
+// Rebucket bins every stored rate into the configured min spacing bucket and keeps only the newest rate
+// per coin, source and bucket, as Store does for new rates. It is run once after a change of the spacing
+// or after migrating a deployment that does not use the default spacing, and returns the removed rates.
+func (s *Storage) Rebucket(ctx context.Context) (int64, error) {
+	var removed int64
+	err := s.withMigrationLock(ctx, func(conn *pgx.Conn, current map[int64]bool) error {
+		if !current[bucketMigration] {
+			return errors.Wrapf(entities.ErrInvalidParam, "migration %d must be applied before rebucketing", bucketMigration)
+		}
+
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return errors.Wrapf(entities.ErrInternal, "failed to begin transaction: %v", err)
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		spacing := s.minSpacing.Microseconds()
+		tag, err := tx.Exec(ctx, `
+            DELETE FROM coins c
+            USING (
+                SELECT id, ROW_NUMBER() OVER (
+                    PARTITION BY title, source, `+sampleBucket("$1", "actual_at")+`
+                    ORDER BY actual_at DESC, id DESC
+                ) AS position
+                FROM coins
+            ) AS ranked
+            WHERE c.id = ranked.id AND ranked.position > 1
+        `, spacing)
+		if err != nil {
+			return errors.Wrapf(entities.ErrInternal, "failed to remove rates sharing a bucket: %v", err)
+		}
+		removed = tag.RowsAffected()
+
+		// Rows move between buckets one at a time, the unique index is rebuilt once they all moved.
+		if _, err := tx.Exec(ctx, "DROP INDEX IF EXISTS coins_title_source_bucket_key"); err != nil {
+			return errors.Wrapf(entities.ErrInternal, "failed to drop bucket index: %v", err)
+		}
+		bucket := sampleBucket("$1", "actual_at")
+		if _, err := tx.Exec(ctx, "UPDATE coins SET bucket = "+bucket+" WHERE bucket <> "+bucket, spacing); err != nil {
+			return errors.Wrapf(entities.ErrInternal, "failed to rebucket rates: %v", err)
+		}
+		if _, err := tx.Exec(ctx, "CREATE UNIQUE INDEX coins_title_source_bucket_key ON coins (title, source, bucket)"); err != nil {
+			return errors.Wrapf(entities.ErrInternal, "failed to create bucket index: %v", err)
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return errors.Wrapf(entities.ErrInternal, "failed to commit rebucketing: %v", err)
+		}
+		s.logger.InfoContext(ctx, "Rates rebucketed", "min_spacing", s.minSpacing, "removed", removed)
+		return nil
+	})
+	return removed, err
+}
+
+// withMigrationLock runs fn on a dedicated connection holding the migration advisory lock, together
+// with the set of applied migration versions.
+func (s *Storage) withMigrationLock(ctx context.Context, fn func(conn *pgx.Conn, applied map[int64]bool) error) error {
+	conn, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		return errors.Wrapf(entities.ErrUnavailable, "failed to acquire connection: %v", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return errors.Wrapf(entities.ErrInternal, "failed to lock migrations: %v", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to unlock migrations", "err", err)
+		}
+	}()
+
+	_, err = conn.Exec(ctx, `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version BIGINT PRIMARY KEY,
+            applied_at TIMESTAMP DEFAULT NOW()
+        )
+    `)
+	if err != nil {
+		return errors.Wrapf(entities.ErrInternal, "failed to create schema_migrations: %v", err)
+	}
+
+	rows, err := conn.Query(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return errors.Wrapf(entities.ErrInternal, "failed to read applied migrations: %v", err)
+	}
+	versions, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return errors.Wrapf(entities.ErrInternal, "failed to read applied migrations: %v", err)
+	}
+	applied := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		applied[version] = true
+	}
+
+	return fn(conn.Conn(), applied)
+}
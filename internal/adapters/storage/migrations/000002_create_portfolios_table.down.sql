@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS portfolio_positions;
+DROP TABLE IF EXISTS portfolios;
+
+END;
\ No newline at end of file
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS portfolios (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS portfolio_positions (
+    portfolio_id INTEGER NOT NULL REFERENCES portfolios (id) ON DELETE CASCADE,
+    title VARCHAR(50) NOT NULL,
+    quantity DOUBLE PRECISION NOT NULL,
+    cost_basis DOUBLE PRECISION NOT NULL DEFAULT 0,
+    PRIMARY KEY (portfolio_id, title)
+);
+
+END;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS api_key_usage;
+DROP TABLE IF EXISTS api_keys;
+
+END;
\ No newline at end of file
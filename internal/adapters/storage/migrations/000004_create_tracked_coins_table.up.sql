@@ -0,0 +1,9 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS tracked_coins (
+    title VARCHAR(50) PRIMARY KEY,
+    tracked BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+END;
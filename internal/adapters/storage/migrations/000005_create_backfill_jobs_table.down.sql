@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS coins_title_actual_at_idx;
+DROP TABLE IF EXISTS backfill_jobs;
+
+END;
@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS backfill_jobs (
+    title VARCHAR(50) NOT NULL,
+    resolution VARCHAR(10) NOT NULL,
+    range_from TIMESTAMP NOT NULL,
+    range_to TIMESTAMP NOT NULL,
+    cursor TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (title, resolution, range_from, range_to)
+);
+
+CREATE INDEX IF NOT EXISTS coins_title_actual_at_idx ON coins (title, actual_at);
+
+END;
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS quarantined_rates (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(50) NOT NULL,
+    cost DOUBLE PRECISION NOT NULL,
+    actual_at TIMESTAMP NOT NULL,
+    reference_cost DOUBLE PRECISION NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    quarantined_at TIMESTAMP DEFAULT NOW(),
+    reviewed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS quarantined_rates_status_idx ON quarantined_rates (status, quarantined_at);
+
+END;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE quarantined_rates DROP COLUMN IF EXISTS source;
+
+DROP INDEX IF EXISTS coins_title_source_bucket_key;
+ALTER TABLE coins ALTER COLUMN actual_at DROP NOT NULL;
+ALTER TABLE coins DROP COLUMN IF EXISTS bucket;
+ALTER TABLE coins DROP COLUMN IF EXISTS source;
+
+END;
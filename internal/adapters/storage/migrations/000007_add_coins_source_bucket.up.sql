@@ -0,0 +1,24 @@
+BEGIN;
+
+ALTER TABLE coins ADD COLUMN IF NOT EXISTS source VARCHAR(50) NOT NULL DEFAULT 'cryptocompare';
+ALTER TABLE coins ADD COLUMN IF NOT EXISTS bucket TIMESTAMP;
+
+UPDATE coins SET actual_at = NOW() WHERE actual_at IS NULL;
+
+-- Existing rates are binned like Store bins them with the default min-sample-spacing of one minute.
+-- Deployments with another spacing run `cryptoctl migrate rebucket` once after this migration.
+UPDATE coins SET bucket = date_bin(INTERVAL '1 minute', actual_at, TIMESTAMP '1970-01-01') WHERE bucket IS NULL;
+
+-- Only the newest rate of every bucket is kept, the same rate a later Store would have kept.
+DELETE FROM coins a
+USING coins b
+WHERE a.title = b.title AND a.source = b.source AND a.bucket = b.bucket
+  AND (a.actual_at < b.actual_at OR (a.actual_at = b.actual_at AND a.id < b.id));
+
+ALTER TABLE coins ALTER COLUMN actual_at SET NOT NULL;
+ALTER TABLE coins ALTER COLUMN bucket SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS coins_title_source_bucket_key ON coins (title, source, bucket);
+
+ALTER TABLE quarantined_rates ADD COLUMN IF NOT EXISTS source VARCHAR(50) NOT NULL DEFAULT 'cryptocompare';
+
+END;
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+func (s *Storage) CreatePortfolio(ctx context.Context, portfolio entities.Portfolio) (entities.Portfolio, error) {
+	tx, err := s.dbPool.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to begin transaction", "err", err)
+		return entities.Portfolio{}, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck //ok
+
+	err = tx.QueryRow(ctx,
+		"INSERT INTO portfolios (name) VALUES ($1) RETURNING id, created_at, updated_at",
+		portfolio.Name,
+	).Scan(&portfolio.ID, &portfolio.CreatedAt, &portfolio.UpdatedAt)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to insert portfolio", "name", portfolio.Name, "err", err)
+		return entities.Portfolio{}, errors.Wrap(err, "failed to insert portfolio")
+	}
+
+	if err := s.insertPositions(ctx, tx, portfolio.ID, portfolio.Positions); err != nil {
+		return entities.Portfolio{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to commit transaction", "err", err)
+		return entities.Portfolio{}, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	s.logger.DebugContext(ctx, "Portfolio created successfully", "portfolio_id", portfolio.ID)
+	return portfolio, nil
+}
+
+func (s *Storage) GetPortfolio(ctx context.Context, id int64) (entities.Portfolio, error) {
+	portfolio := entities.Portfolio{ID: id}
+	err := s.dbPool.QueryRow(ctx,
+		"SELECT name, created_at, updated_at FROM portfolios WHERE id = $1",
+		id,
+	).Scan(&portfolio.Name, &portfolio.CreatedAt, &portfolio.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.Portfolio{}, errors.Wrapf(entities.ErrNotFound, "portfolio %d not found", id)
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch portfolio", "portfolio_id", id, "err", err)
+		return entities.Portfolio{}, errors.Wrap(err, "failed to fetch portfolio")
+	}
+
+	positions, err := s.getPositions(ctx, []int64{id})
+	if err != nil {
+		return entities.Portfolio{}, err
+	}
+	portfolio.Positions = positions[id]
+
+	s.logger.DebugContext(ctx, "Portfolio fetched successfully", "portfolio_id", id)
+	return portfolio, nil
+}
+
+func (s *Storage) ListPortfolios(ctx context.Context) ([]entities.Portfolio, error) {
+	rows, err := s.dbPool.Query(ctx, "SELECT id, name, created_at, updated_at FROM portfolios ORDER BY id ASC")
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch portfolios", "err", err)
+		return nil, errors.Wrap(err, "failed to fetch portfolios")
+	}
+	defer rows.Close()
+
+	result := make([]entities.Portfolio, 0)
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var portfolio entities.Portfolio
+		if err := rows.Scan(&portfolio.ID, &portfolio.Name, &portfolio.CreatedAt, &portfolio.UpdatedAt); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to scan row into portfolio object", "err", err)
+			return nil, errors.Wrap(err, "failed to scan row into portfolio object")
+		}
+		result = append(result, portfolio)
+		ids = append(ids, portfolio.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
+		return nil, errors.Wrap(err, "error occurred while iterating over results")
+	}
+
+	positions, err := s.getPositions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Positions = positions[result[i].ID]
+	}
+
+	s.logger.DebugContext(ctx, "Portfolios fetched successfully", "number_of_portfolios", len(result))
+	return result, nil
+}
+
+func (s *Storage) UpdatePortfolio(ctx context.Context, portfolio entities.Portfolio) (entities.Portfolio, error) {
+	tx, err := s.dbPool.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to begin transaction", "err", err)
+		return entities.Portfolio{}, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck //ok
+
+	err = tx.QueryRow(ctx,
+		"UPDATE portfolios SET name = $2, updated_at = NOW() WHERE id = $1 RETURNING created_at, updated_at",
+		portfolio.ID, portfolio.Name,
+	).Scan(&portfolio.CreatedAt, &portfolio.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.Portfolio{}, errors.Wrapf(entities.ErrNotFound, "portfolio %d not found", portfolio.ID)
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to update portfolio", "portfolio_id", portfolio.ID, "err", err)
+		return entities.Portfolio{}, errors.Wrap(err, "failed to update portfolio")
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM portfolio_positions WHERE portfolio_id = $1", portfolio.ID); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to delete portfolio positions", "portfolio_id", portfolio.ID, "err", err)
+		return entities.Portfolio{}, errors.Wrap(err, "failed to delete portfolio positions")
+	}
+
+	if err := s.insertPositions(ctx, tx, portfolio.ID, portfolio.Positions); err != nil {
+		return entities.Portfolio{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to commit transaction", "err", err)
+		return entities.Portfolio{}, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	s.logger.DebugContext(ctx, "Portfolio updated successfully", "portfolio_id", portfolio.ID)
+	return portfolio, nil
+}
+
+func (s *Storage) DeletePortfolio(ctx context.Context, id int64) error {
+	tag, err := s.dbPool.Exec(ctx, "DELETE FROM portfolios WHERE id = $1", id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to delete portfolio", "portfolio_id", id, "err", err)
+		return errors.Wrap(err, "failed to delete portfolio")
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Wrapf(entities.ErrNotFound, "portfolio %d not found", id)
+	}
+
+	s.logger.DebugContext(ctx, "Portfolio deleted successfully", "portfolio_id", id)
+	return nil
+}
+
+func (s *Storage) insertPositions(ctx context.Context, tx pgx.Tx, portfolioID int64, positions []entities.Position) error {
+	data := make([][]interface{}, len(positions))
+	for i, position := range positions {
+		data[i] = []interface{}{portfolioID, position.Title, position.Quantity, position.CostBasis}
+	}
+
+	_, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"portfolio_positions"},
+		[]string{"portfolio_id", "title", "quantity", "cost_basis"},
+		pgx.CopyFromRows(data),
+	)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to insert portfolio positions", "portfolio_id", portfolioID, "err", err)
+		return errors.Wrap(err, "failed to insert portfolio positions")
+	}
+	return nil
+}
+
+func (s *Storage) getPositions(ctx context.Context, portfolioIDs []int64) (map[int64][]entities.Position, error) {
+	rows, err := s.dbPool.Query(ctx, `
+        SELECT portfolio_id, title, quantity, cost_basis
+        FROM portfolio_positions
+        WHERE portfolio_id = ANY($1::INTEGER[])
+        ORDER BY portfolio_id ASC, title ASC
+    `, portfolioIDs)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch portfolio positions", "err", err)
+		return nil, errors.Wrap(err, "failed to fetch portfolio positions")
+	}
+	defer rows.Close()
+
+	result := make(map[int64][]entities.Position, len(portfolioIDs))
+	for rows.Next() {
+		var portfolioID int64
+		var position entities.Position
+		if err := rows.Scan(&portfolioID, &position.Title, &position.Quantity, &position.CostBasis); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to scan row into position object", "err", err)
+			return nil, errors.Wrap(err, "failed to scan row into position object")
+		}
+		result[portfolioID] = append(result[portfolioID], position)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
+		return nil, errors.Wrap(err, "error occurred while iterating over results")
+	}
+
+	return result, nil
+}
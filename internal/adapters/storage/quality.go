@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// FindRateGaps returns the pairs of consecutive rates of a coin stored between from and to that lie more
+// than minGap apart.
+func (s *Storage) FindRateGaps(ctx context.Context, from, to time.Time, minGap time.Duration) ([]entities.QualityIssue, error) {
+	rows, err := s.dbPool.Query(ctx, `
+        SELECT title, prev_at, actual_at
+        FROM (
+            SELECT title, actual_at, LAG(actual_at) OVER (PARTITION BY title ORDER BY actual_at) AS prev_at
+            FROM coins
+            WHERE actual_at >= $1 AND actual_at < $2
+        ) AS samples
+        WHERE actual_at - prev_at > $3 * INTERVAL '1 microsecond'
+        ORDER BY title ASC, prev_at ASC
+    `, from.UTC(), to.UTC(), minGap.Microseconds())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to search for rate gaps", "err", err)
+		return nil, errors.Wrap(err, "failed to search for rate gaps")
+	}
+
+	return s.collectQualityIssues(ctx, rows, func(row pgx.CollectableRow) (entities.QualityIssue, error) {
+		issue := entities.QualityIssue{Kind: entities.QualityGap}
+		err := row.Scan(&issue.Title, &issue.From, &issue.To)
+		return issue, err
+	})
+}
+
+// FindRateJumps returns the pairs of consecutive rates of a coin stored between from and to whose change
+// deviates from the mean change of the coin in that window by more than sigma standard deviations. Changes
+// are compared as log returns so that coins of any price level are judged alike.
+func (s *Storage) FindRateJumps(ctx context.Context, from, to time.Time, sigma float64) ([]entities.QualityIssue, error) {
+	rows, err := s.dbPool.Query(ctx, `
+        WITH samples AS (
+            SELECT title, actual_at, cost,
+                   LAG(actual_at) OVER w AS prev_at,
+                   LAG(cost) OVER w AS prev_cost
+            FROM coins
+            WHERE actual_at >= $1 AND actual_at < $2
+            WINDOW w AS (PARTITION BY title ORDER BY actual_at, id)
+        ), returns AS (
+            SELECT title, prev_at, actual_at, prev_cost, cost,
+                   LN((cost / prev_cost)::DOUBLE PRECISION) AS change
+            FROM samples
+            WHERE prev_cost > 0 AND cost > 0
+        ), spread AS (
+            SELECT title, AVG(change) AS mean, STDDEV_SAMP(change) AS deviation
+            FROM returns
+            GROUP BY title
+        )
+        SELECT r.title, r.prev_at, r.actual_at, r.prev_cost::DOUBLE PRECISION, r.cost::DOUBLE PRECISION,
+               ABS(r.change - s.mean) / s.deviation
+        FROM returns r
+        JOIN spread s ON s.title = r.title
+        WHERE s.deviation > 0 AND ABS(r.change - s.mean) > $3 * s.deviation
+        ORDER BY r.title ASC, r.prev_at ASC
+    `, from.UTC(), to.UTC(), sigma)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to search for rate jumps", "err", err)
+		return nil, errors.Wrap(err, "failed to search for rate jumps")
+	}
+
+	return s.collectQualityIssues(ctx, rows, func(row pgx.CollectableRow) (entities.QualityIssue, error) {
+		issue := entities.QualityIssue{Kind: entities.QualityJump}
+		err := row.Scan(&issue.Title, &issue.From, &issue.To, &issue.FromCost, &issue.ToCost, &issue.Sigmas)
+		return issue, err
+	})
+}
+
+// FindDuplicateRates returns the timestamps stored more than once for a coin and source between from and to.
+func (s *Storage) FindDuplicateRates(ctx context.Context, from, to time.Time) ([]entities.QualityIssue, error) {
+	rows, err := s.dbPool.Query(ctx, `
+        SELECT title, actual_at, COUNT(*)
+        FROM coins
+        WHERE actual_at >= $1 AND actual_at < $2
+        GROUP BY title, source, actual_at
+        HAVING COUNT(*) > 1
+        ORDER BY title ASC, actual_at ASC
+    `, from.UTC(), to.UTC())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to search for duplicate rates", "err", err)
+		return nil, errors.Wrap(err, "failed to search for duplicate rates")
+	}
+
+	return s.collectQualityIssues(ctx, rows, func(row pgx.CollectableRow) (entities.QualityIssue, error) {
+		issue := entities.QualityIssue{Kind: entities.QualityDuplicate}
+		err := row.Scan(&issue.Title, &issue.From, &issue.Count)
+		issue.To = issue.From
+		return issue, err
+	})
+}
+
+func (s *Storage) collectQualityIssues(ctx context.Context, rows pgx.Rows, scan pgx.RowToFunc[entities.QualityIssue]) ([]entities.QualityIssue, error) {
+	issues, err := pgx.CollectRows(rows, scan)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to scan row into quality issue", "err", err)
+		return nil, errors.Wrap(err, "failed to scan row into quality issue")
+	}
+
+	s.logger.DebugContext(ctx, "Quality issues fetched successfully", "number_of_issues", len(issues))
+	return issues, nil
+}
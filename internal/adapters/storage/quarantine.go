@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// GetReferenceRates returns the median of the rates of each coin stored since the given time. Coins without
+// rates in that window are left out.
+func (s *Storage) GetReferenceRates(ctx context.Context, titles []string, since time.Time) (map[string]float64, error) {
+	rows, err := s.dbPool.Query(ctx, `
+        SELECT title, PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY cost)
+        FROM coins
+        WHERE title = ANY($1) AND actual_at >= $2
+        GROUP BY title
+    `, titles, since.UTC())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch reference rates", "titles", titles, "err", err)
+		return nil, errors.Wrap(err, "failed to fetch reference rates")
+	}
+	defer rows.Close()
+
+	references := make(map[string]float64, len(titles))
+	for rows.Next() {
+		var title string
+		var cost float64
+		if err := rows.Scan(&title, &cost); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to scan row into reference rate", "err", err)
+			return nil, errors.Wrap(err, "failed to scan row into reference rate")
+		}
+		references[title] = cost
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
+		return nil, errors.Wrap(err, "error occurred while iterating over results")
+	}
+	return references, nil
+}
+
+func (s *Storage) QuarantineRates(ctx context.Context, rates []entities.QuarantinedRate) error {
+	data := make([][]interface{}, len(rates))
+	for i, rate := range rates {
+		data[i] = []interface{}{rate.Title, rate.Source, rate.Cost, rate.ActualAt.UTC(), rate.ReferenceCost}
+	}
+
+	_, err := s.dbPool.CopyFrom(ctx, pgx.Identifier{"quarantined_rates"},
+		[]string{"title", "source", "cost", "actual_at", "reference_cost"}, pgx.CopyFromRows(data))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to quarantine rates", "err", err)
+		return errors.Wrapf(entities.ErrInternal, "failed to quarantine rates: %v", err)
+	}
+
+	s.logger.DebugContext(ctx, "Rates quarantined successfully", "number_of_rates", len(rates))
+	return nil
+}
+
+func (s *Storage) ListQuarantinedRates(ctx context.Context, status entities.QuarantineStatus) ([]entities.QuarantinedRate, error) {
+	rows, err := s.dbPool.Query(ctx, `
+        SELECT id, title, source, cost, actual_at, reference_cost, status, quarantined_at, reviewed_at
+        FROM quarantined_rates
+        WHERE status = $1
+        ORDER BY quarantined_at DESC, id DESC
+    `, string(status))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch quarantined rates", "status", status, "err", err)
+		return nil, errors.Wrap(err, "failed to fetch quarantined rates")
+	}
+	defer rows.Close()
+
+	result := make([]entities.QuarantinedRate, 0)
+	for rows.Next() {
+		var rate entities.QuarantinedRate
+		var reviewedAt *time.Time
+		if err := rows.Scan(&rate.ID, &rate.Title, &rate.Source, &rate.Cost, &rate.ActualAt, &rate.ReferenceCost,
+			&rate.Status, &rate.QuarantinedAt, &reviewedAt); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to scan row into quarantined rate", "err", err)
+			return nil, errors.Wrap(err, "failed to scan row into quarantined rate")
+		}
+		if reviewedAt != nil {
+			rate.ReviewedAt = *reviewedAt
+		}
+		result = append(result, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
+		return nil, errors.Wrap(err, "error occurred while iterating over results")
+	}
+
+	s.logger.DebugContext(ctx, "Quarantined rates fetched successfully", "status", status, "number_of_rates", len(result))
+	return result, nil
+}
+
+// ApproveQuarantinedRate stores a pending quarantined rate with its original timestamp and marks it approved.
+// Like Store, it only replaces an older rate stored in its bucket meanwhile. A newer one is kept, the rate
+// stays pending and entities.ErrConflict tells the reviewer to discard it instead.
+func (s *Storage) ApproveQuarantinedRate(ctx context.Context, id int64) error {
+	tx, err := s.dbPool.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to begin transaction", "err", err)
+		return errors.Wrapf(entities.ErrInternal, "failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck //ok
+
+	var title string
+	var actualAt time.Time
+	err = tx.QueryRow(ctx, `
+        SELECT title, actual_at FROM quarantined_rates
+        WHERE id = $1 AND status = 'pending'
+        FOR UPDATE
+    `, id).Scan(&title, &actualAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.Wrapf(entities.ErrNotFound, "pending quarantined rate %d not found", id)
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to lock quarantined rate", "quarantined_rate_id", id, "err", err)
+		return errors.Wrap(err, "failed to lock quarantined rate")
+	}
+
+	tag, err := tx.Exec(ctx, `
+        INSERT INTO coins (title, source, cost, actual_at, bucket)
+        SELECT title, source, cost, actual_at, `+sampleBucket("$2", "actual_at")+`
+        FROM quarantined_rates
+        WHERE id = $1
+        ON CONFLICT (title, source, bucket) DO UPDATE
+        SET cost = EXCLUDED.cost, actual_at = EXCLUDED.actual_at
+        WHERE coins.actual_at <= EXCLUDED.actual_at
+    `, id, s.minSpacing.Microseconds())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to store quarantined rate", "quarantined_rate_id", id, "err", err)
+		return errors.Wrap(err, "failed to store quarantined rate")
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Wrapf(entities.ErrConflict, "a rate of %s newer than quarantined rate %d (%s) is stored in its bucket",
+			title, id, actualAt.UTC().Format(time.RFC3339))
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE quarantined_rates SET status = 'approved', reviewed_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to approve quarantined rate", "quarantined_rate_id", id, "err", err)
+		return errors.Wrap(err, "failed to approve quarantined rate")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to commit transaction", "err", err)
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	s.logger.DebugContext(ctx, "Quarantined rate approved successfully", "quarantined_rate_id", id)
+	return nil
+}
+
+func (s *Storage) DiscardQuarantinedRate(ctx context.Context, id int64) error {
+	tag, err := s.dbPool.Exec(ctx, `
+        UPDATE quarantined_rates SET status = 'discarded', reviewed_at = NOW()
+        WHERE id = $1 AND status = 'pending'
+    `, id)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to discard quarantined rate", "quarantined_rate_id", id, "err", err)
+		return errors.Wrap(err, "failed to discard quarantined rate")
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Wrapf(entities.ErrNotFound, "pending quarantined rate %d not found", id)
+	}
+
+	s.logger.DebugContext(ctx, "Quarantined rate discarded successfully", "quarantined_rate_id", id)
+	return nil
+}
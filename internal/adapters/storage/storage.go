@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -14,26 +16,73 @@ import (
 	"Cryptoproject/internal/entities"
 )
 
+const (
+	firstCost = "(ARRAY_AGG(cost ORDER BY actual_at ASC))[1]"
+	lastCost  = "(ARRAY_AGG(cost ORDER BY actual_at DESC))[1]"
+
+	defaultMinSpacing = time.Minute
+)
+
 type Storage struct {
 	dbPool *pgxpool.Pool
 	cancel context.CancelFunc
 	once   sync.Once
+	logger *slog.Logger
+	// minSpacing is the width of the buckets a coin keeps one rate per source in.
+	minSpacing time.Duration
+}
+
+type StorageOption func(*Storage)
+
+func WithLogger(logger *slog.Logger) StorageOption {
+	return func(s *Storage) {
+		s.logger = logger
+	}
 }
 
-func NewStorage(connStr string) (*Storage, error) {
+// WithMinSpacing sets the minimum spacing between stored rates of a coin from one source, rates closer to
+// each other share a bucket and only the latest of them is kept.
+func WithMinSpacing(spacing time.Duration) StorageOption {
+	return func(s *Storage) {
+		s.minSpacing = spacing
+	}
+}
+
+func NewStorage(connStr string, opts ...StorageOption) (*Storage, error) {
 	if connStr == "" {
 		return nil, errors.Wrap(entities.ErrInvalidParam, "connection string is empty")
 	}
-	st := &Storage{}
+	st := &Storage{logger: slog.Default(), minSpacing: defaultMinSpacing}
+	for _, opt := range opts {
+		opt(st)
+	}
+	if st.logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "logger cannot be nil")
+	}
+	if st.minSpacing < time.Microsecond {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "min spacing must be at least a microsecond")
+	}
+
+	poolCfg, err := pgxpool.ParseConfig(connStr)
+	if err != nil {
+		st.logger.Error("Failed to parse connection string", "err", err)
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "invalid connection string: %v", err)
+	}
+	poolCfg.ConnConfig.Tracer = queryTracer{}
+
+	// Only the address is logged, the connection string carries the password.
+	dbAttrs := []any{"host", poolCfg.ConnConfig.Host, "port", poolCfg.ConnConfig.Port, "database", poolCfg.ConnConfig.Database}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	st.cancel = cancel
-	pool, err := pgxpool.New(ctx, connStr)
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
-		slog.Error("Failed to connect to database", "conn_str", connStr, "err", err)
+		cancel()
+		st.logger.Error("Failed to connect to database", append(dbAttrs, "err", err)...)
 		return nil, errors.Wrapf(entities.ErrInternal, "New pool failure: %v", err)
 	}
 	st.dbPool = pool
-	slog.Info("Database connection established", "conn_str", connStr)
+	st.logger.Info("Database connection established", dbAttrs...)
 	return st, nil
 }
 
@@ -42,31 +91,110 @@ func (s *Storage) Close() {
 		func() {
 			s.cancel()
 			s.dbPool.Close()
-			slog.Info("Database connection closed")
+			s.logger.Info("Database connection closed")
 		},
 	)
 }
 
+// PoolStat returns a snapshot of the connection pool statistics.
+func (s *Storage) PoolStat() *pgxpool.Stat {
+	return s.dbPool.Stat()
+}
+
+func (s *Storage) Ping(ctx context.Context) error {
+	if err := s.dbPool.Ping(ctx); err != nil {
+		return errors.Wrapf(entities.ErrUnavailable, "database ping failed: %v", err)
+	}
+	return nil
+}
+
+// Store upserts the rates through a staging table: a coin keeps one rate per source and min spacing bucket,
+// a later rate in the same bucket replaces the stored one and storing the same rates again changes nothing.
+// Rates without a timestamp are stored as of now.
 func (s *Storage) Store(ctx context.Context, coins []entities.Coin) error {
 	data := make([][]interface{}, len(coins))
 	for i, coin := range coins {
-		data[i] = []interface{}{coin.Title, coin.Cost}
+		var actualAt *time.Time
+		if !coin.ActualAt.IsZero() {
+			utc := coin.ActualAt.UTC()
+			actualAt = &utc
+		}
+		data[i] = []interface{}{coin.Title, coin.Source, coin.Cost, actualAt}
+	}
+
+	tx, err := s.dbPool.Begin(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to begin transaction", "err", err)
+		return errors.Wrapf(entities.ErrInternal, "failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck //ok
+
+	_, err = tx.Exec(ctx, `
+        CREATE TEMP TABLE coins_staging (
+            title VARCHAR(50),
+            source VARCHAR(50),
+            cost DECIMAL(10, 2),
+            actual_at TIMESTAMP
+        ) ON COMMIT DROP
+    `)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create staging table", "err", err)
+		return errors.Wrapf(entities.ErrInternal, "failed to create staging table: %v", err)
 	}
 
-	_, err := s.dbPool.CopyFrom(ctx, pgx.Identifier{"coins"}, []string{"title", "cost"}, pgx.CopyFromRows(data))
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"coins_staging"}, []string{"title", "source", "cost", "actual_at"}, pgx.CopyFromRows(data))
 	if err != nil {
-		slog.Error("Failed to perform bulk insert using COPY", "err", err)
+		s.logger.ErrorContext(ctx, "Failed to perform bulk insert using COPY", "err", err)
 		return errors.Wrapf(entities.ErrInternal, "failed to perform bulk insert using COPY: %v", err)
 	}
 
-	slog.Info("Bulk insert completed successfully", "number_of_coins", len(coins))
+	tag, err := tx.Exec(ctx, `
+        INSERT INTO coins (title, source, cost, actual_at, bucket)
+        SELECT DISTINCT ON (title, source, bucket) title, source, cost, actual_at, bucket
+        FROM (
+            SELECT title, source, cost, actual_at, `+sampleBucket("$1", "actual_at")+` AS bucket
+            FROM (
+                SELECT title, source, cost, COALESCE(actual_at, LOCALTIMESTAMP) AS actual_at
+                FROM coins_staging
+            ) AS staged
+        ) AS binned
+        ORDER BY title, source, bucket, actual_at DESC
+        ON CONFLICT (title, source, bucket) DO UPDATE
+        SET cost = EXCLUDED.cost, actual_at = EXCLUDED.actual_at
+        WHERE coins.actual_at <= EXCLUDED.actual_at
+    `, s.minSpacing.Microseconds())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to upsert staged rates", "err", err)
+		return errors.Wrapf(entities.ErrInternal, "failed to upsert staged rates: %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to commit transaction", "err", err)
+		return errors.Wrapf(entities.ErrInternal, "failed to commit transaction: %v", err)
+	}
+
+	s.logger.DebugContext(ctx, "Bulk upsert completed successfully", "number_of_coins", len(coins), "rows_written", tag.RowsAffected())
 	return nil
 }
 
+// sampleBucket returns the SQL expression of the bucket of a timestamp, the bucket width being given
+// in microseconds by param. Stored rates are binned by the min spacing, backfill coverage by the resolution.
+func sampleBucket(param, column string) string {
+	return fmt.Sprintf("date_bin(%s * INTERVAL '1 microsecond', %s, TIMESTAMP '1970-01-01')", param, column)
+}
+
+// GetCoinsList returns the coins to update: the stored ones and the tracked ones, without the untracked ones.
 func (s *Storage) GetCoinsList(ctx context.Context) ([]string, error) {
-	rows, err := s.dbPool.Query(ctx, "SELECT DISTINCT title FROM coins ORDER BY title ASC")
+	rows, err := s.dbPool.Query(ctx, `
+        SELECT title FROM coins
+        UNION
+        SELECT title FROM tracked_coins WHERE tracked
+        EXCEPT
+        SELECT title FROM tracked_coins WHERE NOT tracked
+        ORDER BY title ASC
+    `)
 	if err != nil {
-		slog.Error("Failed to fetch distinct titles of coins", "err", err)
+		s.logger.ErrorContext(ctx, "Failed to fetch distinct titles of coins", "err", err)
 		return nil, errors.Wrap(err, "failed to fetch distinct titles of coins")
 	}
 	defer rows.Close()
@@ -75,37 +203,32 @@ func (s *Storage) GetCoinsList(ctx context.Context) ([]string, error) {
 	for rows.Next() {
 		var title string
 		if err := rows.Scan(&title); err != nil {
-			slog.Error("Failed to scan row into title", "err", err)
+			s.logger.ErrorContext(ctx, "Failed to scan row into title", "err", err)
 			return nil, errors.Wrap(err, "failed to scan row into title")
 		}
 		titles = append(titles, title)
 	}
 
 	if err := rows.Err(); err != nil {
-		slog.Error("Error occurred while iterating over results", "err", err)
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
 		return nil, errors.Wrap(err, "error occurred while iterating over results")
 	}
 
-	slog.Info("Distinct coin titles fetched successfully", "number_of_titles", len(titles))
+	s.logger.DebugContext(ctx, "Distinct coin titles fetched successfully", "number_of_titles", len(titles))
 	return titles, nil
 }
 
 func (s *Storage) GetActualCoins(ctx context.Context, titles []string) ([]entities.Coin, error) {
 	query := `
-    SELECT title, cost
+    SELECT DISTINCT ON (title) title, cost, actual_at
         FROM coins
-        WHERE title = ANY($1::TEXT[]) AND actual_at IN (
-            SELECT MAX(actual_at) 
-            FROM coins 
-            WHERE title = ANY($1::TEXT[])
-            GROUP BY title
-        )
-        ORDER BY title ASC
+        WHERE title = ANY($1::TEXT[])
+        ORDER BY title ASC, actual_at DESC
     `
 
 	rows, err := s.dbPool.Query(ctx, query, titles)
 	if err != nil {
-		slog.Error("Failed to execute select query for actual coins", "err", err)
+		s.logger.ErrorContext(ctx, "Failed to execute select query for actual coins", "err", err)
 		return nil, errors.Wrap(err, "failed to execute select query for actual coins")
 	}
 	defer rows.Close()
@@ -113,47 +236,137 @@ func (s *Storage) GetActualCoins(ctx context.Context, titles []string) ([]entiti
 	result := make([]entities.Coin, 0)
 	for rows.Next() {
 		var coin entities.Coin
-		if err := rows.Scan(&coin.Title, &coin.Cost); err != nil {
-			slog.Error("Failed to scan row into coin object", "err", err)
+		if err := rows.Scan(&coin.Title, &coin.Cost, &coin.ActualAt); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to scan row into coin object", "err", err)
+			return nil, errors.Wrap(err, "failed to scan row into coin object")
+		}
+		result = append(result, coin)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
+		return nil, errors.Wrap(err, "error occurred while iterating over results")
+	}
+
+	s.logger.DebugContext(ctx, "Actual coin rates fetched successfully", "number_of_coins", len(result))
+	return result, nil
+}
+
+func (s *Storage) GetCoinsAt(ctx context.Context, titles []string, at time.Time) ([]entities.Coin, error) {
+	query := `
+    SELECT DISTINCT ON (title) title, cost, actual_at
+        FROM coins
+        WHERE title = ANY($1::TEXT[]) AND actual_at <= $2
+        ORDER BY title ASC, actual_at DESC
+    `
+
+	rows, err := s.dbPool.Query(ctx, query, titles, at.UTC())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to execute select query for coins at time", "at", at, "err", err)
+		return nil, errors.Wrap(err, "failed to execute select query for coins at time")
+	}
+	defer rows.Close()
+
+	result := make([]entities.Coin, 0)
+	for rows.Next() {
+		var coin entities.Coin
+		if err := rows.Scan(&coin.Title, &coin.Cost, &coin.ActualAt); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to scan row into coin object", "err", err)
 			return nil, errors.Wrap(err, "failed to scan row into coin object")
 		}
 		result = append(result, coin)
 	}
 
 	if err := rows.Err(); err != nil {
-		slog.Error("Error occurred while iterating over results", "err", err)
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
 		return nil, errors.Wrap(err, "error occurred while iterating over results")
 	}
 
-	slog.Info("Actual coin rates fetched successfully", "number_of_coins", len(result))
+	s.logger.DebugContext(ctx, "Coin rates at time fetched successfully", "at", at, "number_of_coins", len(result))
 	return result, nil
 }
 
-func (s *Storage) GetAggregateCoins(ctx context.Context, titles []string, aggType string) ([]entities.Coin, error) {
-	var aggFunc string
+func (s *Storage) GetCoinHistory(ctx context.Context, title string, from, to time.Time, limit int) ([]entities.Coin, error) {
+	query := `
+    SELECT title, cost, actual_at
+        FROM coins
+        WHERE title = $1 AND actual_at >= $2 AND actual_at <= $3
+        ORDER BY actual_at DESC
+        LIMIT $4
+    `
+
+	rows, err := s.dbPool.Query(ctx, query, title, from.UTC(), to.UTC(), limit)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to execute select query for coin history", "title", title, "err", err)
+		return nil, errors.Wrap(err, "failed to execute select query for coin history")
+	}
+	defer rows.Close()
+
+	result := make([]entities.Coin, 0)
+	for rows.Next() {
+		var coin entities.Coin
+		if err := rows.Scan(&coin.Title, &coin.Cost, &coin.ActualAt); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to scan row into coin object", "err", err)
+			return nil, errors.Wrap(err, "failed to scan row into coin object")
+		}
+		result = append(result, coin)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
+		return nil, errors.Wrap(err, "error occurred while iterating over results")
+	}
 
+	s.logger.DebugContext(ctx, "Coin history fetched successfully", "title", title, "number_of_coins", len(result))
+	return result, nil
+}
+
+func aggregateExpr(aggType string) (string, error) {
 	switch aggType {
 	case "AVG":
-		aggFunc = "AVG(cost)"
+		return "AVG(cost)", nil
 	case "MIN":
-		aggFunc = "MIN(cost)"
+		return "MIN(cost)", nil
 	case "MAX":
-		aggFunc = "MAX(cost)"
+		return "MAX(cost)", nil
+	case "COUNT":
+		return "COUNT(*)", nil
+	case "STDDEV":
+		return "COALESCE(STDDEV_SAMP(cost), 0)", nil
+	case "MEDIAN":
+		return "PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY cost)", nil
+	case "FIRST":
+		return firstCost, nil
+	case "LAST":
+		return lastCost, nil
+	case "CHANGE":
+		return fmt.Sprintf("%s - %s", lastCost, firstCost), nil
+	case "CHANGE_PCT":
+		return fmt.Sprintf("(%s - %s) * 100 / NULLIF(%s, 0)", lastCost, firstCost, firstCost), nil
 	default:
-		return nil, errors.Wrap(entities.ErrInvalidParam, "unsupported aggregation type")
+		return "", errors.Wrapf(entities.ErrInvalidParam, "unsupported aggregation type %q", aggType)
+	}
+}
+
+// GetAggregateCoins computes aggType over the rates of every title stored within [from, to]. An aggregate
+// that is undefined for a title, CHANGE_PCT from a zero first rate, is rejected with ErrInvalidParam.
+func (s *Storage) GetAggregateCoins(ctx context.Context, titles []string, aggType string, from, to time.Time) ([]entities.Coin, error) {
+	aggFunc, err := aggregateExpr(aggType)
+	if err != nil {
+		return nil, err
 	}
 
 	query := fmt.Sprintf(`
         SELECT title, %s AS cost
         FROM coins
-        WHERE title = ANY($1::TEXT[])
+        WHERE title = ANY($1::TEXT[]) AND actual_at >= $2 AND actual_at <= $3
         GROUP BY title
         ORDER BY title ASC
     `, aggFunc)
 
-	rows, err := s.dbPool.Query(ctx, query, titles)
+	rows, err := s.dbPool.Query(ctx, query, titles, from.UTC(), to.UTC())
 	if err != nil {
-		slog.Error("Failed to execute aggregated query", "err", err)
+		s.logger.ErrorContext(ctx, "Failed to execute aggregated query", "err", err)
 		return nil, errors.Wrap(err, "failed to execute aggregated query")
 	}
 	defer rows.Close()
@@ -163,20 +376,86 @@ func (s *Storage) GetAggregateCoins(ctx context.Context, titles []string, aggTyp
 		var coin entities.Coin
 		var cost sql.NullFloat64
 		if err := rows.Scan(&coin.Title, &cost); err != nil {
-			slog.Error("Failed to scan row into coin object", "err", err)
+			s.logger.ErrorContext(ctx, "Failed to scan row into coin object", "err", err)
 			return nil, errors.Wrap(err, "failed to scan row into coin object")
 		}
-		if cost.Valid {
-			coin.Cost = cost.Float64
+		if !cost.Valid {
+			s.logger.ErrorContext(ctx, "Aggregate is undefined", "title", coin.Title, "agg_type", aggType)
+			return nil, errors.Wrapf(entities.ErrInvalidParam, "%s of coin %q is undefined in the requested window", aggType, coin.Title)
 		}
+		coin.Cost = cost.Float64
 		result = append(result, coin)
 	}
 
 	if err := rows.Err(); err != nil {
-		slog.Error("Error occurred while iterating over results", "err", err)
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
+		return nil, errors.Wrap(err, "error occurred while iterating over results")
+	}
+
+	s.logger.DebugContext(ctx, "Aggregated coin rates fetched successfully", "number_of_coins", len(result))
+	return result, nil
+}
+
+// GetAggregateStats computes every aggType over the rates of every title stored within [from, to].
+// Aggregates that are undefined for a title, CHANGE_PCT from a zero first rate, are left out of its values.
+func (s *Storage) GetAggregateStats(ctx context.Context, titles []string, aggTypes []string, from, to time.Time) ([]entities.CoinStats, error) {
+	columns := make([]string, len(aggTypes))
+	for i, aggType := range aggTypes {
+		aggFunc, err := aggregateExpr(aggType)
+		if err != nil {
+			return nil, err
+		}
+		columns[i] = fmt.Sprintf("%s AS agg_%d", aggFunc, i)
+	}
+
+	query := fmt.Sprintf(`
+        SELECT title, MAX(actual_at), %s
+        FROM coins
+        WHERE title = ANY($1::TEXT[]) AND actual_at >= $2 AND actual_at <= $3
+        GROUP BY title
+        ORDER BY title ASC
+    `, strings.Join(columns, ", "))
+
+	rows, err := s.dbPool.Query(ctx, query, titles, from.UTC(), to.UTC())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to execute aggregated stats query", "err", err)
+		return nil, errors.Wrap(err, "failed to execute aggregated stats query")
+	}
+	defer rows.Close()
+
+	result := make([]entities.CoinStats, 0)
+	for rows.Next() {
+		var title string
+		var updatedAt time.Time
+		values := make([]sql.NullFloat64, len(aggTypes))
+		dest := make([]interface{}, 0, len(aggTypes)+2)
+		dest = append(dest, &title, &updatedAt)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to scan row into coin stats object", "err", err)
+			return nil, errors.Wrap(err, "failed to scan row into coin stats object")
+		}
+
+		stats := entities.CoinStats{
+			Title:     title,
+			Values:    make(map[string]float64, len(aggTypes)),
+			UpdatedAt: updatedAt,
+		}
+		for i, aggType := range aggTypes {
+			if values[i].Valid {
+				stats.Values[aggType] = values[i].Float64
+			}
+		}
+		result = append(result, stats)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
 		return nil, errors.Wrap(err, "error occurred while iterating over results")
 	}
 
-	slog.Info("Aggregated coin rates fetched successfully", "number_of_coins", len(result))
+	s.logger.DebugContext(ctx, "Aggregated coin stats fetched successfully", "number_of_coins", len(result), "agg_types", aggTypes)
 	return result, nil
 }
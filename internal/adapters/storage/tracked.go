@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+func (s *Storage) SetCoinTracked(ctx context.Context, title string, tracked bool) error {
+	_, err := s.dbPool.Exec(ctx, `
+        INSERT INTO tracked_coins (title, tracked, updated_at)
+        VALUES ($1, $2, NOW())
+        ON CONFLICT (title) DO UPDATE SET tracked = EXCLUDED.tracked, updated_at = EXCLUDED.updated_at
+    `, title, tracked)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to update tracked coin", "title", title, "tracked", tracked, "err", err)
+		return errors.Wrap(err, "failed to update tracked coin")
+	}
+
+	s.logger.DebugContext(ctx, "Tracked coin updated successfully", "title", title, "tracked", tracked)
+	return nil
+}
+
+func (s *Storage) ListTrackedCoins(ctx context.Context) ([]entities.TrackedCoin, error) {
+	rows, err := s.dbPool.Query(ctx, "SELECT title, tracked, updated_at FROM tracked_coins ORDER BY title ASC")
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch tracked coins", "err", err)
+		return nil, errors.Wrap(err, "failed to fetch tracked coins")
+	}
+	defer rows.Close()
+
+	result := make([]entities.TrackedCoin, 0)
+	for rows.Next() {
+		var coin entities.TrackedCoin
+		if err := rows.Scan(&coin.Title, &coin.Tracked, &coin.UpdatedAt); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to scan row into tracked coin", "err", err)
+			return nil, errors.Wrap(err, "failed to scan row into tracked coin")
+		}
+		result = append(result, coin)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.ErrorContext(ctx, "Error occurred while iterating over results", "err", err)
+		return nil, errors.Wrap(err, "error occurred while iterating over results")
+	}
+
+	s.logger.DebugContext(ctx, "Tracked coins fetched successfully", "number_of_coins", len(result))
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package tracing
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	defaultEndpoint    = "localhost:4317"
+	defaultServiceName = "cryptoproject"
+	defaultSampleRatio = 1.0
+	exportTimeout      = 10 * time.Second
+)
+
+// Tracer exports spans of the whole process over OTLP/gRPC. Until NewTracer is called the global
+// tracer provider is a no-op, so instrumented code costs next to nothing when tracing is disabled.
+type Tracer struct {
+	endpoint    string
+	serviceName string
+	sampleRatio float64
+	insecure    bool
+	logger      *slog.Logger
+	provider    *sdktrace.TracerProvider
+}
+
+type TracerOption func(*Tracer)
+
+// WithEndpoint sets the host:port of the OTLP/gRPC collector.
+func WithEndpoint(endpoint string) TracerOption {
+	return func(t *Tracer) {
+		t.endpoint = endpoint
+	}
+}
+
+func WithServiceName(serviceName string) TracerOption {
+	return func(t *Tracer) {
+		t.serviceName = serviceName
+	}
+}
+
+// WithSampleRatio sets the share of root traces that are recorded, child spans follow their parent.
+func WithSampleRatio(ratio float64) TracerOption {
+	return func(t *Tracer) {
+		t.sampleRatio = ratio
+	}
+}
+
+// WithInsecure disables TLS towards the collector, which is the usual setup for a local agent.
+func WithInsecure() TracerOption {
+	return func(t *Tracer) {
+		t.insecure = true
+	}
+}
+
+func WithLogger(logger *slog.Logger) TracerOption {
+	return func(t *Tracer) {
+		t.logger = logger
+	}
+}
+
+// NewTracer installs the OTLP exporter as the global tracer provider together with W3C trace context
+// and baggage propagation.
+func NewTracer(ctx context.Context, opts ...TracerOption) (*Tracer, error) {
+	t := &Tracer{
+		endpoint:    defaultEndpoint,
+		serviceName: defaultServiceName,
+		sampleRatio: defaultSampleRatio,
+		logger:      slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if t.endpoint == "" {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "collector endpoint cannot be empty")
+	}
+	if t.serviceName == "" {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "service name cannot be empty")
+	}
+	if t.sampleRatio < 0 || t.sampleRatio > 1 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "sample ratio must be between 0 and 1")
+	}
+	if t.logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "logger cannot be nil")
+	}
+
+	exporterOpts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(t.endpoint),
+		otlptracegrpc.WithTimeout(exportTimeout),
+	}
+	if t.insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(entities.ErrInternal, "failed to create OTLP exporter: %v", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(t.serviceName)))
+	if err != nil {
+		return nil, errors.Wrapf(entities.ErrInternal, "failed to build trace resource: %v", err)
+	}
+
+	t.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(t.sampleRatio))),
+	)
+	otel.SetTracerProvider(t.provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	t.logger.Info("Tracing enabled", "endpoint", t.endpoint, "service_name", t.serviceName, "sample_ratio", t.sampleRatio)
+	return t, nil
+}
+
+// Shutdown flushes the spans that are still buffered.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if err := t.provider.Shutdown(ctx); err != nil {
+		return errors.Wrapf(entities.ErrInternal, "failed to shut down tracer provider: %v", err)
+	}
+	return nil
+}
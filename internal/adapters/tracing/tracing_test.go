@@ -0,0 +1,38 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/tracing"
+	"Cryptoproject/internal/entities"
+)
+
+func TestNewTracer_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	for name, opts := range map[string][]tracing.TracerOption{
+		"empty endpoint":     {tracing.WithEndpoint("")},
+		"empty service name": {tracing.WithServiceName("")},
+		"negative ratio":     {tracing.WithSampleRatio(-0.1)},
+		"ratio above one":    {tracing.WithSampleRatio(1.5)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tracing.NewTracer(context.Background(), opts...)
+			require.ErrorIs(t, err, entities.ErrInvalidParam)
+		})
+	}
+}
+
+func TestNewTracer_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	// The exporter connects lazily, so no collector is needed to build and stop the tracer.
+	tracer, err := tracing.NewTracer(context.Background(), tracing.WithInsecure(), tracing.WithEndpoint("127.0.0.1:1"))
+	require.NoError(t, err)
+	require.NoError(t, tracer.Shutdown(context.Background()))
+}
@@ -5,15 +5,21 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"os/signal"
-	"sync"
+	"time"
 
-	"github.com/robfig/cron"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
 
 	"Cryptoproject/config"
+	"Cryptoproject/internal/adapters/broker"
 	"Cryptoproject/internal/adapters/client"
+	"Cryptoproject/internal/adapters/jwks"
+	"Cryptoproject/internal/adapters/logging"
+	"Cryptoproject/internal/adapters/metrics"
 	"Cryptoproject/internal/adapters/storage"
+	"Cryptoproject/internal/adapters/tracing"
 	"Cryptoproject/internal/cases"
+	mygrpc "Cryptoproject/internal/ports/grpc"
 	myhttp "Cryptoproject/internal/ports/http"
 	"log/slog"
 )
@@ -26,87 +32,233 @@ func NewApp() *App {
 
 func (a *App) Run() {
 	cfg, err := config.LoadCfg()
+	if errors.Is(err, pflag.ErrHelp) {
+		os.Exit(0)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
 	}
 
-	connStr := cfg.ConnStr
+	logLevel := new(slog.LevelVar)
+	logger, err := logging.NewLogger(
+		logging.WithLevel(cfg.LogLevel),
+		logging.WithLevelVar(logLevel),
+		logging.WithFormat(cfg.LogFormat),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to set up logging: %v\n", err)
+		os.Exit(1)
+	}
+	// Libraries that log through the default logger get the same level, format and redaction.
+	slog.SetDefault(logger)
+
+	ctx := context.Background()
+
+	var tracer *tracing.Tracer
+	if cfg.TracingEnabled {
+		tracerOpts := []tracing.TracerOption{
+			tracing.WithEndpoint(cfg.TracingEndpoint),
+			tracing.WithSampleRatio(cfg.TracingSampleRatio),
+			tracing.WithLogger(logger),
+		}
+		if cfg.TracingInsecure {
+			tracerOpts = append(tracerOpts, tracing.WithInsecure())
+		}
+		tracer, err = tracing.NewTracer(ctx, tracerOpts...)
+		if err != nil {
+			logger.Error("Failed to set up tracing", "endpoint", cfg.TracingEndpoint, "err", err)
+			fmt.Fprintf(os.Stderr, "Failed to set up tracing: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	servPort := cfg.SrvPort
 
-	client, err := client.NewClient(client.WithCustomCostIn("USD"))
+	client, err := client.NewClient(client.WithCustomCostIn("USD"), client.WithLogger(logger))
 	if err != nil {
-		slog.Error("Failed to create client", "err", err)
+		logger.Error("Failed to create client", "err", err)
 		fmt.Fprintf(os.Stderr, "Failed to create client: %v\n", err)
 		os.Exit(1)
 	}
 
-	storage, err := storage.NewStorage(connStr)
+	storage, err := storage.NewStorage(cfg.DSN(), storage.WithMinSpacing(cfg.MinSampleSpacing), storage.WithLogger(logger))
 	if err != nil {
-		slog.Error("Failed to create storage", "err", err)
+		logger.Error("Failed to create storage", "err", err)
 		fmt.Fprintf(os.Stderr, "Failed to create storage: %v\n", err)
 		os.Exit(1)
 	}
 
-	service, err := cases.NewService(storage, client)
+	appMetrics, err := metrics.NewMetrics()
+	if err != nil {
+		logger.Error("Failed to create metrics", "err", err)
+		fmt.Fprintf(os.Stderr, "Failed to create metrics: %v\n", err)
+		os.Exit(1)
+	}
+	if err := appMetrics.RegisterPool(storage.PoolStat); err != nil {
+		logger.Error("Failed to register pool metrics", "err", err)
+		fmt.Fprintf(os.Stderr, "Failed to register pool metrics: %v\n", err)
+		os.Exit(1)
+	}
+
+	broker, err := broker.NewBroker(broker.WithBufferSize(cfg.StreamBufferSize), broker.WithLogger(logger))
+	if err != nil {
+		logger.Error("Failed to create broker", "err", err)
+		fmt.Fprintf(os.Stderr, "Failed to create broker: %v\n", err)
+		os.Exit(1)
+	}
+
+	service, err := cases.NewService(storage, metrics.InstrumentProvider("cryptocompare", client, appMetrics),
+		cases.WithMaxStaleness(cfg.MaxStaleness),
+		cases.WithPublisher(broker),
+		cases.WithProviderCircuit(client),
+		cases.WithTrackedCoins(cfg.TrackedCoins),
+		cases.WithOutlierGuard(cfg.OutlierMaxDeviation, cfg.OutlierWindow),
+		cases.WithUpdateInterval(cfg.UpdateInterval),
+		cases.WithQualityWindow(cfg.QualityWindow),
+		cases.WithJumpSigma(cfg.QualityJumpSigma),
+		cases.WithLogger(logger),
+	)
 	if err != nil {
-		slog.Error("Failed to create service", "err", err)
+		logger.Error("Failed to create service", "err", err)
 		fmt.Fprintf(os.Stderr, "Failed to create service: %v\n", err)
 		os.Exit(1)
 	}
 
-	ctx := context.Background()
+	scheduler, err := NewScheduler(updateSchedule(cfg.UpdateInterval), func(ctx context.Context) error {
+		start := time.Now()
+		err := service.UpdateRates(ctx)
+		appMetrics.ObserveUpdateRates(time.Since(start), err)
+		return err
+	}, WithSchedulerLogger(logger))
+	if err != nil {
+		logger.Error("Cron job setup failed", "err", err)
+		fmt.Fprintf(os.Stderr, "Cron job failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	qualityScanner, err := NewScheduler(updateSchedule(cfg.QualityScanInterval), func(ctx context.Context) error {
+		report, err := service.GetQualityReport(ctx, time.Time{}, time.Time{})
+		if err != nil {
+			return err
+		}
+		appMetrics.ObserveQualityReport(report)
+		return nil
+	}, WithSchedulerLogger(logger))
+	if err != nil {
+		logger.Error("Quality scan setup failed", "err", err)
+		fmt.Fprintf(os.Stderr, "Quality scan setup failed: %v\n", err)
+		os.Exit(1)
+	}
 
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		c := cron.New()
-		err := c.AddFunc("@every 5m", func() { _ = service.UpdateRates(ctx) })
+	serverOpts := []myhttp.ServerOption{
+		myhttp.WithBroker(broker),
+		myhttp.WithMetrics(appMetrics),
+		myhttp.WithLogger(logger),
+		myhttp.WithRequestTimeout(cfg.RequestTimeout),
+		myhttp.WithMaxBodyBytes(cfg.MaxBodyBytes),
+		myhttp.WithCORS(myhttp.CORSConfig{
+			AllowedOrigins: cfg.CORSAllowedOrigins,
+			AllowedMethods: cfg.CORSAllowedMethods,
+			AllowedHeaders: cfg.CORSAllowedHeaders,
+			MaxAge:         cfg.CORSMaxAge,
+		}),
+	}
+	for route, timeout := range cfg.RouteTimeouts {
+		serverOpts = append(serverOpts, myhttp.WithRouteTimeout(route, timeout))
+	}
+	switch cfg.AuthMode {
+	case "none":
+		logger.Warn("HTTP authentication is disabled")
+	case "api-key":
+		serverOpts = append(serverOpts, myhttp.WithAPIKeyAuth(cfg.AdminToken))
+	case "jwt":
+		keySet, err := jwks.NewKeySet(ctx, cfg.JWKSSource, jwks.WithLogger(logger))
 		if err != nil {
-			slog.Error("Cron job setup failed", "err", err)
-			fmt.Fprintf(os.Stderr, "Cron job failed: %v\n", err)
+			logger.Error("Failed to load JWKS", "source", cfg.JWKSSource, "err", err)
+			fmt.Fprintf(os.Stderr, "Failed to load JWKS: %v\n", err)
 			os.Exit(1)
 		}
-		c.Start()
-	}()
+		serverOpts = append(serverOpts, myhttp.WithJWTAuth(keySet, cfg.JWTIssuer, cfg.JWTAudience))
+	default:
+		logger.Error("Unknown authentication mode", "auth_mode", cfg.AuthMode)
+		fmt.Fprintf(os.Stderr, "Unknown authentication mode: %s\n", cfg.AuthMode)
+		os.Exit(1)
+	}
 
-	server, err := myhttp.NewServer(servPort, service)
+	server, err := myhttp.NewServer(servPort, service, serverOpts...)
 	if err != nil {
-		slog.Error("Failed to create server", "err", err)
+		logger.Error("Failed to create server", "err", err)
 		fmt.Fprintf(os.Stderr, "Failed to create server: %v\n", err)
 		os.Exit(1)
 	}
 
-	srv := &http.Server{
-		Addr:    servPort,
-		Handler: server.Router,
+	grpcServer, err := mygrpc.NewServer(cfg.GrpcPort, service, mygrpc.WithBroker(broker), mygrpc.WithLogger(logger))
+	if err != nil {
+		logger.Error("Failed to create gRPC server", "err", err)
+		fmt.Fprintf(os.Stderr, "Failed to create gRPC server: %v\n", err)
+		os.Exit(1)
 	}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
-
-	go func() {
-		<-quit
-		slog.Info("Received shutdown signal")
-		fmt.Println("Shutting down server...")
+	watcher, err := config.Watch(os.Args[1:], cfg, config.WithLogger(logger))
+	switch {
+	case errors.Is(err, config.ErrNoConfigFile):
+		logger.Info("Configuration hot reload disabled, no config file in use")
+	case err != nil:
+		logger.Error("Failed to watch configuration", "err", err)
+		fmt.Fprintf(os.Stderr, "Failed to watch configuration: %v\n", err)
+		os.Exit(1)
+	default:
+		watcher.Subscribe(func(cfg *config.Config) {
+			if level, err := logging.ParseLevel(cfg.LogLevel); err == nil {
+				logLevel.Set(level)
+			}
+			service.SetTrackedCoins(cfg.TrackedCoins)
+			service.SetUpdateInterval(cfg.UpdateInterval)
+			if err := scheduler.SetSchedule(updateSchedule(cfg.UpdateInterval)); err != nil {
+				logger.Error("Failed to change update schedule", "update_interval", cfg.UpdateInterval, "err", err)
+			}
+		})
+	}
 
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
+	lifecycle, err := NewLifecycle(WithDrainTimeout(cfg.ShutdownTimeout), WithLogger(logger))
+	if err != nil {
+		logger.Error("Failed to create lifecycle", "err", err)
+		fmt.Fprintf(os.Stderr, "Failed to create lifecycle: %v\n", err)
+		os.Exit(1)
+	}
 
-		if err := srv.Shutdown(ctx); err != nil {
-			slog.Error("Server shutdown error", "err", err)
-			fmt.Fprintf(os.Stderr, "Server shutdown error: %v\n", err)
+	// Components stop in reverse order: servers drain first, then the scheduled jobs finish their runs,
+	// then the config watcher, storage and the tracer are closed.
+	if tracer != nil {
+		lifecycle.Add("tracer", nil, tracer.Shutdown)
+	}
+	lifecycle.Add("storage", nil, func(context.Context) error {
+		storage.Close()
+		return nil
+	})
+	if watcher != nil {
+		lifecycle.Add("config watcher", nil, watcher.Close)
+	}
+	lifecycle.Add("scheduler", scheduler.Start, scheduler.Stop)
+	lifecycle.Add("quality scanner", qualityScanner.Start, qualityScanner.Stop)
+	lifecycle.Add("grpc server", grpcServer.Start, grpcServer.Shutdown)
+	lifecycle.Add("http server", func() error {
+		logger.Info("Server starting", "port", servPort)
+		fmt.Printf("Server running on port %s\n", servPort)
+		if err := server.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
 		}
-	}()
+		return nil
+	}, server.Shutdown)
 
-	slog.Info("Server starting", "port", servPort)
-	fmt.Printf("Server running on port %s\n", servPort)
-	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		slog.Error("ListenAndServe error", "err", err)
-		fmt.Fprintf(os.Stderr, "Failed to start server: %v\n", err)
+	if err := lifecycle.Run(ctx); err != nil {
+		logger.Error("Application stopped with error", "err", err)
+		fmt.Fprintf(os.Stderr, "Application stopped with error: %v\n", err)
 		os.Exit(1)
 	}
+}
 
-	wg.Wait()
+func updateSchedule(interval time.Duration) string {
+	return "@every " + interval.String()
 }
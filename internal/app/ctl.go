@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"Cryptoproject/config"
+	"Cryptoproject/internal/adapters/client"
+	"Cryptoproject/internal/adapters/logging"
+	"Cryptoproject/internal/adapters/storage"
+	"Cryptoproject/internal/cases"
+	"Cryptoproject/internal/cli"
+	"Cryptoproject/internal/entities"
+)
+
+// Ctl wires the cryptoctl admin CLI to the same configuration, storage and service as the server.
+type Ctl struct {
+	stderr io.Writer
+}
+
+type CtlOption func(*Ctl)
+
+// WithErrOutput sets where usage, errors and logs are written, os.Stderr by default.
+func WithErrOutput(w io.Writer) CtlOption {
+	return func(c *Ctl) {
+		c.stderr = w
+	}
+}
+
+func NewCtl(opts ...CtlOption) *Ctl {
+	c := &Ctl{stderr: os.Stderr}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Run executes one cryptoctl command and returns the process exit code.
+func (c *Ctl) Run(args []string) int {
+	flags := pflag.NewFlagSet("cryptoctl", pflag.ContinueOnError)
+	flags.SetInterspersed(false)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() { fmt.Fprint(c.stderr, cli.Usage()) }
+	configFile := flags.String("config", "", "path to the YAML config file")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() == 0 {
+		fmt.Fprint(c.stderr, cli.Usage())
+		return 2
+	}
+
+	var configArgs []string
+	if *configFile != "" {
+		configArgs = []string{"--config", *configFile}
+	}
+	cfg, err := config.LoadCLI(configArgs)
+	if err != nil {
+		fmt.Fprintf(c.stderr, "Error loading configuration: %v\n", err)
+		return 1
+	}
+
+	// Logs go to stderr so that command output can be piped.
+	logger, err := logging.NewLogger(
+		logging.WithLevel(cfg.LogLevel),
+		logging.WithFormat(cfg.LogFormat),
+		logging.WithOutput(c.stderr),
+	)
+	if err != nil {
+		fmt.Fprintf(c.stderr, "Failed to set up logging: %v\n", err)
+		return 1
+	}
+
+	provider, err := client.NewClient(client.WithCustomCostIn("USD"), client.WithLogger(logger))
+	if err != nil {
+		fmt.Fprintf(c.stderr, "Failed to create client: %v\n", err)
+		return 1
+	}
+
+	store, err := storage.NewStorage(cfg.DSN(), storage.WithMinSpacing(cfg.MinSampleSpacing), storage.WithLogger(logger))
+	if err != nil {
+		fmt.Fprintf(c.stderr, "Failed to create storage: %v\n", err)
+		return 1
+	}
+	defer store.Close()
+
+	service, err := cases.NewService(store, provider,
+		cases.WithMaxStaleness(cfg.MaxStaleness),
+		cases.WithTrackedCoins(cfg.TrackedCoins),
+		cases.WithOutlierGuard(cfg.OutlierMaxDeviation, cfg.OutlierWindow),
+		cases.WithHistoryProvider(provider),
+		cases.WithLogger(logger),
+	)
+	if err != nil {
+		fmt.Fprintf(c.stderr, "Failed to create service: %v\n", err)
+		return 1
+	}
+
+	commands, err := cli.NewCLI(service, store)
+	if err != nil {
+		fmt.Fprintf(c.stderr, "Failed to create CLI: %v\n", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := commands.Run(ctx, flags.Args()); err != nil {
+		fmt.Fprintf(c.stderr, "Error: %v\n", err)
+		if errors.Is(err, entities.ErrInvalidParam) {
+			fmt.Fprintln(c.stderr, "Run cryptoctl --help for usage.")
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,26 @@
+package app_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/app"
+)
+
+func TestCtl_RunWithoutAdminToken(t *testing.T) {
+	t.Setenv("CRYPTOPROJECT_ADMIN_TOKEN", "")
+	require.NoError(t, os.Unsetenv("CRYPTOPROJECT_ADMIN_TOKEN"))
+
+	var stderr bytes.Buffer
+	ctl := app.NewCtl(app.WithErrOutput(&stderr))
+
+	// The command is rejected before the database is queried, the configuration must load first.
+	code := ctl.Run([]string{"--config", "../../config/cfg.yaml", "migrate", "sideways"})
+
+	require.Equal(t, 2, code, stderr.String())
+	require.NotContains(t, stderr.String(), "Error loading configuration")
+	require.Contains(t, stderr.String(), "migrate expects up, down or rebucket")
+}
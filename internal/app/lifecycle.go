@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const defaultDrainTimeout = 30 * time.Second
+
+type component struct {
+	name  string
+	start func() error
+	stop  func(ctx context.Context) error
+}
+
+// Lifecycle starts components in the order they were added and stops them in reverse order, so that
+// servers stop taking requests before the scheduler and storage they depend on are shut down.
+type Lifecycle struct {
+	drainTimeout time.Duration
+	signals      []os.Signal
+	components   []component
+	logger       *slog.Logger
+}
+
+type LifecycleOption func(*Lifecycle)
+
+// WithDrainTimeout bounds the time all components together get to stop.
+func WithDrainTimeout(timeout time.Duration) LifecycleOption {
+	return func(l *Lifecycle) {
+		l.drainTimeout = timeout
+	}
+}
+
+// WithSignals replaces the signals that trigger a shutdown, SIGINT and SIGTERM by default.
+func WithSignals(signals ...os.Signal) LifecycleOption {
+	return func(l *Lifecycle) {
+		l.signals = signals
+	}
+}
+
+func WithLogger(logger *slog.Logger) LifecycleOption {
+	return func(l *Lifecycle) {
+		l.logger = logger
+	}
+}
+
+func NewLifecycle(opts ...LifecycleOption) (*Lifecycle, error) {
+	l := &Lifecycle{
+		drainTimeout: defaultDrainTimeout,
+		signals:      []os.Signal{os.Interrupt, syscall.SIGTERM},
+		logger:       slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if l.drainTimeout <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "drain timeout must be greater than zero")
+	}
+	if l.logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "logger cannot be nil")
+	}
+	return l, nil
+}
+
+// Add registers a component. start may block while the component runs and must return nil once it is
+// stopped, an error from start shuts the whole application down. Either function may be nil.
+func (l *Lifecycle) Add(name string, start func() error, stop func(ctx context.Context) error) {
+	l.components = append(l.components, component{name: name, start: start, stop: stop})
+}
+
+// Run starts all components and blocks until ctx is done, a shutdown signal arrives or a component fails.
+// It then stops the components within the drain timeout and returns the component failure or, failing that,
+// the first error met while stopping. Every stop error is logged.
+func (l *Lifecycle) Run(ctx context.Context) error {
+	ctx, stopSignals := signal.NotifyContext(ctx, l.signals...)
+	defer stopSignals()
+
+	failures := make(chan error, len(l.components))
+	var running sync.WaitGroup
+	for _, c := range l.components {
+		if c.start == nil {
+			continue
+		}
+		l.logger.Info("Starting component", "component", c.name)
+		running.Add(1)
+		go func() {
+			defer running.Done()
+			if err := c.start(); err != nil {
+				l.logger.Error("Component failed", "component", c.name, "err", err)
+				failures <- errors.Wrapf(err, "%s failed", c.name)
+			}
+		}()
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		l.logger.Info("Shutdown requested")
+	case runErr = <-failures:
+	}
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), l.drainTimeout)
+	defer cancel()
+
+	firstErr := runErr
+	for i := len(l.components) - 1; i >= 0; i-- {
+		c := l.components[i]
+		if c.stop == nil {
+			continue
+		}
+		l.logger.Info("Stopping component", "component", c.name)
+		if err := c.stop(drainCtx); err != nil {
+			l.logger.Error("Failed to stop component", "component", c.name, "err", err)
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "failed to stop %s", c.name)
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-drainCtx.Done():
+		l.logger.Error("Components did not stop within the drain timeout", "drain_timeout", l.drainTimeout)
+		if firstErr == nil {
+			firstErr = errors.Wrap(drainCtx.Err(), "components did not stop within the drain timeout")
+		}
+	}
+
+	l.logger.Info("Shutdown complete")
+	return firstErr
+}
@@ -0,0 +1,275 @@
+package app_test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/app"
+	"Cryptoproject/internal/entities"
+)
+
+// blockingComponent runs until it is stopped, like a server.
+type blockingComponent struct {
+	name    string
+	stopped chan struct{}
+	order   *stopOrder
+}
+
+type stopOrder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (o *stopOrder) add(name string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.names = append(o.names, name)
+}
+
+func newBlockingComponent(name string, order *stopOrder) *blockingComponent {
+	return &blockingComponent{name: name, stopped: make(chan struct{}), order: order}
+}
+
+func (c *blockingComponent) Start() error {
+	<-c.stopped
+	return nil
+}
+
+func (c *blockingComponent) Stop(context.Context) error {
+	c.order.add(c.name)
+	close(c.stopped)
+	return nil
+}
+
+func TestLifecycle_StopsInReverseOrder(t *testing.T) {
+	t.Parallel()
+
+	lifecycle, err := app.NewLifecycle(app.WithDrainTimeout(time.Second))
+	require.NoError(t, err)
+
+	order := &stopOrder{}
+	lifecycle.Add("storage", nil, func(context.Context) error {
+		order.add("storage")
+		return nil
+	})
+	for _, name := range []string{"scheduler", "http server"} {
+		c := newBlockingComponent(name, order)
+		lifecycle.Add(name, c.Start, c.Stop)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, lifecycle.Run(ctx))
+	require.Equal(t, []string{"http server", "scheduler", "storage"}, order.names)
+}
+
+func TestLifecycle_ComponentFailureShutsDown(t *testing.T) {
+	t.Parallel()
+
+	lifecycle, err := app.NewLifecycle(app.WithDrainTimeout(time.Second))
+	require.NoError(t, err)
+
+	order := &stopOrder{}
+	healthy := newBlockingComponent("grpc server", order)
+	lifecycle.Add("grpc server", healthy.Start, healthy.Stop)
+	lifecycle.Add("http server", func() error {
+		return errors.New("address already in use")
+	}, func(context.Context) error {
+		order.add("http server")
+		return nil
+	})
+
+	err = lifecycle.Run(context.Background())
+
+	require.ErrorContains(t, err, "http server failed: address already in use")
+	require.Equal(t, []string{"http server", "grpc server"}, order.names)
+}
+
+func TestLifecycle_DrainTimeout(t *testing.T) {
+	t.Parallel()
+
+	lifecycle, err := app.NewLifecycle(app.WithDrainTimeout(50 * time.Millisecond))
+	require.NoError(t, err)
+
+	storageClosed := false
+	lifecycle.Add("storage", nil, func(context.Context) error {
+		storageClosed = true
+		return nil
+	})
+	lifecycle.Add("http server", nil, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err = lifecycle.Run(ctx)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "failed to stop http server")
+	require.True(t, storageClosed)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+// TestLifecycle_Signal is not parallel because it signals the test process.
+func TestLifecycle_Signal(t *testing.T) {
+	lifecycle, err := app.NewLifecycle(app.WithSignals(syscall.SIGUSR1))
+	require.NoError(t, err)
+
+	order := &stopOrder{}
+	c := newBlockingComponent("http server", order)
+	lifecycle.Add("http server", func() error {
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+		return c.Start()
+	}, c.Stop)
+
+	require.NoError(t, lifecycle.Run(context.Background()))
+	require.Equal(t, []string{"http server"}, order.names)
+}
+
+func TestNewLifecycle_InvalidDrainTimeout(t *testing.T) {
+	t.Parallel()
+
+	_, err := app.NewLifecycle(app.WithDrainTimeout(0))
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestScheduler_StopWaitsForRunningJob(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	scheduler, err := app.NewScheduler("@every 1s", func(ctx context.Context) error {
+		once.Do(func() { close(started) })
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	require.NoError(t, err)
+	require.NoError(t, scheduler.Start())
+	<-started
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- scheduler.Stop(context.Background()) }()
+
+	select {
+	case <-stopped:
+		t.Fatal("scheduler stopped while its job was still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-stopped)
+}
+
+func TestScheduler_StopCancelsJobAfterDeadline(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	var once sync.Once
+	scheduler, err := app.NewScheduler("@every 1s", func(ctx context.Context) error {
+		once.Do(func() { close(started) })
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	})
+	require.NoError(t, err)
+	require.NoError(t, scheduler.Start())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, scheduler.Stop(ctx), context.DeadlineExceeded)
+	<-cancelled
+}
+
+func TestScheduler_SkipsTickWhileRunning(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	var concurrent atomic.Int32
+	overlapped := make(chan struct{}, 1)
+	release := make(chan struct{})
+	scheduler, err := app.NewScheduler("@every 1s", func(ctx context.Context) error {
+		runs.Add(1)
+		if concurrent.Add(1) > 1 {
+			select {
+			case overlapped <- struct{}{}:
+			default:
+			}
+		}
+		defer concurrent.Add(-1)
+
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, scheduler.Start())
+
+	// The first run blocks across the following ticks.
+	time.Sleep(3500 * time.Millisecond)
+	require.Equal(t, int32(1), runs.Load(), "ticks during the run are skipped")
+
+	close(release)
+	require.Eventually(t, func() bool { return runs.Load() >= 2 }, 3*time.Second, 50*time.Millisecond,
+		"the job runs again once the previous run finished")
+	require.NoError(t, scheduler.Stop(context.Background()))
+
+	select {
+	case <-overlapped:
+		t.Fatal("runs overlapped")
+	default:
+	}
+}
+
+func TestNewScheduler_InvalidSpec(t *testing.T) {
+	t.Parallel()
+
+	_, err := app.NewScheduler("not a schedule", func(context.Context) error { return nil })
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestScheduler_SetSchedule(t *testing.T) {
+	t.Parallel()
+
+	ran := make(chan struct{}, 1)
+	scheduler, err := app.NewScheduler("@every 1h", func(context.Context) error {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, scheduler.Start())
+	defer scheduler.Stop(context.Background()) //nolint:errcheck //ok
+
+	require.ErrorIs(t, scheduler.SetSchedule("not a schedule"), entities.ErrInvalidParam)
+	require.NoError(t, scheduler.SetSchedule("@every 1s"))
+
+	select {
+	case <-ran:
+	case <-time.After(3 * time.Second):
+		t.Fatal("job did not run on the new schedule")
+	}
+}
@@ -0,0 +1,164 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron"
+
+	"Cryptoproject/internal/entities"
+)
+
+// Scheduler runs a job on a cron schedule. A tick that arrives while the previous run is still in flight
+// is skipped, so runs never overlap. Stopping it waits for a run that is already in flight instead
+// of abandoning it halfway through.
+type Scheduler struct {
+	job      func(ctx context.Context) error
+	ctx      context.Context
+	cancel   context.CancelFunc
+	mu       sync.Mutex
+	cron     *cron.Cron
+	spec     string
+	started  bool
+	stopped  bool
+	inFlight bool
+	running  sync.WaitGroup
+	logger   *slog.Logger
+}
+
+type SchedulerOption func(*Scheduler)
+
+func WithSchedulerLogger(logger *slog.Logger) SchedulerOption {
+	return func(s *Scheduler) {
+		s.logger = logger
+	}
+}
+
+func NewScheduler(spec string, job func(ctx context.Context) error, opts ...SchedulerOption) (*Scheduler, error) {
+	if job == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "job not provided")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Scheduler{
+		job:    job,
+		ctx:    ctx,
+		cancel: cancel,
+		logger: slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.logger == nil {
+		cancel()
+		return nil, errors.Wrap(entities.ErrInvalidParam, "logger cannot be nil")
+	}
+
+	c, err := s.newCron(spec)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	s.cron = c
+	s.spec = spec
+	return s, nil
+}
+
+func (s *Scheduler) newCron(spec string) (*cron.Cron, error) {
+	c := cron.New()
+	err := c.AddFunc(spec, func() {
+		if !s.begin(spec) {
+			return
+		}
+		defer s.end()
+
+		if err := s.job(s.ctx); err != nil {
+			s.logger.ErrorContext(s.ctx, "Scheduled job failed", "spec", spec, "err", err)
+		}
+	})
+	if err != nil {
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "invalid schedule %q: %v", spec, err)
+	}
+	return c, nil
+}
+
+// SetSchedule replaces the schedule of future runs. A run already in flight is not interrupted.
+func (s *Scheduler) SetSchedule(spec string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if spec == s.spec || s.stopped {
+		return nil
+	}
+	c, err := s.newCron(spec)
+	if err != nil {
+		return err
+	}
+
+	s.cron.Stop()
+	s.cron = c
+	if s.started {
+		s.cron.Start()
+	}
+	s.logger.Info("Schedule changed", "old_spec", s.spec, "spec", spec)
+	s.spec = spec
+	return nil
+}
+
+// begin registers a run unless the scheduler is already stopping or the previous run has not finished.
+func (s *Scheduler) begin(spec string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return false
+	}
+	if s.inFlight {
+		s.logger.WarnContext(s.ctx, "Skipping scheduled run, the previous run is still in flight", "spec", spec)
+		return false
+	}
+	s.inFlight = true
+	s.running.Add(1)
+	return true
+}
+
+func (s *Scheduler) end() {
+	s.mu.Lock()
+	s.inFlight = false
+	s.mu.Unlock()
+	s.running.Done()
+}
+
+func (s *Scheduler) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.started = true
+	s.cron.Start()
+	return nil
+}
+
+// Stop prevents further runs and waits for the current one. If ctx ends first, the run is cancelled.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopped = true
+	s.cron.Stop()
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		return errors.Wrap(ctx.Err(), "scheduled job did not finish in time")
+	}
+}
@@ -0,0 +1,141 @@
+package cases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	apiKeyPrefix      = "ck_"
+	apiKeyBytes       = 32
+	apiKeyShownLength = len(apiKeyPrefix) + 8
+	defaultUsageDays  = 30
+)
+
+// CreateAPIKey generates a new key and stores only its hash. The plaintext key is returned once
+// and cannot be recovered afterwards.
+func (s *Service) CreateAPIKey(ctx context.Context, name string, rateLimit float64, burst int, dailyQuota int64) (*entities.APIKey, string, error) {
+	key, err := entities.NewAPIKey(name, rateLimit, burst, dailyQuota)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Invalid API key", "name", name, "err", err)
+		return nil, "", errors.Wrap(err, "invalid API key")
+	}
+
+	secret := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(secret); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to generate API key", "err", err)
+		return nil, "", errors.Wrap(entities.ErrInternal, "failed to generate API key")
+	}
+	plaintext := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	key.Prefix = plaintext[:apiKeyShownLength]
+	key.Hash = entities.HashAPIKey(plaintext)
+
+	created, err := s.storage.CreateAPIKey(ctx, *key)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to store API key", "name", name, "err", err)
+		return nil, "", errors.Wrap(entities.ErrInternal, "failed to create API key")
+	}
+
+	s.logger.InfoContext(ctx, "API key created", "api_key_id", created.ID, "name", created.Name)
+	return &created, plaintext, nil
+}
+
+func (s *Service) ListAPIKeys(ctx context.Context) ([]*entities.APIKey, error) {
+	keys, err := s.storage.ListAPIKeys(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list API keys", "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to list API keys")
+	}
+
+	result := make([]*entities.APIKey, len(keys))
+	for i := range keys {
+		result[i] = &keys[i]
+	}
+	return result, nil
+}
+
+func (s *Service) RevokeAPIKey(ctx context.Context, id int64) error {
+	if err := s.storage.RevokeAPIKey(ctx, id); err != nil {
+		if errors.Is(err, entities.ErrNotFound) {
+			return errors.Wrap(err, "failed to revoke API key")
+		}
+		s.logger.ErrorContext(ctx, "Failed to revoke API key", "api_key_id", id, "err", err)
+		return errors.Wrap(entities.ErrInternal, "failed to revoke API key")
+	}
+
+	s.logger.InfoContext(ctx, "API key revoked", "api_key_id", id)
+	return nil
+}
+
+// Authenticate resolves an active API key from its plaintext value.
+func (s *Service) Authenticate(ctx context.Context, plaintext string) (*entities.APIKey, error) {
+	if plaintext == "" {
+		return nil, errors.Wrap(entities.ErrUnauthorized, "API key is missing")
+	}
+
+	key, err := s.storage.GetAPIKeyByHash(ctx, entities.HashAPIKey(plaintext))
+	if errors.Is(err, entities.ErrNotFound) {
+		return nil, errors.Wrap(entities.ErrUnauthorized, "API key is invalid")
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch API key", "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to authenticate API key")
+	}
+
+	if key.Revoked() {
+		s.logger.WarnContext(ctx, "Revoked API key used", "api_key_id", key.ID)
+		return nil, errors.Wrap(entities.ErrUnauthorized, "API key is revoked")
+	}
+
+	return &key, nil
+}
+
+// ConsumeQuota counts a request against the daily quota of the key.
+func (s *Service) ConsumeQuota(ctx context.Context, key *entities.APIKey) error {
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+
+	allowed, err := s.storage.ConsumeAPIKeyQuota(ctx, key.ID, day, key.DailyQuota)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to record API key usage", "api_key_id", key.ID, "err", err)
+		return errors.Wrap(entities.ErrInternal, "failed to record API key usage")
+	}
+	if !allowed {
+		s.logger.WarnContext(ctx, "API key daily quota exceeded", "api_key_id", key.ID, "daily_quota", key.DailyQuota)
+		return errors.Wrapf(entities.ErrRateLimited, "daily quota of %d requests exceeded", key.DailyQuota)
+	}
+
+	return nil
+}
+
+// GetAPIKeyUsage returns per key and day request counters between from and to inclusive.
+// A zero to means today and a zero from means 30 days before to.
+func (s *Service) GetAPIKeyUsage(ctx context.Context, from, to time.Time) ([]*entities.APIKeyUsage, error) {
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultUsageDays)
+	}
+	if from.After(to) {
+		s.logger.ErrorContext(ctx, "Invalid usage window", "from", from, "to", to)
+		return nil, errors.Wrap(entities.ErrInvalidParam, "from must not be after to")
+	}
+
+	usage, err := s.storage.GetAPIKeyUsage(ctx, from, to)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch API key usage", "from", from, "to", to, "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to get API key usage")
+	}
+
+	result := make([]*entities.APIKeyUsage, len(usage))
+	for i := range usage {
+		result[i] = &usage[i]
+	}
+	return result, nil
+}
@@ -0,0 +1,121 @@
+package cases_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func TestService_CreateAPIKey_Success(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	var stored entities.APIKey
+	mockStorage.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, key entities.APIKey) (entities.APIKey, error) {
+			stored = key
+			key.ID = 7
+			return key, nil
+		})
+
+	key, plaintext, err := service.CreateAPIKey(context.Background(), "billing", 5, 10, 1000)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(7), key.ID)
+	require.True(t, strings.HasPrefix(plaintext, "ck_"))
+	require.True(t, strings.HasPrefix(plaintext, stored.Prefix))
+	require.Equal(t, entities.HashAPIKey(plaintext), stored.Hash)
+	require.NotContains(t, stored.Hash, plaintext)
+}
+
+func TestService_CreateAPIKey_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := setupService(t)
+
+	key, plaintext, err := service.CreateAPIKey(context.Background(), "billing", 0, 10, 0)
+
+	require.Nil(t, key)
+	require.Empty(t, plaintext)
+	require.True(t, errors.Is(err, entities.ErrInvalidParam))
+}
+
+func TestService_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		key     entities.APIKey
+		err     error
+		wantErr error
+	}{
+		"active":    {key: entities.APIKey{ID: 1}},
+		"revoked":   {key: entities.APIKey{ID: 1, RevokedAt: time.Now()}, wantErr: entities.ErrUnauthorized},
+		"unknown":   {err: errors.Wrap(entities.ErrNotFound, "missing"), wantErr: entities.ErrUnauthorized},
+		"db failed": {err: errors.New("connection refused"), wantErr: entities.ErrInternal},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service, mockStorage, _ := setupService(t)
+			mockStorage.EXPECT().GetAPIKeyByHash(gomock.Any(), entities.HashAPIKey("ck_secret")).Return(tc.key, tc.err)
+
+			key, err := service.Authenticate(context.Background(), "ck_secret")
+
+			if tc.wantErr != nil {
+				require.Nil(t, key)
+				require.True(t, errors.Is(err, tc.wantErr))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.key.ID, key.ID)
+		})
+	}
+}
+
+func TestService_Authenticate_MissingKey(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := setupService(t)
+
+	_, err := service.Authenticate(context.Background(), "")
+
+	require.True(t, errors.Is(err, entities.ErrUnauthorized))
+}
+
+func TestService_ConsumeQuota(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	key := &entities.APIKey{ID: 3, DailyQuota: 100}
+	mockStorage.EXPECT().ConsumeAPIKeyQuota(gomock.Any(), int64(3), gomock.Any(), int64(100)).Return(true, nil)
+	mockStorage.EXPECT().ConsumeAPIKeyQuota(gomock.Any(), int64(3), gomock.Any(), int64(100)).Return(false, nil)
+
+	require.NoError(t, service.ConsumeQuota(context.Background(), key))
+	require.True(t, errors.Is(service.ConsumeQuota(context.Background(), key), entities.ErrRateLimited))
+}
+
+func TestService_GetAPIKeyUsage_DefaultWindow(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	mockStorage.EXPECT().GetAPIKeyUsage(gomock.Any(), to.AddDate(0, 0, -30), to).Return([]entities.APIKeyUsage{
+		{KeyID: 1, Day: to, Requests: 42},
+	}, nil)
+
+	usage, err := service.GetAPIKeyUsage(context.Background(), time.Time{}, to)
+
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+	require.Equal(t, int64(42), usage[0].Requests)
+}
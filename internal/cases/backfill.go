@@ -0,0 +1,124 @@
+package cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"Cryptoproject/internal/entities"
+)
+
+// backfillChunkBuckets is the number of buckets fetched and stored before the cursor of a job is saved.
+const backfillChunkBuckets = 500
+
+// Backfill fills the buckets in [from, to) that hold no stored rate of the coin with rates from the
+// history provider. The range is widened to whole buckets and processed in chunks, the progress of the job
+// is saved after every chunk so that an interrupted backfill of the same range resumes where it stopped.
+// Chunks already fully covered are not fetched, and rates are only inserted into empty buckets, so
+// running a backfill again stores nothing twice.
+func (s *Service) Backfill(ctx context.Context, title string, resolution entities.Resolution, from, to time.Time) (_ *entities.BackfillResult, err error) {
+	ctx, span := tracer.Start(ctx, "Service.Backfill",
+		trace.WithAttributes(attribute.String("coin.title", title), attribute.String("coin.resolution", string(resolution))))
+	defer func() { endSpan(span, err) }()
+
+	if s.history == nil {
+		s.logger.ErrorContext(ctx, "Backfill requested without a history provider")
+		return nil, errors.Wrap(entities.ErrUnavailable, "no history provider configured")
+	}
+	if title == "" {
+		s.logger.ErrorContext(ctx, "Empty title provided")
+		return nil, errors.Wrap(entities.ErrInvalidParam, "title cannot be empty")
+	}
+	bucket := resolution.Bucket()
+	if bucket == 0 {
+		s.logger.ErrorContext(ctx, "Invalid backfill resolution", "resolution", resolution)
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "invalid resolution %q", resolution)
+	}
+	if now := time.Now().UTC(); to.IsZero() || to.After(now) {
+		to = now
+	}
+	if !from.Before(to) {
+		s.logger.ErrorContext(ctx, "Invalid backfill window", "from", from, "to", to)
+		return nil, errors.Wrap(entities.ErrInvalidParam, "from must be before to")
+	}
+
+	job := entities.BackfillJob{
+		Title:      title,
+		Resolution: resolution,
+		From:       from.UTC().Truncate(bucket),
+		To:         to.UTC().Add(bucket - time.Nanosecond).Truncate(bucket),
+	}
+	job.Cursor, err = s.storage.GetBackfillCursor(ctx, job)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch backfill cursor", "title", title, "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to fetch backfill cursor")
+	}
+	if job.Cursor.Before(job.From) {
+		job.Cursor = job.From
+	} else {
+		s.logger.InfoContext(ctx, "Resuming backfill", "title", title, "resolution", resolution, "cursor", job.Cursor)
+	}
+
+	result := &entities.BackfillResult{Title: title, Resolution: resolution, From: job.From, To: job.To}
+	for job.Cursor.Before(job.To) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		chunkTo := job.Cursor.Add(backfillChunkBuckets * bucket)
+		if chunkTo.After(job.To) {
+			chunkTo = job.To
+		}
+		if err := s.backfillChunk(ctx, job, chunkTo, result); err != nil {
+			return nil, err
+		}
+
+		job.Cursor = chunkTo
+		if err := s.storage.SaveBackfillCursor(ctx, job); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to save backfill cursor", "title", title, "err", err)
+			return nil, errors.Wrap(entities.ErrInternal, "failed to save backfill cursor")
+		}
+	}
+
+	s.logger.InfoContext(ctx, "Backfill completed", "title", title, "resolution", resolution,
+		"from", job.From, "to", job.To, "fetched", result.Fetched, "inserted", result.Inserted, "skipped", result.Skipped)
+	return result, nil
+}
+
+// backfillChunk fills the empty buckets in [job.Cursor, chunkTo) and adds the outcome to result.
+func (s *Service) backfillChunk(ctx context.Context, job entities.BackfillJob, chunkTo time.Time, result *entities.BackfillResult) error {
+	bucket := job.Resolution.Bucket()
+	buckets := int(chunkTo.Sub(job.Cursor) / bucket)
+
+	covered, err := s.storage.CountRateBuckets(ctx, job.Title, job.Cursor, chunkTo, bucket)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to count covered buckets", "title", job.Title, "err", err)
+		return errors.Wrap(entities.ErrInternal, "failed to count covered buckets")
+	}
+	if covered >= buckets {
+		result.Skipped += buckets
+		return nil
+	}
+
+	coins, err := s.history.GetHistoricalRates(ctx, job.Title, job.Resolution, job.Cursor, chunkTo)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to retrieve historical rates from provider", "title", job.Title, "err", err)
+		return errors.Wrap(err, "failed to retrieve historical rates from provider")
+	}
+	result.Fetched += len(coins)
+	if len(coins) == 0 {
+		return nil
+	}
+
+	inserted, err := s.storage.StoreHistory(ctx, coins, bucket)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to store historical rates", "title", job.Title, "err", err)
+		return errors.Wrap(entities.ErrInternal, "failed to store historical rates")
+	}
+	result.Inserted += inserted
+	result.Skipped += len(coins) - inserted
+	return nil
+}
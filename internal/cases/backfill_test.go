@@ -0,0 +1,151 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	mocks "Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func setupBackfillService(t *testing.T) (*cases.Service, *mocks.MockStorage, *mocks.MockHistoryProvider) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockHistory := mocks.NewMockHistoryProvider(ctrl)
+
+	service, err := cases.NewService(mockStorage, mocks.NewMockCryptoProvider(ctrl), cases.WithHistoryProvider(mockHistory))
+	require.NoError(t, err)
+
+	return service, mockStorage, mockHistory
+}
+
+func hourlyRates(title string, from time.Time, hours int) []entities.Coin {
+	coins := make([]entities.Coin, hours)
+	for i := range coins {
+		coins[i] = entities.Coin{Title: title, Cost: 100 + float64(i), ActualAt: from.Add(time.Duration(i) * time.Hour)}
+	}
+	return coins
+}
+
+func TestService_Backfill_FillsGapsInChunks(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, mockHistory := setupBackfillService(t)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	middle := from.Add(500 * time.Hour)
+	to := from.Add(700 * time.Hour)
+	job := entities.BackfillJob{Title: "BTC", Resolution: entities.ResolutionHour, From: from, To: to}
+
+	firstChunk := job
+	firstChunk.Cursor = middle
+	lastChunk := job
+	lastChunk.Cursor = to
+
+	gomock.InOrder(
+		mockStorage.EXPECT().GetBackfillCursor(gomock.Any(), job).Return(time.Time{}, nil),
+		mockStorage.EXPECT().CountRateBuckets(gomock.Any(), "BTC", from, middle, time.Hour).Return(500, nil),
+		mockStorage.EXPECT().SaveBackfillCursor(gomock.Any(), firstChunk).Return(nil),
+		mockStorage.EXPECT().CountRateBuckets(gomock.Any(), "BTC", middle, to, time.Hour).Return(20, nil),
+		mockHistory.EXPECT().GetHistoricalRates(gomock.Any(), "BTC", entities.ResolutionHour, middle, to).
+			Return(hourlyRates("BTC", middle, 200), nil),
+		mockStorage.EXPECT().StoreHistory(gomock.Any(), hourlyRates("BTC", middle, 200), time.Hour).Return(180, nil),
+		mockStorage.EXPECT().SaveBackfillCursor(gomock.Any(), lastChunk).Return(nil),
+	)
+
+	result, err := service.Backfill(context.Background(), "BTC", entities.ResolutionHour, from.Add(30*time.Minute), to.Add(-30*time.Minute))
+
+	require.NoError(t, err)
+	require.Equal(t, &entities.BackfillResult{
+		Title: "BTC", Resolution: entities.ResolutionHour, From: from, To: to,
+		Fetched: 200, Inserted: 180, Skipped: 520,
+	}, result)
+}
+
+func TestService_Backfill_ResumesFromCursor(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, mockHistory := setupBackfillService(t)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * 24 * time.Hour)
+	cursor := from.Add(7 * 24 * time.Hour)
+	job := entities.BackfillJob{Title: "ETH", Resolution: entities.ResolutionDay, From: from, To: to}
+	done := job
+	done.Cursor = to
+
+	gomock.InOrder(
+		mockStorage.EXPECT().GetBackfillCursor(gomock.Any(), job).Return(cursor, nil),
+		mockStorage.EXPECT().CountRateBuckets(gomock.Any(), "ETH", cursor, to, 24*time.Hour).Return(0, nil),
+		mockHistory.EXPECT().GetHistoricalRates(gomock.Any(), "ETH", entities.ResolutionDay, cursor, to).Return([]entities.Coin{}, nil),
+		mockStorage.EXPECT().SaveBackfillCursor(gomock.Any(), done).Return(nil),
+	)
+
+	result, err := service.Backfill(context.Background(), "ETH", entities.ResolutionDay, from, to)
+
+	require.NoError(t, err)
+	require.Zero(t, result.Fetched)
+}
+
+func TestService_Backfill_CompletedJobFetchesNothing(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupBackfillService(t)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	mockStorage.EXPECT().GetBackfillCursor(gomock.Any(), gomock.Any()).Return(to, nil)
+
+	result, err := service.Backfill(context.Background(), "BTC", entities.ResolutionHour, from, to)
+
+	require.NoError(t, err)
+	require.Equal(t, &entities.BackfillResult{Title: "BTC", Resolution: entities.ResolutionHour, From: from, To: to}, result)
+}
+
+func TestService_Backfill_ProviderFailureKeepsCursor(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, mockHistory := setupBackfillService(t)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	mockStorage.EXPECT().GetBackfillCursor(gomock.Any(), gomock.Any()).Return(time.Time{}, nil)
+	mockStorage.EXPECT().CountRateBuckets(gomock.Any(), "BTC", from, to, time.Hour).Return(0, nil)
+	mockHistory.EXPECT().GetHistoricalRates(gomock.Any(), "BTC", entities.ResolutionHour, from, to).
+		Return(nil, entities.ErrUnavailable)
+
+	_, err := service.Backfill(context.Background(), "BTC", entities.ResolutionHour, from, to)
+
+	require.ErrorIs(t, err, entities.ErrUnavailable)
+}
+
+func TestService_Backfill_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := setupBackfillService(t)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.Backfill(context.Background(), "", entities.ResolutionHour, from, from.Add(time.Hour))
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = service.Backfill(context.Background(), "BTC", entities.Resolution("minute"), from, from.Add(time.Hour))
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	_, err = service.Backfill(context.Background(), "BTC", entities.ResolutionHour, from, from)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	withoutHistory, _, _ := setupService(t)
+	_, err = withoutHistory.Backfill(context.Background(), "BTC", entities.ResolutionHour, from, from.Add(time.Hour))
+	require.ErrorIs(t, err, entities.ErrUnavailable)
+}
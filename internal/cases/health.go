@@ -0,0 +1,88 @@
+package cases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	dependencyDatabase = "database"
+	dependencyUpdates  = "rate_updates"
+	dependencyProvider = "provider"
+
+	pingTimeout = 2 * time.Second
+)
+
+// Readiness checks the dependencies the service needs to answer requests. An unreachable database makes
+// the service unhealthy, stale rates or an open provider circuit only degrade it since stored rates can
+// still be served.
+func (s *Service) Readiness(ctx context.Context) *entities.Health {
+	dependencies := []entities.DependencyHealth{s.databaseHealth(ctx), s.updatesHealth()}
+	if s.circuit != nil {
+		dependencies = append(dependencies, s.providerHealth())
+	}
+
+	health := entities.NewHealth(dependencies...)
+	if health.Status != entities.HealthStatusHealthy {
+		s.logger.WarnContext(ctx, "Service is not fully ready", "status", health.Status)
+	}
+	return health
+}
+
+func (s *Service) databaseHealth(ctx context.Context) entities.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	start := time.Now()
+	if err := s.storage.Ping(ctx); err != nil {
+		s.logger.ErrorContext(ctx, "Database ping failed", "err", err)
+		return entities.DependencyHealth{
+			Name:   dependencyDatabase,
+			Status: entities.HealthStatusUnhealthy,
+			Detail: fmt.Sprintf("ping failed: %v", err),
+		}
+	}
+	return entities.DependencyHealth{
+		Name:   dependencyDatabase,
+		Status: entities.HealthStatusHealthy,
+		Detail: fmt.Sprintf("ping took %s", time.Since(start).Round(time.Millisecond)),
+	}
+}
+
+func (s *Service) updatesHealth() entities.DependencyHealth {
+	lastUpdate := s.lastUpdate.Load()
+	if lastUpdate == 0 {
+		return entities.DependencyHealth{
+			Name:   dependencyUpdates,
+			Status: entities.HealthStatusDegraded,
+			Detail: "no successful rate update yet",
+		}
+	}
+
+	at := time.Unix(0, lastUpdate).UTC()
+	status := entities.HealthStatusHealthy
+	if age := time.Since(at); age > s.updateStaleness {
+		status = entities.HealthStatusDegraded
+	}
+	return entities.DependencyHealth{
+		Name:   dependencyUpdates,
+		Status: status,
+		Detail: "last successful rate update at " + at.Format(time.RFC3339),
+	}
+}
+
+func (s *Service) providerHealth() entities.DependencyHealth {
+	state := s.circuit.CircuitState()
+	status := entities.HealthStatusHealthy
+	if state != entities.CircuitClosed {
+		status = entities.HealthStatusDegraded
+	}
+	return entities.DependencyHealth{
+		Name:   dependencyProvider,
+		Status: status,
+		Detail: "circuit " + string(state),
+	}
+}
@@ -0,0 +1,95 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	mocks "Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func dependencyStatuses(health *entities.Health) map[string]entities.HealthStatus {
+	statuses := make(map[string]entities.HealthStatus, len(health.Dependencies))
+	for _, dependency := range health.Dependencies {
+		statuses[dependency.Name] = dependency.Status
+	}
+	return statuses
+}
+
+func TestService_Readiness_BeforeFirstUpdate(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+	mockStorage.EXPECT().Ping(gomock.Any()).Return(nil)
+
+	health := service.Readiness(context.Background())
+
+	require.Equal(t, entities.HealthStatusDegraded, health.Status)
+	require.Equal(t, map[string]entities.HealthStatus{
+		"database":     entities.HealthStatusHealthy,
+		"rate_updates": entities.HealthStatusDegraded,
+	}, dependencyStatuses(health))
+}
+
+func TestService_Readiness_HealthyAfterUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockProvider := mocks.NewMockCryptoProvider(ctrl)
+	mockCircuit := mocks.NewMockProviderCircuit(ctrl)
+
+	service, err := cases.NewService(mockStorage, mockProvider, cases.WithProviderCircuit(mockCircuit))
+	require.NoError(t, err)
+
+	rates := []entities.Coin{{Title: "BTC", Cost: 50000}}
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC"}, nil)
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), []string{"BTC"}).Return(rates, nil)
+	mockStorage.EXPECT().Store(gomock.Any(), rates).Return(nil)
+	require.NoError(t, service.UpdateRates(context.Background()))
+
+	mockStorage.EXPECT().Ping(gomock.Any()).Return(nil)
+	mockCircuit.EXPECT().CircuitState().Return(entities.CircuitClosed)
+
+	health := service.Readiness(context.Background())
+
+	require.Equal(t, entities.HealthStatusHealthy, health.Status)
+	require.Len(t, health.Dependencies, 3)
+}
+
+func TestService_Readiness_DatabaseDown(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockCircuit := mocks.NewMockProviderCircuit(ctrl)
+
+	service, err := cases.NewService(mockStorage, mocks.NewMockCryptoProvider(ctrl), cases.WithProviderCircuit(mockCircuit))
+	require.NoError(t, err)
+
+	mockStorage.EXPECT().Ping(gomock.Any()).Return(errors.Wrap(entities.ErrUnavailable, "connection refused"))
+	mockCircuit.EXPECT().CircuitState().Return(entities.CircuitOpen)
+
+	health := service.Readiness(context.Background())
+
+	require.Equal(t, entities.HealthStatusUnhealthy, health.Status)
+	require.Equal(t, map[string]entities.HealthStatus{
+		"database":     entities.HealthStatusUnhealthy,
+		"rate_updates": entities.HealthStatusDegraded,
+		"provider":     entities.HealthStatusDegraded,
+	}, dependencyStatuses(health))
+}
+
+func TestNewService_InvalidUpdateStaleness(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	_, err := cases.NewService(mocks.NewMockStorage(ctrl), mocks.NewMockCryptoProvider(ctrl), cases.WithUpdateStaleness(0))
+
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
@@ -0,0 +1,141 @@
+package cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const maxHistoryLimit = 10000
+
+func (s *Service) GetStoredRates(ctx context.Context, requestedTitles []string) ([]*entities.Coin, error) {
+	s.logger.DebugContext(ctx, "Starting retrieval of stored rates", "requested_titles", requestedTitles)
+
+	if len(requestedTitles) == 0 {
+		s.logger.ErrorContext(ctx, "Empty titles list provided", "requested_titles", requestedTitles)
+		return nil, errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty")
+	}
+
+	storedCoins, err := s.storage.GetActualCoins(ctx, requestedTitles)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch stored coin rates", "requested_titles", requestedTitles, "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to get stored coin rates")
+	}
+
+	found := make(map[string]struct{}, len(storedCoins))
+	for _, coin := range storedCoins {
+		found[coin.Title] = struct{}{}
+	}
+	for _, title := range requestedTitles {
+		if _, exists := found[title]; !exists {
+			s.logger.ErrorContext(ctx, "No stored rate found", "missing_title", title)
+			return nil, errors.Wrapf(entities.ErrNotFound, "no stored rate for coin %q", title)
+		}
+	}
+
+	result := make([]*entities.Coin, len(storedCoins))
+	for i := range storedCoins {
+		result[i] = &storedCoins[i]
+	}
+
+	s.logger.DebugContext(ctx, "Retrieved stored coin rates successfully", "number_of_coins", len(result))
+	return result, nil
+}
+
+// resolveWindow defaults an open window end to now, a zero start covers the whole history.
+func (s *Service) resolveWindow(ctx context.Context, from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.After(to) {
+		s.logger.ErrorContext(ctx, "Invalid time window", "from", from, "to", to)
+		return time.Time{}, time.Time{}, errors.Wrap(entities.ErrInvalidParam, "from must not be after to")
+	}
+	return from, to, nil
+}
+
+func (s *Service) GetStoredAggregateStats(ctx context.Context, requestedTitles []string, aggTypes []string, from, to time.Time) ([]*entities.CoinStats, error) {
+	s.logger.DebugContext(ctx, "Starting aggregation of stored coin rates", "requested_titles", requestedTitles, "agg_types", aggTypes, "from", from, "to", to)
+
+	if len(requestedTitles) == 0 {
+		s.logger.ErrorContext(ctx, "Empty titles list provided", "requested_titles", requestedTitles)
+		return nil, errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty")
+	}
+
+	uniqueAggTypes, err := dedupeAggTypes(aggTypes)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Invalid aggregation types", "agg_types", aggTypes, "err", err)
+		return nil, err
+	}
+
+	from, to, err = s.resolveWindow(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	storedStats, err := s.storage.GetAggregateStats(ctx, requestedTitles, uniqueAggTypes, from, to)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch aggregated stored coin stats", "requested_titles", requestedTitles, "agg_types", uniqueAggTypes, "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to get aggregate coin stats")
+	}
+
+	found := make(map[string]struct{}, len(storedStats))
+	for _, stats := range storedStats {
+		found[stats.Title] = struct{}{}
+	}
+	for _, title := range requestedTitles {
+		if _, exists := found[title]; !exists {
+			s.logger.ErrorContext(ctx, "No stored rates found", "missing_title", title)
+			return nil, errors.Wrapf(entities.ErrNotFound, "no stored rates for coin %q", title)
+		}
+	}
+
+	result := make([]*entities.CoinStats, len(storedStats))
+	for i := range storedStats {
+		result[i] = &storedStats[i]
+	}
+
+	s.logger.DebugContext(ctx, "Aggregated stored coin stats retrieved successfully", "number_of_coins", len(result), "agg_types", uniqueAggTypes)
+	return result, nil
+}
+
+func (s *Service) GetCoinHistory(ctx context.Context, title string, from, to time.Time, limit int) ([]*entities.Coin, error) {
+	s.logger.DebugContext(ctx, "Starting retrieval of coin history", "title", title, "from", from, "to", to, "limit", limit)
+
+	if title == "" {
+		s.logger.ErrorContext(ctx, "Empty title provided")
+		return nil, errors.Wrap(entities.ErrInvalidParam, "title cannot be empty")
+	}
+
+	from, to, err := s.resolveWindow(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > maxHistoryLimit {
+		s.logger.ErrorContext(ctx, "Invalid history limit", "limit", limit)
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "limit must be between 1 and %d", maxHistoryLimit)
+	}
+
+	history, err := s.storage.GetCoinHistory(ctx, title, from, to, limit)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch coin history", "title", title, "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to get coin history")
+	}
+
+	if len(history) == 0 {
+		s.logger.ErrorContext(ctx, "No stored rates found in window", "title", title, "from", from, "to", to)
+		return nil, errors.Wrapf(entities.ErrNotFound, "no stored rates for coin %q in the requested window", title)
+	}
+
+	result := make([]*entities.Coin, len(history))
+	for i := range history {
+		result[i] = &history[i]
+	}
+
+	s.logger.DebugContext(ctx, "Retrieved coin history successfully", "title", title, "number_of_coins", len(result))
+	return result, nil
+}
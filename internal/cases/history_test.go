@@ -0,0 +1,152 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func TestService_GetStoredRates_Success(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	actualAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockStorage.EXPECT().GetActualCoins(gomock.Any(), []string{"BTC", "ETH"}).Return([]entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: actualAt},
+		{Title: "ETH", Cost: 3000, ActualAt: actualAt},
+	}, nil)
+
+	rates, err := service.GetStoredRates(context.Background(), []string{"BTC", "ETH"})
+
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	require.Equal(t, actualAt, rates[0].ActualAt)
+}
+
+func TestService_GetStoredRates_TitleNotFound(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	mockStorage.EXPECT().GetActualCoins(gomock.Any(), []string{"BTC", "DOGE"}).Return([]entities.Coin{
+		{Title: "BTC", Cost: 50000},
+	}, nil)
+
+	rates, err := service.GetStoredRates(context.Background(), []string{"BTC", "DOGE"})
+
+	require.Nil(t, rates)
+	require.True(t, errors.Is(err, entities.ErrNotFound))
+}
+
+func TestService_GetStoredAggregateStats_Success(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	updatedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockStorage.EXPECT().GetAggregateStats(gomock.Any(), []string{"BTC"}, []string{"MIN", "MAX"}, gomock.Any(), gomock.Any()).Return([]entities.CoinStats{
+		{Title: "BTC", Values: map[string]float64{"MIN": 40000, "MAX": 60000}, UpdatedAt: updatedAt},
+	}, nil)
+
+	stats, err := service.GetStoredAggregateStats(context.Background(), []string{"BTC"}, []string{"MIN", "MAX", "MIN"}, time.Time{}, time.Time{})
+
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, updatedAt, stats[0].UpdatedAt)
+}
+
+func TestService_GetStoredAggregateStats_StorageError(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	mockStorage.EXPECT().GetAggregateStats(gomock.Any(), []string{"BTC"}, []string{"AVG"}, gomock.Any(), gomock.Any()).Return(nil, errors.New("storage error"))
+
+	stats, err := service.GetStoredAggregateStats(context.Background(), []string{"BTC"}, []string{"AVG"}, time.Time{}, time.Time{})
+
+	require.Nil(t, stats)
+	require.True(t, errors.Is(err, entities.ErrInternal))
+}
+
+func TestService_GetStoredAggregateStats_Window(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	mockStorage.EXPECT().GetAggregateStats(gomock.Any(), []string{"BTC"}, []string{"CHANGE"}, from, to).Return([]entities.CoinStats{
+		{Title: "BTC", Values: map[string]float64{"CHANGE": 1500}, UpdatedAt: to},
+	}, nil)
+
+	stats, err := service.GetStoredAggregateStats(context.Background(), []string{"BTC"}, []string{"CHANGE"}, from, to)
+	require.NoError(t, err)
+	require.Equal(t, 1500.0, stats[0].Values["CHANGE"])
+
+	stats, err = service.GetStoredAggregateStats(context.Background(), []string{"BTC"}, []string{"CHANGE"}, to, from)
+	require.Nil(t, stats)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.ErrorContains(t, err, "from must not be after to")
+}
+
+func TestService_GetCoinHistory_Success(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	mockStorage.EXPECT().GetCoinHistory(gomock.Any(), "BTC", from, to, 10).Return([]entities.Coin{
+		{Title: "BTC", Cost: 51000, ActualAt: to.Add(-time.Hour)},
+		{Title: "BTC", Cost: 50000, ActualAt: from.Add(time.Hour)},
+	}, nil)
+
+	history, err := service.GetCoinHistory(context.Background(), "BTC", from, to, 10)
+
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, float64(51000), history[0].Cost)
+}
+
+func TestService_GetCoinHistory_Empty(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	mockStorage.EXPECT().GetCoinHistory(gomock.Any(), "BTC", time.Time{}, gomock.Any(), 10).Return(nil, nil)
+
+	history, err := service.GetCoinHistory(context.Background(), "BTC", time.Time{}, time.Time{}, 10)
+
+	require.Nil(t, history)
+	require.True(t, errors.Is(err, entities.ErrNotFound))
+}
+
+func TestService_GetCoinHistory_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := setupService(t)
+
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		title    string
+		from, to time.Time
+		limit    int
+	}{
+		"empty title":    {"", time.Time{}, time.Time{}, 10},
+		"reversed range": {"BTC", from, to, 10},
+		"zero limit":     {"BTC", time.Time{}, time.Time{}, 0},
+		"limit too big":  {"BTC", time.Time{}, time.Time{}, 10001},
+	} {
+		_, err := service.GetCoinHistory(context.Background(), tc.title, tc.from, tc.to, tc.limit)
+		require.True(t, errors.Is(err, entities.ErrInvalidParam), name)
+	}
+}
@@ -0,0 +1,171 @@
+package cases
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// maxPortfolioNameLength matches the VARCHAR(100) name column of the portfolios table.
+const maxPortfolioNameLength = 100
+
+func (s *Service) ValuePortfolio(ctx context.Context, positions []entities.Position, at time.Time) (*entities.PortfolioValuation, error) {
+	s.logger.DebugContext(ctx, "Starting portfolio valuation", "number_of_positions", len(positions), "at", at)
+
+	if err := entities.ValidatePositions(positions); err != nil {
+		s.logger.ErrorContext(ctx, "Invalid portfolio positions", "err", err)
+		return nil, errors.Wrap(err, "invalid portfolio positions")
+	}
+
+	titles := make([]string, len(positions))
+	for i, position := range positions {
+		titles[i] = position.Title
+	}
+
+	var (
+		coins []*entities.Coin
+		err   error
+	)
+	if at.IsZero() {
+		at = time.Now().UTC()
+		coins, err = s.GetLastRates(ctx, titles)
+	} else {
+		coins, err = s.GetRatesAt(ctx, titles, at, 0)
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get rates for portfolio valuation", "titles", titles, "at", at, "err", err)
+		return nil, errors.Wrap(err, "failed to get rates for portfolio valuation")
+	}
+
+	rates := make(map[string]entities.Coin, len(coins))
+	for _, coin := range coins {
+		rates[coin.Title] = *coin
+	}
+
+	valuation := &entities.PortfolioValuation{
+		Positions: make([]entities.PositionValuation, 0, len(positions)),
+		At:        at,
+	}
+	for _, position := range positions {
+		coin, exists := rates[position.Title]
+		if !exists {
+			s.logger.ErrorContext(ctx, "Rate missing for portfolio position", "title", position.Title)
+			return nil, errors.Wrapf(entities.ErrNotFound, "rate for %q was not found", position.Title)
+		}
+
+		positionValuation := position.Valuate(coin)
+		valuation.Positions = append(valuation.Positions, positionValuation)
+		valuation.TotalValue += positionValuation.Value
+		valuation.TotalCost += positionValuation.Cost
+		valuation.TotalPnL += positionValuation.PnL
+	}
+
+	s.logger.DebugContext(ctx, "Portfolio valuation completed successfully", "total_value", valuation.TotalValue)
+	return valuation, nil
+}
+
+func (s *Service) CreatePortfolio(ctx context.Context, name string, positions []entities.Position) (*entities.Portfolio, error) {
+	if err := s.validatePortfolioName(ctx, name); err != nil {
+		return nil, err
+	}
+
+	portfolio, err := entities.NewPortfolio(name, positions)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Invalid portfolio", "name", name, "err", err)
+		return nil, errors.Wrap(err, "invalid portfolio")
+	}
+
+	created, err := s.storage.CreatePortfolio(ctx, *portfolio)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create portfolio", "name", name, "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to create portfolio")
+	}
+
+	return &created, nil
+}
+
+func (s *Service) GetPortfolio(ctx context.Context, id int64) (*entities.Portfolio, error) {
+	portfolio, err := s.storage.GetPortfolio(ctx, id)
+	if err != nil {
+		return nil, s.portfolioStorageError(ctx, err, id, "failed to get portfolio")
+	}
+
+	return &portfolio, nil
+}
+
+func (s *Service) ListPortfolios(ctx context.Context) ([]*entities.Portfolio, error) {
+	portfolios, err := s.storage.ListPortfolios(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list portfolios", "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to list portfolios")
+	}
+
+	result := make([]*entities.Portfolio, len(portfolios))
+	for i := range portfolios {
+		result[i] = &portfolios[i]
+	}
+	return result, nil
+}
+
+func (s *Service) UpdatePortfolio(ctx context.Context, id int64, name string, positions []entities.Position) (*entities.Portfolio, error) {
+	if err := s.validatePortfolioName(ctx, name); err != nil {
+		return nil, err
+	}
+
+	portfolio, err := entities.NewPortfolio(name, positions)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Invalid portfolio", "portfolio_id", id, "err", err)
+		return nil, errors.Wrap(err, "invalid portfolio")
+	}
+	portfolio.ID = id
+
+	updated, err := s.storage.UpdatePortfolio(ctx, *portfolio)
+	if err != nil {
+		return nil, s.portfolioStorageError(ctx, err, id, "failed to update portfolio")
+	}
+
+	return &updated, nil
+}
+
+func (s *Service) DeletePortfolio(ctx context.Context, id int64) error {
+	if err := s.storage.DeletePortfolio(ctx, id); err != nil {
+		return s.portfolioStorageError(ctx, err, id, "failed to delete portfolio")
+	}
+
+	return nil
+}
+
+func (s *Service) ValueStoredPortfolio(ctx context.Context, id int64, at time.Time) (*entities.Portfolio, *entities.PortfolioValuation, error) {
+	portfolio, err := s.GetPortfolio(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	valuation, err := s.ValuePortfolio(ctx, portfolio.Positions, at)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return portfolio, valuation, nil
+}
+
+func (s *Service) validatePortfolioName(ctx context.Context, name string) error {
+	if length := utf8.RuneCountInString(name); length > maxPortfolioNameLength {
+		s.logger.ErrorContext(ctx, "Portfolio name too long", "length", length)
+		return errors.Wrapf(entities.ErrInvalidParam, "invalid portfolio: name cannot be longer than %d characters", maxPortfolioNameLength)
+	}
+	return nil
+}
+
+func (s *Service) portfolioStorageError(ctx context.Context, err error, id int64, message string) error {
+	if errors.Is(err, entities.ErrNotFound) {
+		return errors.Wrap(err, message)
+	}
+
+	s.logger.ErrorContext(ctx, "Portfolio storage failure", "portfolio_id", id, "err", err)
+	return errors.Wrap(entities.ErrInternal, message)
+}
@@ -0,0 +1,155 @@
+package cases_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func TestService_ValuePortfolio_Historical(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	positions := []entities.Position{
+		{Title: "BTC", Quantity: 0.5, CostBasis: 40000},
+		{Title: "ETH", Quantity: 2},
+	}
+
+	mockStorage.EXPECT().GetCoinsAt(gomock.Any(), []string{"BTC", "ETH"}, at).Return([]entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: at.Add(-time.Minute)},
+		{Title: "ETH", Cost: 3000, ActualAt: at.Add(-time.Minute)},
+	}, nil)
+
+	valuation, err := service.ValuePortfolio(context.Background(), positions, at)
+
+	require.NoError(t, err)
+	require.Len(t, valuation.Positions, 2)
+	require.Equal(t, float64(25000), valuation.Positions[0].Value)
+	require.Equal(t, float64(5000), valuation.Positions[0].PnL)
+	require.Equal(t, float64(6000), valuation.Positions[1].Value)
+	require.Equal(t, float64(31000), valuation.TotalValue)
+	require.Equal(t, float64(20000), valuation.TotalCost)
+	require.Equal(t, float64(5000), valuation.TotalPnL)
+	require.Equal(t, at, valuation.At)
+}
+
+func TestService_ValuePortfolio_Latest(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, mockProvider := setupService(t)
+
+	positions := []entities.Position{{Title: "BTC", Quantity: 2}}
+
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), []string{"BTC"}).Return([]entities.Coin{{Title: "BTC", Cost: 50000}}, nil)
+
+	mockStorage.EXPECT().Store(gomock.Any(), []entities.Coin{{Title: "BTC", Cost: 50000}}).Return(nil)
+
+	mockStorage.EXPECT().GetActualCoins(gomock.Any(), []string{"BTC"}).Return([]entities.Coin{{Title: "BTC", Cost: 50000}}, nil)
+
+	valuation, err := service.ValuePortfolio(context.Background(), positions, time.Time{})
+
+	require.NoError(t, err)
+	require.Equal(t, float64(100000), valuation.TotalValue)
+	require.False(t, valuation.At.IsZero())
+}
+
+func TestService_ValuePortfolio_InvalidPositions(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := setupService(t)
+
+	valuation, err := service.ValuePortfolio(context.Background(), []entities.Position{{Title: "BTC", Quantity: -1}}, time.Time{})
+
+	require.Nil(t, valuation)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestService_CreatePortfolio_Success(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	positions := []entities.Position{{Title: "BTC", Quantity: 1}}
+
+	mockStorage.EXPECT().CreatePortfolio(gomock.Any(), entities.Portfolio{Name: "main", Positions: positions}).Return(entities.Portfolio{
+		ID:        7,
+		Name:      "main",
+		Positions: positions,
+	}, nil)
+
+	portfolio, err := service.CreatePortfolio(context.Background(), "main", positions)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(7), portfolio.ID)
+}
+
+func TestService_CreatePortfolio_NameTooLong(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := setupService(t)
+
+	positions := []entities.Position{{Title: "BTC", Quantity: 1}}
+
+	portfolio, err := service.CreatePortfolio(context.Background(), strings.Repeat("ж", 101), positions)
+
+	require.Nil(t, portfolio)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.ErrorContains(t, err, "name cannot be longer than 100 characters")
+
+	_, err = service.UpdatePortfolio(context.Background(), 7, strings.Repeat("a", 101), positions)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestService_CreatePortfolio_NameAtLimit(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	name := strings.Repeat("ж", 100)
+	positions := []entities.Position{{Title: "BTC", Quantity: 1}}
+
+	mockStorage.EXPECT().CreatePortfolio(gomock.Any(), entities.Portfolio{Name: name, Positions: positions}).Return(entities.Portfolio{
+		ID:        7,
+		Name:      name,
+		Positions: positions,
+	}, nil)
+
+	_, err := service.CreatePortfolio(context.Background(), name, positions)
+
+	require.NoError(t, err, "the limit counts characters, not bytes")
+}
+
+func TestService_GetPortfolio_NotFound(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	mockStorage.EXPECT().GetPortfolio(gomock.Any(), int64(7)).Return(entities.Portfolio{}, errors.Wrap(entities.ErrNotFound, "portfolio 7 not found"))
+
+	portfolio, err := service.GetPortfolio(context.Background(), 7)
+
+	require.Nil(t, portfolio)
+	require.ErrorIs(t, err, entities.ErrNotFound)
+}
+
+func TestService_DeletePortfolio_StorageError(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	mockStorage.EXPECT().DeletePortfolio(gomock.Any(), int64(7)).Return(errors.New("connection refused"))
+
+	err := service.DeletePortfolio(context.Background(), 7)
+
+	require.ErrorIs(t, err, entities.ErrInternal)
+	require.ErrorContains(t, err, "failed to delete portfolio")
+}
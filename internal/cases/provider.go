@@ -3,9 +3,20 @@ package cases
 import (
 	"Cryptoproject/internal/entities"
 	"context"
+	"time"
 )
 
 //go:generate mockgen -source=provider.go -destination=./testdata/provider.go -package=testdata
 type CryptoProvider interface {
 	GetActualRates(ctx context.Context, titles []string) ([]entities.Coin, error)
 }
+
+// ProviderCircuit reports the circuit breaker state of a provider for readiness checks.
+type ProviderCircuit interface {
+	CircuitState() entities.CircuitState
+}
+
+// HistoryProvider is the optional capability of a provider to return past rates, one per resolution bucket.
+type HistoryProvider interface {
+	GetHistoricalRates(ctx context.Context, title string, resolution entities.Resolution, from, to time.Time) ([]entities.Coin, error)
+}
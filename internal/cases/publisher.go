@@ -0,0 +1,11 @@
+package cases
+
+import (
+	"Cryptoproject/internal/entities"
+	"context"
+)
+
+//go:generate mockgen -source=publisher.go -destination=./testdata/publisher.go -package=testdata
+type Publisher interface {
+	Publish(ctx context.Context, coins []entities.Coin)
+}
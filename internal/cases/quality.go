@@ -0,0 +1,78 @@
+package cases
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// gapTolerance is how many update intervals may pass between two stored rates of a coin before the stretch
+// counts as a gap. It ignores the jitter of the scheduler but catches a single missed update.
+const gapTolerance = 1.5
+
+// SetUpdateInterval replaces the expected time between scheduled rate updates, it is safe to call while the
+// service runs.
+func (s *Service) SetUpdateInterval(interval time.Duration) {
+	s.updateInterval.Store(int64(interval))
+}
+
+// GetQualityReport scans the rates stored between from and to for gaps longer than the update interval
+// allows, jumps beyond the configured number of standard deviations and duplicate timestamps. A zero to
+// means now and a zero from means the quality window before to. Coins that stopped updating altogether are
+// left to the readiness check.
+func (s *Service) GetQualityReport(ctx context.Context, from, to time.Time) (*entities.QualityReport, error) {
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = to.Add(-s.qualityWindow)
+	}
+	if !from.Before(to) {
+		s.logger.ErrorContext(ctx, "Invalid quality report window", "from", from, "to", to)
+		return nil, errors.Wrap(entities.ErrInvalidParam, "from must be before to")
+	}
+
+	interval := time.Duration(s.updateInterval.Load())
+	report := &entities.QualityReport{
+		From:             from,
+		To:               to,
+		ExpectedInterval: interval,
+		JumpSigma:        s.jumpSigma,
+		Issues:           make([]entities.QualityIssue, 0),
+	}
+
+	gaps, err := s.storage.FindRateGaps(ctx, from, to, time.Duration(float64(interval)*gapTolerance))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to search for rate gaps", "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to search for rate gaps")
+	}
+	jumps, err := s.storage.FindRateJumps(ctx, from, to, s.jumpSigma)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to search for rate jumps", "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to search for rate jumps")
+	}
+	duplicates, err := s.storage.FindDuplicateRates(ctx, from, to)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to search for duplicate rates", "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to search for duplicate rates")
+	}
+
+	report.Issues = append(report.Issues, gaps...)
+	report.Issues = append(report.Issues, jumps...)
+	report.Issues = append(report.Issues, duplicates...)
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		a, b := report.Issues[i], report.Issues[j]
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return a.From.Before(b.From)
+	})
+
+	s.logger.DebugContext(ctx, "Quality report built successfully", "from", from, "to", to,
+		"gaps", len(gaps), "jumps", len(jumps), "duplicates", len(duplicates))
+	return report, nil
+}
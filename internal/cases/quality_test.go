@@ -0,0 +1,92 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	mocks "Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func TestService_GetQualityReport(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	service, err := cases.NewService(mockStorage, mocks.NewMockCryptoProvider(ctrl),
+		cases.WithUpdateInterval(10*time.Minute),
+		cases.WithJumpSigma(3),
+	)
+	require.NoError(t, err)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	gap := entities.QualityIssue{Title: "ETH", Kind: entities.QualityGap, From: from.Add(time.Hour), To: from.Add(2 * time.Hour)}
+	jump := entities.QualityIssue{Title: "BTC", Kind: entities.QualityJump, From: from.Add(3 * time.Hour), To: from.Add(3*time.Hour + 10*time.Minute),
+		FromCost: 60000, ToCost: 6000000, Sigmas: 12}
+	duplicate := entities.QualityIssue{Title: "BTC", Kind: entities.QualityDuplicate, From: from.Add(time.Hour), To: from.Add(time.Hour), Count: 2}
+
+	mockStorage.EXPECT().FindRateGaps(gomock.Any(), from, to, 15*time.Minute).Return([]entities.QualityIssue{gap}, nil)
+	mockStorage.EXPECT().FindRateJumps(gomock.Any(), from, to, 3.0).Return([]entities.QualityIssue{jump}, nil)
+	mockStorage.EXPECT().FindDuplicateRates(gomock.Any(), from, to).Return([]entities.QualityIssue{duplicate}, nil)
+
+	report, err := service.GetQualityReport(context.Background(), from, to)
+
+	require.NoError(t, err)
+	require.Equal(t, &entities.QualityReport{
+		From: from, To: to, ExpectedInterval: 10 * time.Minute, JumpSigma: 3,
+		Issues: []entities.QualityIssue{duplicate, jump, gap},
+	}, report)
+}
+
+func TestService_GetQualityReport_FollowsUpdateInterval(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+	service.SetUpdateInterval(time.Hour)
+
+	mockStorage.EXPECT().FindRateGaps(gomock.Any(), gomock.Any(), gomock.Any(), 90*time.Minute).Return(nil, nil)
+	mockStorage.EXPECT().FindRateJumps(gomock.Any(), gomock.Any(), gomock.Any(), 4.0).Return(nil, nil)
+	mockStorage.EXPECT().FindDuplicateRates(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	report, err := service.GetQualityReport(context.Background(), time.Time{}, time.Time{})
+
+	require.NoError(t, err)
+	require.Equal(t, 24*time.Hour, report.To.Sub(report.From))
+	require.Empty(t, report.Issues)
+}
+
+func TestService_GetQualityReport_Errors(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.GetQualityReport(context.Background(), from, from.Add(-time.Hour))
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+
+	mockStorage.EXPECT().FindRateGaps(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockStorage.EXPECT().FindRateJumps(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, entities.ErrInternal)
+
+	_, err = service.GetQualityReport(context.Background(), from, from.Add(time.Hour))
+	require.ErrorIs(t, err, entities.ErrInternal)
+}
+
+func TestNewService_InvalidQualitySettings(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	for _, opt := range []cases.ServiceOption{
+		cases.WithUpdateInterval(0),
+		cases.WithQualityWindow(0),
+		cases.WithJumpSigma(-1),
+	} {
+		_, err := cases.NewService(mocks.NewMockStorage(ctrl), mocks.NewMockCryptoProvider(ctrl), opt)
+		require.ErrorIs(t, err, entities.ErrInvalidParam)
+	}
+}
@@ -0,0 +1,110 @@
+package cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// screenRates quarantines the rates that deviate from the recent rates of their coin by more than the
+// outlier guard allows and returns the others. Coins without recent rates have nothing to be compared
+// against and pass, so the guard lets a coin through again once its last accepted rates leave the window.
+func (s *Service) screenRates(ctx context.Context, coins []entities.Coin) ([]entities.Coin, error) {
+	if s.maxDeviation == 0 || len(coins) == 0 {
+		return coins, nil
+	}
+
+	titles := make([]string, len(coins))
+	for i, coin := range coins {
+		titles[i] = coin.Title
+	}
+	now := time.Now().UTC()
+	references, err := s.storage.GetReferenceRates(ctx, titles, now.Add(-s.outlierWindow))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch reference rates", "titles", titles, "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to fetch reference rates")
+	}
+
+	accepted := make([]entities.Coin, 0, len(coins))
+	var rejected []entities.QuarantinedRate
+	for _, coin := range coins {
+		reference, exists := references[coin.Title]
+		if !exists || reference <= 0 || entities.RelativeDeviation(coin.Cost, reference) <= s.maxDeviation {
+			accepted = append(accepted, coin)
+			continue
+		}
+
+		actualAt := coin.ActualAt
+		if actualAt.IsZero() {
+			actualAt = now
+		}
+		rejected = append(rejected, entities.QuarantinedRate{
+			Title:         coin.Title,
+			Cost:          coin.Cost,
+			ActualAt:      actualAt,
+			Source:        coin.Source,
+			ReferenceCost: reference,
+		})
+		s.logger.WarnContext(ctx, "Rate quarantined as an outlier", "title", coin.Title, "cost", coin.Cost, "reference_cost", reference)
+	}
+
+	if len(rejected) > 0 {
+		if err := s.storage.QuarantineRates(ctx, rejected); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to quarantine rates", "err", err)
+			return nil, errors.Wrap(entities.ErrInternal, "failed to quarantine rates")
+		}
+	}
+	return accepted, nil
+}
+
+func (s *Service) ListQuarantinedRates(ctx context.Context, status entities.QuarantineStatus) ([]*entities.QuarantinedRate, error) {
+	if _, err := entities.ParseQuarantineStatus(string(status)); err != nil {
+		s.logger.ErrorContext(ctx, "Invalid quarantine status", "status", status)
+		return nil, err
+	}
+
+	rates, err := s.storage.ListQuarantinedRates(ctx, status)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list quarantined rates", "status", status, "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to list quarantined rates")
+	}
+
+	result := make([]*entities.QuarantinedRate, len(rates))
+	for i := range rates {
+		result[i] = &rates[i]
+	}
+	return result, nil
+}
+
+// ApproveQuarantinedRate stores a pending quarantined rate with its original timestamp. A newer rate stored in
+// its bucket meanwhile is kept and reported as entities.ErrConflict, the quarantined rate stays pending.
+func (s *Service) ApproveQuarantinedRate(ctx context.Context, id int64) error {
+	if err := s.storage.ApproveQuarantinedRate(ctx, id); err != nil {
+		return s.quarantineStorageError(ctx, err, id, "failed to approve quarantined rate")
+	}
+
+	s.logger.InfoContext(ctx, "Quarantined rate approved", "quarantined_rate_id", id)
+	return nil
+}
+
+// DiscardQuarantinedRate drops a pending quarantined rate for good.
+func (s *Service) DiscardQuarantinedRate(ctx context.Context, id int64) error {
+	if err := s.storage.DiscardQuarantinedRate(ctx, id); err != nil {
+		return s.quarantineStorageError(ctx, err, id, "failed to discard quarantined rate")
+	}
+
+	s.logger.InfoContext(ctx, "Quarantined rate discarded", "quarantined_rate_id", id)
+	return nil
+}
+
+func (s *Service) quarantineStorageError(ctx context.Context, err error, id int64, message string) error {
+	if errors.Is(err, entities.ErrNotFound) || errors.Is(err, entities.ErrConflict) {
+		return errors.Wrap(err, message)
+	}
+
+	s.logger.ErrorContext(ctx, "Quarantine storage failure", "quarantined_rate_id", id, "err", err)
+	return errors.Wrap(entities.ErrInternal, message)
+}
@@ -0,0 +1,131 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cases"
+	mocks "Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+)
+
+func setupGuardedService(t *testing.T) (*cases.Service, *mocks.MockStorage, *mocks.MockCryptoProvider) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockProvider := mocks.NewMockCryptoProvider(ctrl)
+
+	service, err := cases.NewService(mockStorage, mockProvider, cases.WithOutlierGuard(0.5, time.Hour))
+	require.NoError(t, err)
+
+	return service, mockStorage, mockProvider
+}
+
+func TestService_UpdateRates_QuarantinesOutliers(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, mockProvider := setupGuardedService(t)
+
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC", "ETH", "SOL"}, nil)
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), []string{"BTC", "ETH", "SOL"}).Return([]entities.Coin{
+		{Title: "BTC", Cost: 6000000, Source: "cryptocompare"},
+		{Title: "ETH", Cost: 3100},
+		{Title: "SOL", Cost: 150},
+	}, nil)
+
+	before := time.Now().UTC()
+	mockStorage.EXPECT().GetReferenceRates(gomock.Any(), []string{"BTC", "ETH", "SOL"}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ []string, since time.Time) (map[string]float64, error) {
+			require.WithinDuration(t, before.Add(-time.Hour), since, time.Second)
+			return map[string]float64{"BTC": 60000, "ETH": 3000}, nil
+		})
+	mockStorage.EXPECT().QuarantineRates(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, rates []entities.QuarantinedRate) error {
+			require.Len(t, rates, 1)
+			require.Equal(t, "BTC", rates[0].Title)
+			require.Equal(t, 6000000.0, rates[0].Cost)
+			require.Equal(t, 60000.0, rates[0].ReferenceCost)
+			require.Equal(t, "cryptocompare", rates[0].Source)
+			require.False(t, rates[0].ActualAt.IsZero())
+			return nil
+		})
+	mockStorage.EXPECT().Store(gomock.Any(), []entities.Coin{
+		{Title: "ETH", Cost: 3100},
+		{Title: "SOL", Cost: 150},
+	}).Return(nil)
+
+	require.NoError(t, service.UpdateRates(context.Background()))
+}
+
+func TestService_UpdateRates_QuarantineFailure(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, mockProvider := setupGuardedService(t)
+
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC"}, nil)
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), []string{"BTC"}).Return([]entities.Coin{{Title: "BTC", Cost: 600}}, nil)
+	mockStorage.EXPECT().GetReferenceRates(gomock.Any(), []string{"BTC"}, gomock.Any()).Return(map[string]float64{"BTC": 60000}, nil)
+	mockStorage.EXPECT().QuarantineRates(gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+
+	err := service.UpdateRates(context.Background())
+	require.ErrorIs(t, err, entities.ErrInternal)
+}
+
+func TestService_ListQuarantinedRates(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	quarantinedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockStorage.EXPECT().ListQuarantinedRates(gomock.Any(), entities.QuarantinePending).Return([]entities.QuarantinedRate{
+		{ID: 7, Title: "BTC", Cost: 600, ReferenceCost: 60000, Status: entities.QuarantinePending, QuarantinedAt: quarantinedAt},
+	}, nil)
+
+	rates, err := service.ListQuarantinedRates(context.Background(), entities.QuarantinePending)
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	require.Equal(t, int64(7), rates[0].ID)
+
+	_, err = service.ListQuarantinedRates(context.Background(), entities.QuarantineStatus("rejected"))
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestService_ReviewQuarantinedRate(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	mockStorage.EXPECT().ApproveQuarantinedRate(gomock.Any(), int64(7)).Return(nil)
+	require.NoError(t, service.ApproveQuarantinedRate(context.Background(), 7))
+
+	mockStorage.EXPECT().DiscardQuarantinedRate(gomock.Any(), int64(8)).Return(errors.Wrap(entities.ErrNotFound, "pending quarantined rate 8 not found"))
+	require.ErrorIs(t, service.DiscardQuarantinedRate(context.Background(), 8), entities.ErrNotFound)
+
+	mockStorage.EXPECT().ApproveQuarantinedRate(gomock.Any(), int64(9)).Return(errors.New("connection reset"))
+	require.ErrorIs(t, service.ApproveQuarantinedRate(context.Background(), 9), entities.ErrInternal)
+
+	mockStorage.EXPECT().ApproveQuarantinedRate(gomock.Any(), int64(10)).
+		Return(errors.Wrap(entities.ErrConflict, "a rate of BTC newer than quarantined rate 10 is stored in its bucket"))
+	err := service.ApproveQuarantinedRate(context.Background(), 10)
+	require.ErrorIs(t, err, entities.ErrConflict, "an occupied bucket is reported to the reviewer")
+	require.ErrorContains(t, err, "newer than quarantined rate 10")
+}
+
+func TestNewService_InvalidOutlierGuard(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	for _, opt := range []cases.ServiceOption{
+		cases.WithOutlierGuard(-1, time.Hour),
+		cases.WithOutlierGuard(0.5, 0),
+	} {
+		_, err := cases.NewService(mocks.NewMockStorage(ctrl), mocks.NewMockCryptoProvider(ctrl), opt)
+		require.ErrorIs(t, err, entities.ErrInvalidParam)
+	}
+}
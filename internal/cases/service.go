@@ -4,18 +4,126 @@ import (
 	"context"
 	"log/slog"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"Cryptoproject/internal/entities"
 )
 
+const (
+	defaultMaxStaleness    = time.Hour
+	defaultUpdateStaleness = 15 * time.Minute
+	defaultUpdateInterval  = 5 * time.Minute
+	defaultQualityWindow   = 24 * time.Hour
+	defaultJumpSigma       = 4.0
+)
+
 type Service struct {
-	storage  Storage
-	provider CryptoProvider
+	storage         Storage
+	provider        CryptoProvider
+	history         HistoryProvider
+	publisher       Publisher
+	circuit         ProviderCircuit
+	maxStaleness    time.Duration
+	updateStaleness time.Duration
+	qualityWindow   time.Duration
+	jumpSigma       float64
+	maxDeviation    float64
+	outlierWindow   time.Duration
+	logger          *slog.Logger
+	// trackedCoins are updated in addition to the coins already stored.
+	trackedCoins atomic.Pointer[[]string]
+	// updateInterval holds the expected time in nanoseconds between two scheduled rate updates.
+	updateInterval atomic.Int64
+	// lastUpdate holds the Unix time in nanoseconds of the last successful UpdateRates run, zero if none.
+	lastUpdate atomic.Int64
+}
+
+type ServiceOption func(*Service)
+
+func WithMaxStaleness(maxStaleness time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.maxStaleness = maxStaleness
+	}
 }
 
-func NewService(storage Storage, provider CryptoProvider) (*Service, error) {
+func WithPublisher(publisher Publisher) ServiceOption {
+	return func(s *Service) {
+		s.publisher = publisher
+	}
+}
+
+// WithHistoryProvider enables Backfill with the given source of past rates.
+func WithHistoryProvider(history HistoryProvider) ServiceOption {
+	return func(s *Service) {
+		s.history = history
+	}
+}
+
+// WithProviderCircuit includes the circuit breaker state of the provider in readiness checks.
+func WithProviderCircuit(circuit ProviderCircuit) ServiceOption {
+	return func(s *Service) {
+		s.circuit = circuit
+	}
+}
+
+// WithUpdateStaleness sets how old the last successful rate update may get before readiness reports it as degraded.
+func WithUpdateStaleness(updateStaleness time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.updateStaleness = updateStaleness
+	}
+}
+
+// WithTrackedCoins makes UpdateRates fetch the given coins even before any rate of them is stored.
+func WithTrackedCoins(titles []string) ServiceOption {
+	return func(s *Service) {
+		s.SetTrackedCoins(titles)
+	}
+}
+
+// WithUpdateInterval sets the expected time between scheduled rate updates, longer gaps between stored
+// rates are reported as quality issues.
+func WithUpdateInterval(interval time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.SetUpdateInterval(interval)
+	}
+}
+
+// WithQualityWindow sets how far back quality reports look by default.
+func WithQualityWindow(window time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.qualityWindow = window
+	}
+}
+
+// WithJumpSigma sets how many standard deviations a change between consecutive rates may stray from the
+// mean change of the coin before quality reports flag it as a jump.
+func WithJumpSigma(sigma float64) ServiceOption {
+	return func(s *Service) {
+		s.jumpSigma = sigma
+	}
+}
+
+// WithOutlierGuard quarantines fetched rates that deviate from the median of the rates stored within window
+// by more than maxDeviation, 0.5 being 50%, instead of storing them. A zero maxDeviation disables the guard.
+func WithOutlierGuard(maxDeviation float64, window time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.maxDeviation = maxDeviation
+		s.outlierWindow = window
+	}
+}
+
+func WithLogger(logger *slog.Logger) ServiceOption {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+func NewService(storage Storage, provider CryptoProvider, opts ...ServiceOption) (*Service, error) {
 	if storage == nil {
 		return nil, errors.Wrap(entities.ErrInvalidParam, "storage not set")
 	}
@@ -23,23 +131,62 @@ func NewService(storage Storage, provider CryptoProvider) (*Service, error) {
 	if provider == nil {
 		return nil, errors.Wrap(entities.ErrInvalidParam, "cryptoProvider not set")
 	}
-	return &Service{
-		storage:  storage,
-		provider: provider,
-	}, nil
+
+	s := &Service{
+		storage:         storage,
+		provider:        provider,
+		maxStaleness:    defaultMaxStaleness,
+		updateStaleness: defaultUpdateStaleness,
+		qualityWindow:   defaultQualityWindow,
+		jumpSigma:       defaultJumpSigma,
+		logger:          slog.Default(),
+	}
+	s.updateInterval.Store(int64(defaultUpdateInterval))
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.maxStaleness <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "max staleness must be greater than zero")
+	}
+	if s.updateStaleness <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "update staleness must be greater than zero")
+	}
+	if s.updateInterval.Load() <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "update interval must be greater than zero")
+	}
+	if s.qualityWindow <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "quality window must be greater than zero")
+	}
+	if s.jumpSigma <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "jump sigma must be greater than zero")
+	}
+	if s.maxDeviation < 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "max deviation cannot be negative")
+	}
+	if s.maxDeviation > 0 && s.outlierWindow <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "outlier window must be greater than zero")
+	}
+	if s.logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "logger cannot be nil")
+	}
+	return s, nil
 }
 
-func (s *Service) GetLastRates(ctx context.Context, requestedTitles []string) ([]*entities.Coin, error) {
-	slog.Info("Starting retrieval of last rates", "requested_titles", requestedTitles)
+func (s *Service) GetLastRates(ctx context.Context, requestedTitles []string) (_ []*entities.Coin, err error) {
+	ctx, span := tracer.Start(ctx, "Service.GetLastRates", trace.WithAttributes(attribute.StringSlice("coin.titles", requestedTitles)))
+	defer func() { endSpan(span, err) }()
+
+	s.logger.DebugContext(ctx, "Starting retrieval of last rates", "requested_titles", requestedTitles)
 
 	if err := s.ValidateAndFetchTitles(ctx, requestedTitles); err != nil {
-		slog.Error("Validation failed while preprocessing requested titles", "requested_titles", requestedTitles, "err", err)
+		s.logger.ErrorContext(ctx, "Validation failed while preprocessing requested titles", "requested_titles", requestedTitles, "err", err)
 		return nil, errors.Wrap(err, "failed to preprocess requested titles")
 	}
 
 	coinsForUser, err := s.storage.GetActualCoins(ctx, requestedTitles)
 	if err != nil {
-		slog.Error("Failed to fetch actual coin rates", "requested_titles", requestedTitles, "err", err)
+		s.logger.ErrorContext(ctx, "Failed to fetch actual coin rates", "requested_titles", requestedTitles, "err", err)
 		return nil, errors.Wrap(entities.ErrInternal, "failed to get coin rates")
 	}
 
@@ -48,26 +195,36 @@ func (s *Service) GetLastRates(ctx context.Context, requestedTitles []string) ([
 		result[i] = &coin
 	}
 
-	slog.Info("Retrieved latest coin rates successfully", "number_of_coins", len(result))
+	s.logger.DebugContext(ctx, "Retrieved latest coin rates successfully", "number_of_coins", len(result))
 	return result, nil
 }
 
-func (s *Service) GetAggregateRates(ctx context.Context, requestedTitles []string, aggType string) ([]*entities.Coin, error) {
-	slog.Info("Starting aggregation of coin rates", "requested_titles", requestedTitles, "agg_type", aggType)
+// GetAggregateRates computes aggType over the rates stored within [from, to], a zero from covers the whole
+// history and a zero to ends the window now.
+func (s *Service) GetAggregateRates(ctx context.Context, requestedTitles []string, aggType string, from, to time.Time) ([]*entities.Coin, error) {
+	s.logger.DebugContext(ctx, "Starting aggregation of coin rates", "requested_titles", requestedTitles, "agg_type", aggType, "from", from, "to", to)
+
+	from, to, err := s.resolveWindow(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := s.ValidateAndFetchTitles(ctx, requestedTitles); err != nil {
-		slog.Error("Validation failed while processing aggregate requested titles", "requested_titles", requestedTitles, "err", err)
+		s.logger.ErrorContext(ctx, "Validation failed while processing aggregate requested titles", "requested_titles", requestedTitles, "err", err)
 		return nil, errors.Wrap(err, "failed to preprocess aggregate requested titles")
 	}
 
 	if aggType == "" {
-		slog.Error("Aggregation type cannot be empty", "requested_titles", requestedTitles)
+		s.logger.ErrorContext(ctx, "Aggregation type cannot be empty", "requested_titles", requestedTitles)
 		return nil, errors.Wrap(entities.ErrInvalidParam, "aggregation type cannot be empty")
 	}
 
-	coinsForUser, err := s.storage.GetAggregateCoins(ctx, requestedTitles, aggType)
+	coinsForUser, err := s.storage.GetAggregateCoins(ctx, requestedTitles, aggType, from, to)
 	if err != nil {
-		slog.Error("Failed to fetch aggregated coin rates", "requested_titles", requestedTitles, "agg_type", aggType, "err", err)
+		s.logger.ErrorContext(ctx, "Failed to fetch aggregated coin rates", "requested_titles", requestedTitles, "agg_type", aggType, "err", err)
+		if errors.Is(err, entities.ErrInvalidParam) {
+			return nil, errors.Wrap(err, "failed to get aggregate coin rates")
+		}
 		return nil, errors.Wrap(entities.ErrInternal, "failed to get aggregate coin rates")
 	}
 
@@ -76,39 +233,247 @@ func (s *Service) GetAggregateRates(ctx context.Context, requestedTitles []strin
 		result[i] = &coin
 	}
 
-	slog.Info("Aggregated coin rates retrieved successfully", "number_of_coins", len(result), "agg_type", aggType)
+	s.logger.DebugContext(ctx, "Aggregated coin rates retrieved successfully", "number_of_coins", len(result), "agg_type", aggType)
+	return result, nil
+}
+
+// GetAggregateStats computes every aggType over the rates stored within [from, to], see GetAggregateRates.
+func (s *Service) GetAggregateStats(ctx context.Context, requestedTitles []string, aggTypes []string, from, to time.Time) ([]*entities.CoinStats, error) {
+	s.logger.DebugContext(ctx, "Starting multi aggregation of coin rates", "requested_titles", requestedTitles, "agg_types", aggTypes, "from", from, "to", to)
+
+	uniqueAggTypes, err := dedupeAggTypes(aggTypes)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Invalid aggregation types", "agg_types", aggTypes, "err", err)
+		return nil, err
+	}
+
+	from, to, err = s.resolveWindow(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ValidateAndFetchTitles(ctx, requestedTitles); err != nil {
+		s.logger.ErrorContext(ctx, "Validation failed while processing aggregate requested titles", "requested_titles", requestedTitles, "err", err)
+		return nil, errors.Wrap(err, "failed to preprocess aggregate requested titles")
+	}
+
+	statsForUser, err := s.storage.GetAggregateStats(ctx, requestedTitles, uniqueAggTypes, from, to)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch aggregated coin stats", "requested_titles", requestedTitles, "agg_types", uniqueAggTypes, "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to get aggregate coin stats")
+	}
+
+	result := make([]*entities.CoinStats, len(statsForUser))
+	for i := range statsForUser {
+		result[i] = &statsForUser[i]
+	}
+
+	s.logger.DebugContext(ctx, "Aggregated coin stats retrieved successfully", "number_of_coins", len(result), "agg_types", uniqueAggTypes)
 	return result, nil
 }
 
-func (s *Service) UpdateRates(ctx context.Context) error {
-	slog.Info("Updating coin rates started")
+func dedupeAggTypes(aggTypes []string) ([]string, error) {
+	if len(aggTypes) == 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "aggregation types list cannot be empty")
+	}
+
+	result := make([]string, 0, len(aggTypes))
+	seenAggTypes := make(map[string]struct{}, len(aggTypes))
+	for _, aggType := range aggTypes {
+		if aggType == "" {
+			return nil, errors.Wrap(entities.ErrInvalidParam, "aggregation type cannot be empty")
+		}
+		if _, exists := seenAggTypes[aggType]; exists {
+			continue
+		}
+		seenAggTypes[aggType] = struct{}{}
+		result = append(result, aggType)
+	}
+	return result, nil
+}
+
+func (s *Service) GetRatesAt(ctx context.Context, requestedTitles []string, at time.Time, maxStaleness time.Duration) ([]*entities.Coin, error) {
+	s.logger.DebugContext(ctx, "Starting retrieval of rates at time", "requested_titles", requestedTitles, "at", at, "max_staleness", maxStaleness)
+
+	if len(requestedTitles) == 0 {
+		s.logger.ErrorContext(ctx, "Empty titles list provided", "requested_titles", requestedTitles)
+		return nil, errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty")
+	}
+
+	if at.IsZero() {
+		s.logger.ErrorContext(ctx, "Lookup time cannot be empty", "requested_titles", requestedTitles)
+		return nil, errors.Wrap(entities.ErrInvalidParam, "lookup time cannot be empty")
+	}
+
+	if maxStaleness < 0 {
+		s.logger.ErrorContext(ctx, "Negative max staleness provided", "max_staleness", maxStaleness)
+		return nil, errors.Wrap(entities.ErrInvalidParam, "max staleness cannot be negative")
+	}
+	if maxStaleness == 0 {
+		maxStaleness = s.maxStaleness
+	}
+
+	coinsAt, err := s.storage.GetCoinsAt(ctx, requestedTitles, at)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to fetch coin rates at time", "requested_titles", requestedTitles, "at", at, "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to get coin rates at time")
+	}
+
+	foundCoins := make(map[string]entities.Coin, len(coinsAt))
+	for _, coin := range coinsAt {
+		foundCoins[coin.Title] = coin
+	}
+
+	result := make([]*entities.Coin, 0, len(coinsAt))
+	seenTitles := make(map[string]struct{}, len(requestedTitles))
+	for _, title := range requestedTitles {
+		if _, exists := seenTitles[title]; exists {
+			continue
+		}
+		seenTitles[title] = struct{}{}
+
+		coin, exists := foundCoins[title]
+		if !exists {
+			s.logger.ErrorContext(ctx, "No stored rate found before lookup time", "missing_title", title, "at", at)
+			return nil, errors.Wrapf(entities.ErrNotFound, "no stored rate for coin %q at or before %s", title, at.Format(time.RFC3339))
+		}
+		if at.Sub(coin.ActualAt) > maxStaleness {
+			s.logger.ErrorContext(ctx, "Stored rate is too stale", "title", title, "at", at, "actual_at", coin.ActualAt, "max_staleness", maxStaleness)
+			return nil, errors.Wrapf(entities.ErrNotFound, "latest stored rate for coin %q at %s is older than %s", title, coin.ActualAt.Format(time.RFC3339), maxStaleness)
+		}
+		result = append(result, &coin)
+	}
+
+	s.logger.DebugContext(ctx, "Retrieved coin rates at time successfully", "number_of_coins", len(result), "at", at)
+	return result, nil
+}
+
+func (s *Service) Convert(ctx context.Context, from, to string, amount float64, at time.Time, maxStaleness time.Duration) (*entities.Conversion, error) {
+	s.logger.DebugContext(ctx, "Starting conversion", "from", from, "to", to, "amount", amount, "at", at)
+
+	if from == "" || to == "" {
+		s.logger.ErrorContext(ctx, "Conversion legs cannot be empty", "from", from, "to", to)
+		return nil, errors.Wrap(entities.ErrInvalidParam, "from and to titles cannot be empty")
+	}
+
+	if amount <= 0 {
+		s.logger.ErrorContext(ctx, "Conversion amount must be positive", "amount", amount)
+		return nil, errors.Wrap(entities.ErrInvalidParam, "amount must be greater than zero")
+	}
+
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+
+	legs, err := s.GetRatesAt(ctx, []string{from, to}, at, maxStaleness)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to get conversion rates", "from", from, "to", to, "at", at, "err", err)
+		return nil, errors.Wrap(err, "failed to get conversion rates")
+	}
+
+	fromCoin := *legs[0]
+	toCoin := fromCoin
+	if len(legs) > 1 {
+		toCoin = *legs[1]
+	}
+
+	// Costs are stored with two decimals, a coin worth less than a cent is stored as zero and cannot be converted into.
+	if toCoin.Cost <= 0 {
+		s.logger.ErrorContext(ctx, "Conversion target has no usable rate", "to", to, "cost", toCoin.Cost)
+		return nil, errors.Wrapf(entities.ErrInvalidParam, "coin %q has no usable rate to convert into", to)
+	}
+
+	rate := fromCoin.Cost / toCoin.Cost
+	conversion := &entities.Conversion{
+		From:   fromCoin,
+		To:     toCoin,
+		Amount: amount,
+		Rate:   rate,
+		Result: amount * rate,
+		At:     at,
+	}
+
+	s.logger.DebugContext(ctx, "Conversion completed successfully", "from", from, "to", to, "rate", rate)
+	return conversion, nil
+}
+
+func (s *Service) UpdateRates(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "Service.UpdateRates")
+	defer func() { endSpan(span, err) }()
+
+	s.logger.DebugContext(ctx, "Updating coin rates started")
 
 	titles, err := s.storage.GetCoinsList(ctx)
 	if err != nil {
-		slog.Error("Failed to get coins list from storage", "err", err)
+		s.logger.ErrorContext(ctx, "Failed to get coins list from storage", "err", err)
 		return errors.Wrap(err, "failed to get coins list from storage")
 	}
+	titles = s.withTrackedCoins(titles)
 
 	currentRates, err := s.provider.GetActualRates(ctx, titles)
 	if err != nil {
-		slog.Error("Failed to retrieve current rates from provider", "titles", titles, "err", err)
+		s.logger.ErrorContext(ctx, "Failed to retrieve current rates from provider", "titles", titles, "err", err)
 		return errors.Wrap(err, "failed to retrieve current rates from provider")
 	}
 
+	currentRates, err = s.screenRates(ctx, currentRates)
+	if err != nil {
+		return err
+	}
+
 	if err := s.storage.Store(ctx, currentRates); err != nil {
-		slog.Error("Failed to store updated rates in storage", "titles", titles, "err", err)
+		s.logger.ErrorContext(ctx, "Failed to store updated rates in storage", "titles", titles, "err", err)
 		return errors.Wrap(err, "failed to store updated rates in storage")
 	}
 
-	slog.Info("Coin rates update completed successfully", "number_of_rates_updated", len(currentRates))
+	s.lastUpdate.Store(time.Now().UnixNano())
+
+	if s.publisher != nil {
+		s.publisher.Publish(ctx, currentRates)
+	}
+
+	s.logger.InfoContext(ctx, "Coin rates update completed successfully", "number_of_rates_updated", len(currentRates))
 	return nil
 }
 
-func (s *Service) ValidateAndFetchTitles(ctx context.Context, requestedTitles []string) error {
-	slog.Info("Validating and fetching requested titles", "requested_titles", requestedTitles)
+// SetTrackedCoins replaces the coins updated in addition to the stored ones, it is safe to call while the service runs.
+func (s *Service) SetTrackedCoins(titles []string) {
+	tracked := make([]string, 0, len(titles))
+	for _, title := range titles {
+		if title = strings.TrimSpace(title); title != "" {
+			tracked = append(tracked, title)
+		}
+	}
+	s.trackedCoins.Store(&tracked)
+}
+
+func (s *Service) withTrackedCoins(titles []string) []string {
+	tracked := s.trackedCoins.Load()
+	if tracked == nil || len(*tracked) == 0 {
+		return titles
+	}
+
+	seen := make(map[string]struct{}, len(titles))
+	for _, title := range titles {
+		seen[title] = struct{}{}
+	}
+	for _, title := range *tracked {
+		if _, exists := seen[title]; !exists {
+			seen[title] = struct{}{}
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
+
+func (s *Service) ValidateAndFetchTitles(ctx context.Context, requestedTitles []string) (err error) {
+	ctx, span := tracer.Start(ctx, "Service.ValidateAndFetchTitles", trace.WithAttributes(attribute.StringSlice("coin.titles", requestedTitles)))
+	defer func() { endSpan(span, err) }()
+
+	s.logger.DebugContext(ctx, "Validating and fetching requested titles", "requested_titles", requestedTitles)
 
 	if len(requestedTitles) == 0 {
-		slog.Error("Empty titles list provided", "requested_titles", requestedTitles)
+		s.logger.ErrorContext(ctx, "Empty titles list provided", "requested_titles", requestedTitles)
 		return errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty")
 	}
 
@@ -125,10 +490,15 @@ func (s *Service) ValidateAndFetchTitles(ctx context.Context, requestedTitles []
 	allNewCoins, err := s.provider.GetActualRates(ctx, allUniqueTitles)
 	if err != nil {
 		if strings.Contains(err.Error(), "API returned an error") {
-			slog.Error("Provider API returned an error", "all_unique_titles", allUniqueTitles, "err", err)
+			s.logger.ErrorContext(ctx, "Provider API returned an error", "all_unique_titles", allUniqueTitles, "err", err)
 			return errors.Wrap(entities.ErrInvalidParam, err.Error())
 		}
-		slog.Error("Failed to retrieve actual rates from provider", "all_unique_titles", allUniqueTitles, "err", err)
+		if errors.Is(err, entities.ErrInvalidParam) {
+			// The provider rejects a request whose coins it knows none of.
+			s.logger.ErrorContext(ctx, "Provider rejected requested titles", "all_unique_titles", allUniqueTitles, "err", err)
+			return errors.Wrapf(entities.ErrNotFound, "coins %s do not exist or were not found in the provider", strings.Join(requestedTitles, ", "))
+		}
+		s.logger.ErrorContext(ctx, "Failed to retrieve actual rates from provider", "all_unique_titles", allUniqueTitles, "err", err)
 		return errors.Wrap(err, "failed to retrieve actual rates from provider")
 	}
 
@@ -139,16 +509,21 @@ func (s *Service) ValidateAndFetchTitles(ctx context.Context, requestedTitles []
 
 	for _, title := range requestedTitles {
 		if _, exists := foundSymbols[title]; !exists {
-			slog.Error("Coin not found", "missing_title", title)
+			s.logger.ErrorContext(ctx, "Coin not found", "missing_title", title)
 			return errors.Wrapf(entities.ErrNotFound, "coin %q does not exist or was not found in the provider", title)
 		}
 	}
 
+	allNewCoins, err = s.screenRates(ctx, allNewCoins)
+	if err != nil {
+		return err
+	}
+
 	if err := s.storage.Store(ctx, allNewCoins); err != nil {
-		slog.Error("Failed to store new rates", "new_rates", allUniqueTitles, "err", err)
+		s.logger.ErrorContext(ctx, "Failed to store new rates", "new_rates", allUniqueTitles, "err", err)
 		return errors.Wrap(entities.ErrInternal, "failed to store new rates")
 	}
 
-	slog.Info("Title validation and fetching completed successfully", "validated_titles", requestedTitles)
+	s.logger.DebugContext(ctx, "Title validation and fetching completed successfully", "validated_titles", requestedTitles)
 	return nil
 }
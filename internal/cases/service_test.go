@@ -6,6 +6,7 @@ import (
 	"Cryptoproject/internal/entities"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
@@ -124,19 +125,24 @@ func TestService_GetLastRates_GetActualCoinsError(t *testing.T) {
 func TestService_GetAggregateRates_Success(t *testing.T) {
 	t.Parallel()
 
-	service, mockStorage, _ := setupService(t)
+	service, mockStorage, mockProvider := setupService(t)
 
 	requestedTitles := []string{"BTC", "ETH"}
 	aggType := "MAX"
 
-	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC", "ETH"}, nil)
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), gomock.InAnyOrder(requestedTitles)).Return([]entities.Coin{
+		{Title: "BTC", Cost: 50000},
+		{Title: "ETH", Cost: 3000},
+	}, nil)
+
+	mockStorage.EXPECT().Store(gomock.Any(), gomock.Len(2)).Return(nil)
 
-	mockStorage.EXPECT().GetAggregateCoins(gomock.Any(), requestedTitles, aggType).Return([]entities.Coin{
+	mockStorage.EXPECT().GetAggregateCoins(gomock.Any(), requestedTitles, aggType, gomock.Any(), gomock.Any()).Return([]entities.Coin{
 		{Title: "BTC", Cost: 50000},
 		{Title: "ETH", Cost: 3000},
 	}, nil)
 
-	rates, err := service.GetAggregateRates(context.Background(), requestedTitles, aggType)
+	rates, err := service.GetAggregateRates(context.Background(), requestedTitles, aggType, time.Time{}, time.Time{})
 
 	require.NoError(t, err)
 	require.Len(t, rates, 2)
@@ -149,7 +155,7 @@ func TestService_GetAggregateRates_EmptyTitles(t *testing.T) {
 
 	service, _, _ := setupService(t)
 
-	rates, err := service.GetAggregateRates(context.Background(), []string{}, "MAX")
+	rates, err := service.GetAggregateRates(context.Background(), []string{}, "MAX", time.Time{}, time.Time{})
 
 	require.Nil(t, rates)
 	require.ErrorContains(t, err, "titles list cannot be empty")
@@ -159,36 +165,325 @@ func TestService_GetAggregateRates_EmptyTitles(t *testing.T) {
 func TestService_GetAggregateRates_EmptyAggType(t *testing.T) {
 	t.Parallel()
 
-	service, mockStorage, _ := setupService(t)
+	service, mockStorage, mockProvider := setupService(t)
 
-	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC", "ETH"}, nil)
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), []string{"BTC"}).Return([]entities.Coin{{Title: "BTC", Cost: 50000}}, nil)
+
+	mockStorage.EXPECT().Store(gomock.Any(), []entities.Coin{{Title: "BTC", Cost: 50000}}).Return(nil)
 
-	rates, err := service.GetAggregateRates(context.Background(), []string{"BTC"}, "")
+	rates, err := service.GetAggregateRates(context.Background(), []string{"BTC"}, "", time.Time{}, time.Time{})
 
 	require.Nil(t, rates)
 	require.ErrorContains(t, err, "aggregation type cannot be empty")
 	require.ErrorIs(t, err, entities.ErrInvalidParam)
 }
 
+func TestService_GetAggregateRates_InvertedWindow(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := setupService(t)
+
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	rates, err := service.GetAggregateRates(context.Background(), []string{"BTC"}, "CHANGE", from, from.Add(-time.Hour))
+
+	require.Nil(t, rates)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
 func TestService_GetAggregateRates_GetAggregateCoinsError(t *testing.T) {
 	t.Parallel()
 
-	service, mockStorage, _ := setupService(t)
+	service, mockStorage, mockProvider := setupService(t)
 
 	requestedTitles := []string{"BTC"}
 	aggType := "MAX"
 
-	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC", "ETH"}, nil)
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), requestedTitles).Return([]entities.Coin{{Title: "BTC", Cost: 50000}}, nil)
 
-	mockStorage.EXPECT().GetAggregateCoins(gomock.Any(), requestedTitles, aggType).Return(nil, entities.ErrInternal)
+	mockStorage.EXPECT().Store(gomock.Any(), []entities.Coin{{Title: "BTC", Cost: 50000}}).Return(nil)
 
-	rates, err := service.GetAggregateRates(context.Background(), requestedTitles, aggType)
+	mockStorage.EXPECT().GetAggregateCoins(gomock.Any(), requestedTitles, aggType, gomock.Any(), gomock.Any()).Return(nil, entities.ErrInternal)
+
+	rates, err := service.GetAggregateRates(context.Background(), requestedTitles, aggType, time.Time{}, time.Time{})
 
 	require.Nil(t, rates)
 	require.ErrorIs(t, err, entities.ErrInternal)
 	require.ErrorContains(t, err, "failed to get aggregate coin rates")
 }
 
+func TestService_GetAggregateRates_UndefinedAggregate(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, mockProvider := setupService(t)
+
+	requestedTitles := []string{"BTC"}
+
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), requestedTitles).Return([]entities.Coin{{Title: "BTC", Cost: 50000}}, nil)
+
+	mockStorage.EXPECT().Store(gomock.Any(), []entities.Coin{{Title: "BTC", Cost: 50000}}).Return(nil)
+
+	mockStorage.EXPECT().GetAggregateCoins(gomock.Any(), requestedTitles, "CHANGE_PCT", gomock.Any(), gomock.Any()).
+		Return(nil, errors.Wrap(entities.ErrInvalidParam, `CHANGE_PCT of coin "BTC" is undefined in the requested window`))
+
+	rates, err := service.GetAggregateRates(context.Background(), requestedTitles, "CHANGE_PCT", time.Time{}, time.Time{})
+
+	require.Nil(t, rates)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.ErrorContains(t, err, "is undefined in the requested window")
+}
+
+func TestService_GetAggregateRates_StatisticTypes(t *testing.T) {
+	t.Parallel()
+
+	for _, aggType := range []string{"CHANGE", "CHANGE_PCT", "STDDEV", "FIRST", "LAST", "MEDIAN", "COUNT"} {
+		t.Run(aggType, func(t *testing.T) {
+			t.Parallel()
+
+			service, mockStorage, mockProvider := setupService(t)
+
+			requestedTitles := []string{"BTC"}
+
+			mockProvider.EXPECT().GetActualRates(gomock.Any(), requestedTitles).Return([]entities.Coin{{Title: "BTC", Cost: 50000}}, nil)
+
+			mockStorage.EXPECT().Store(gomock.Any(), []entities.Coin{{Title: "BTC", Cost: 50000}}).Return(nil)
+
+			mockStorage.EXPECT().GetAggregateCoins(gomock.Any(), requestedTitles, aggType, gomock.Any(), gomock.Any()).Return([]entities.Coin{
+				{Title: "BTC", Cost: 12.5},
+			}, nil)
+
+			rates, err := service.GetAggregateRates(context.Background(), requestedTitles, aggType, time.Time{}, time.Time{})
+
+			require.NoError(t, err)
+			require.Len(t, rates, 1)
+			require.Equal(t, 12.5, rates[0].Cost)
+		})
+	}
+}
+
+func TestService_GetAggregateStats_Success(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, mockProvider := setupService(t)
+
+	requestedTitles := []string{"BTC"}
+
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), requestedTitles).Return([]entities.Coin{{Title: "BTC", Cost: 50000}}, nil)
+
+	mockStorage.EXPECT().Store(gomock.Any(), []entities.Coin{{Title: "BTC", Cost: 50000}}).Return(nil)
+
+	mockStorage.EXPECT().GetAggregateStats(gomock.Any(), requestedTitles, []string{"MIN", "MAX", "AVG"}, gomock.Any(), gomock.Any()).Return([]entities.CoinStats{
+		{Title: "BTC", Values: map[string]float64{"MIN": 40000, "MAX": 60000, "AVG": 50000}},
+	}, nil)
+
+	stats, err := service.GetAggregateStats(context.Background(), requestedTitles, []string{"MIN", "MAX", "MIN", "AVG"}, time.Time{}, time.Time{})
+
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, map[string]float64{"MIN": 40000, "MAX": 60000, "AVG": 50000}, stats[0].Values)
+}
+
+func TestService_GetAggregateStats_EmptyAggTypes(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := setupService(t)
+
+	stats, err := service.GetAggregateStats(context.Background(), []string{"BTC"}, nil, time.Time{}, time.Time{})
+
+	require.Nil(t, stats)
+	require.ErrorContains(t, err, "aggregation types list cannot be empty")
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestService_GetAggregateStats_GetAggregateStatsError(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, mockProvider := setupService(t)
+
+	requestedTitles := []string{"BTC"}
+
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), requestedTitles).Return([]entities.Coin{{Title: "BTC", Cost: 50000}}, nil)
+
+	mockStorage.EXPECT().Store(gomock.Any(), []entities.Coin{{Title: "BTC", Cost: 50000}}).Return(nil)
+
+	mockStorage.EXPECT().GetAggregateStats(gomock.Any(), requestedTitles, []string{"MEDIAN"}, gomock.Any(), gomock.Any()).Return(nil, entities.ErrInternal)
+
+	stats, err := service.GetAggregateStats(context.Background(), requestedTitles, []string{"MEDIAN"}, time.Time{}, time.Time{})
+
+	require.Nil(t, stats)
+	require.ErrorIs(t, err, entities.ErrInternal)
+	require.ErrorContains(t, err, "failed to get aggregate coin stats")
+}
+
+// --- Тесты для метода GetRatesAt ---
+
+func TestService_GetRatesAt_Success(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	requestedTitles := []string{"BTC", "ETH"}
+
+	mockStorage.EXPECT().GetCoinsAt(gomock.Any(), requestedTitles, at).Return([]entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: at.Add(-time.Minute)},
+		{Title: "ETH", Cost: 3000, ActualAt: at.Add(-5 * time.Minute)},
+	}, nil)
+
+	rates, err := service.GetRatesAt(context.Background(), requestedTitles, at, 10*time.Minute)
+
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	require.Equal(t, "BTC", rates[0].Title)
+	require.Equal(t, at.Add(-time.Minute), rates[0].ActualAt)
+	require.Equal(t, "ETH", rates[1].Title)
+	require.Equal(t, float64(3000), rates[1].Cost)
+}
+
+func TestService_GetRatesAt_DefaultMaxStaleness(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockProvider := mocks.NewMockCryptoProvider(ctrl)
+
+	service, err := cases.NewService(mockStorage, mockProvider, cases.WithMaxStaleness(30*time.Minute))
+	require.NoError(t, err)
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	mockStorage.EXPECT().GetCoinsAt(gomock.Any(), []string{"BTC"}, at).Return([]entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: at.Add(-time.Hour)},
+	}, nil)
+
+	rates, err := service.GetRatesAt(context.Background(), []string{"BTC"}, at, 0)
+
+	require.Nil(t, rates)
+	require.ErrorIs(t, err, entities.ErrNotFound)
+	require.ErrorContains(t, err, "is older than 30m0s")
+}
+
+func TestService_GetRatesAt_TitleNotFound(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	requestedTitles := []string{"BTC", "ETH"}
+
+	mockStorage.EXPECT().GetCoinsAt(gomock.Any(), requestedTitles, at).Return([]entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: at},
+	}, nil)
+
+	rates, err := service.GetRatesAt(context.Background(), requestedTitles, at, time.Hour)
+
+	require.Nil(t, rates)
+	require.ErrorIs(t, err, entities.ErrNotFound)
+	require.ErrorContains(t, err, `no stored rate for coin "ETH"`)
+}
+
+func TestService_GetRatesAt_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := setupService(t)
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err := service.GetRatesAt(context.Background(), nil, at, time.Hour)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.ErrorContains(t, err, "titles list cannot be empty")
+
+	_, err = service.GetRatesAt(context.Background(), []string{"BTC"}, time.Time{}, time.Hour)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.ErrorContains(t, err, "lookup time cannot be empty")
+
+	_, err = service.GetRatesAt(context.Background(), []string{"BTC"}, at, -time.Hour)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.ErrorContains(t, err, "max staleness cannot be negative")
+}
+
+func TestService_GetRatesAt_StorageError(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	mockStorage.EXPECT().GetCoinsAt(gomock.Any(), []string{"BTC"}, at).Return(nil, errors.New("connection refused"))
+
+	rates, err := service.GetRatesAt(context.Background(), []string{"BTC"}, at, time.Hour)
+
+	require.Nil(t, rates)
+	require.ErrorIs(t, err, entities.ErrInternal)
+	require.ErrorContains(t, err, "failed to get coin rates at time")
+}
+
+// --- Тесты для метода Convert ---
+
+func TestService_Convert_Success(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	mockStorage.EXPECT().GetCoinsAt(gomock.Any(), []string{"ETH", "BTC"}, at).Return([]entities.Coin{
+		{Title: "BTC", Cost: 60000, ActualAt: at.Add(-time.Minute)},
+		{Title: "ETH", Cost: 3000, ActualAt: at.Add(-2 * time.Minute)},
+	}, nil)
+
+	conversion, err := service.Convert(context.Background(), "ETH", "BTC", 3.5, at, 0)
+
+	require.NoError(t, err)
+	require.Equal(t, "ETH", conversion.From.Title)
+	require.Equal(t, "BTC", conversion.To.Title)
+	require.InDelta(t, 0.05, conversion.Rate, 1e-9)
+	require.InDelta(t, 0.175, conversion.Result, 1e-9)
+	require.Equal(t, at, conversion.At)
+}
+
+func TestService_Convert_StaleLeg(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	mockStorage.EXPECT().GetCoinsAt(gomock.Any(), []string{"ETH", "BTC"}, at).Return([]entities.Coin{
+		{Title: "BTC", Cost: 60000, ActualAt: at.Add(-time.Hour)},
+		{Title: "ETH", Cost: 3000, ActualAt: at.Add(-time.Minute)},
+	}, nil)
+
+	conversion, err := service.Convert(context.Background(), "ETH", "BTC", 1, at, 15*time.Minute)
+
+	require.Nil(t, conversion)
+	require.ErrorIs(t, err, entities.ErrNotFound)
+	require.ErrorContains(t, err, `coin "BTC"`)
+}
+
+func TestService_Convert_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	_, err := service.Convert(context.Background(), "", "BTC", 1, time.Time{}, 0)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.ErrorContains(t, err, "from and to titles cannot be empty")
+
+	_, err = service.Convert(context.Background(), "ETH", "BTC", 0, time.Time{}, 0)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.ErrorContains(t, err, "amount must be greater than zero")
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockStorage.EXPECT().GetCoinsAt(gomock.Any(), []string{"ETH", "SHIB"}, at).Return([]entities.Coin{
+		{Title: "SHIB", Cost: 0, ActualAt: at.Add(-time.Minute)},
+		{Title: "ETH", Cost: 3000, ActualAt: at.Add(-time.Minute)},
+	}, nil)
+
+	_, err = service.Convert(context.Background(), "ETH", "SHIB", 1, at, 0)
+	require.ErrorIs(t, err, entities.ErrInvalidParam, "a sub-cent rate is stored as zero")
+	require.ErrorContains(t, err, `coin "SHIB" has no usable rate to convert into`)
+}
+
 // --- Тесты для метода UpdateRates ---
 
 func TestService_UpdateRates_Success(t *testing.T) {
@@ -213,6 +508,75 @@ func TestService_UpdateRates_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestService_UpdateRates_PublishesStoredRates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockProvider := mocks.NewMockCryptoProvider(ctrl)
+	mockPublisher := mocks.NewMockPublisher(ctrl)
+
+	service, err := cases.NewService(mockStorage, mockProvider, cases.WithPublisher(mockPublisher))
+	require.NoError(t, err)
+
+	rates := []entities.Coin{{Title: "BTC", Cost: 50000}}
+
+	gomock.InOrder(
+		mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC"}, nil),
+		mockProvider.EXPECT().GetActualRates(gomock.Any(), []string{"BTC"}).Return(rates, nil),
+		mockStorage.EXPECT().Store(gomock.Any(), rates).Return(nil),
+		mockPublisher.EXPECT().Publish(gomock.Any(), rates),
+	)
+
+	require.NoError(t, service.UpdateRates(context.Background()))
+}
+
+func TestService_UpdateRates_StoreErrorNotPublished(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockProvider := mocks.NewMockCryptoProvider(ctrl)
+	mockPublisher := mocks.NewMockPublisher(ctrl)
+
+	service, err := cases.NewService(mockStorage, mockProvider, cases.WithPublisher(mockPublisher))
+	require.NoError(t, err)
+
+	rates := []entities.Coin{{Title: "BTC", Cost: 50000}}
+
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC"}, nil)
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), []string{"BTC"}).Return(rates, nil)
+	mockStorage.EXPECT().Store(gomock.Any(), rates).Return(entities.ErrInternal)
+
+	err = service.UpdateRates(context.Background())
+
+	require.ErrorIs(t, err, entities.ErrInternal)
+}
+
+func TestService_UpdateRates_TrackedCoins(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockProvider := mocks.NewMockCryptoProvider(ctrl)
+
+	service, err := cases.NewService(mockStorage, mockProvider, cases.WithTrackedCoins([]string{"ETH", " SOL ", ""}))
+	require.NoError(t, err)
+
+	rates := []entities.Coin{{Title: "BTC", Cost: 50000}, {Title: "ETH", Cost: 3000}, {Title: "SOL", Cost: 150}}
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC", "ETH"}, nil)
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), []string{"BTC", "ETH", "SOL"}).Return(rates, nil)
+	mockStorage.EXPECT().Store(gomock.Any(), rates).Return(nil)
+	require.NoError(t, service.UpdateRates(context.Background()))
+
+	// Tracked coins can change while the service runs.
+	service.SetTrackedCoins(nil)
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC", "ETH", "SOL"}, nil)
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), []string{"BTC", "ETH", "SOL"}).Return(rates, nil)
+	mockStorage.EXPECT().Store(gomock.Any(), rates).Return(nil)
+	require.NoError(t, service.UpdateRates(context.Background()))
+}
+
 func TestService_UpdateRates_GetCoinsListError(t *testing.T) {
 	t.Parallel()
 
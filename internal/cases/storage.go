@@ -3,12 +3,44 @@ package cases
 import (
 	"Cryptoproject/internal/entities"
 	"context"
+	"time"
 )
 
 //go:generate mockgen -source=storage.go -destination=./testdata/storage.go -package=testdata
 type Storage interface {
+	Ping(ctx context.Context) error
 	Store(ctx context.Context, coins []entities.Coin) error
 	GetCoinsList(ctx context.Context) ([]string, error)
 	GetActualCoins(ctx context.Context, titles []string) ([]entities.Coin, error)
-	GetAggregateCoins(ctx context.Context, titles []string, aggType string) ([]entities.Coin, error)
+	GetCoinsAt(ctx context.Context, titles []string, at time.Time) ([]entities.Coin, error)
+	GetCoinHistory(ctx context.Context, title string, from, to time.Time, limit int) ([]entities.Coin, error)
+	GetAggregateCoins(ctx context.Context, titles []string, aggType string, from, to time.Time) ([]entities.Coin, error)
+	GetAggregateStats(ctx context.Context, titles []string, aggTypes []string, from, to time.Time) ([]entities.CoinStats, error)
+	SetCoinTracked(ctx context.Context, title string, tracked bool) error
+	ListTrackedCoins(ctx context.Context) ([]entities.TrackedCoin, error)
+	StoreHistory(ctx context.Context, coins []entities.Coin, bucket time.Duration) (int, error)
+	CountRateBuckets(ctx context.Context, title string, from, to time.Time, bucket time.Duration) (int, error)
+	GetBackfillCursor(ctx context.Context, job entities.BackfillJob) (time.Time, error)
+	SaveBackfillCursor(ctx context.Context, job entities.BackfillJob) error
+	FindRateGaps(ctx context.Context, from, to time.Time, minGap time.Duration) ([]entities.QualityIssue, error)
+	FindRateJumps(ctx context.Context, from, to time.Time, sigma float64) ([]entities.QualityIssue, error)
+	FindDuplicateRates(ctx context.Context, from, to time.Time) ([]entities.QualityIssue, error)
+	GetReferenceRates(ctx context.Context, titles []string, since time.Time) (map[string]float64, error)
+	QuarantineRates(ctx context.Context, rates []entities.QuarantinedRate) error
+	ListQuarantinedRates(ctx context.Context, status entities.QuarantineStatus) ([]entities.QuarantinedRate, error)
+	ApproveQuarantinedRate(ctx context.Context, id int64) error
+	DiscardQuarantinedRate(ctx context.Context, id int64) error
+
+	CreatePortfolio(ctx context.Context, portfolio entities.Portfolio) (entities.Portfolio, error)
+	GetPortfolio(ctx context.Context, id int64) (entities.Portfolio, error)
+	ListPortfolios(ctx context.Context) ([]entities.Portfolio, error)
+	UpdatePortfolio(ctx context.Context, portfolio entities.Portfolio) (entities.Portfolio, error)
+	DeletePortfolio(ctx context.Context, id int64) error
+
+	CreateAPIKey(ctx context.Context, key entities.APIKey) (entities.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash string) (entities.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]entities.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+	ConsumeAPIKeyQuota(ctx context.Context, id int64, day time.Time, quota int64) (bool, error)
+	GetAPIKeyUsage(ctx context.Context, from, to time.Time) ([]entities.APIKeyUsage, error)
 }
@@ -8,6 +8,7 @@ import (
 	entities "Cryptoproject/internal/entities"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -49,3 +50,78 @@ func (mr *MockCryptoProviderMockRecorder) GetActualRates(ctx, titles interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActualRates", reflect.TypeOf((*MockCryptoProvider)(nil).GetActualRates), ctx, titles)
 }
+
+// MockProviderCircuit is a mock of ProviderCircuit interface.
+type MockProviderCircuit struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderCircuitMockRecorder
+}
+
+// MockProviderCircuitMockRecorder is the mock recorder for MockProviderCircuit.
+type MockProviderCircuitMockRecorder struct {
+	mock *MockProviderCircuit
+}
+
+// NewMockProviderCircuit creates a new mock instance.
+func NewMockProviderCircuit(ctrl *gomock.Controller) *MockProviderCircuit {
+	mock := &MockProviderCircuit{ctrl: ctrl}
+	mock.recorder = &MockProviderCircuitMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProviderCircuit) EXPECT() *MockProviderCircuitMockRecorder {
+	return m.recorder
+}
+
+// CircuitState mocks base method.
+func (m *MockProviderCircuit) CircuitState() entities.CircuitState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CircuitState")
+	ret0, _ := ret[0].(entities.CircuitState)
+	return ret0
+}
+
+// CircuitState indicates an expected call of CircuitState.
+func (mr *MockProviderCircuitMockRecorder) CircuitState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CircuitState", reflect.TypeOf((*MockProviderCircuit)(nil).CircuitState))
+}
+
+// MockHistoryProvider is a mock of HistoryProvider interface.
+type MockHistoryProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryProviderMockRecorder
+}
+
+// MockHistoryProviderMockRecorder is the mock recorder for MockHistoryProvider.
+type MockHistoryProviderMockRecorder struct {
+	mock *MockHistoryProvider
+}
+
+// NewMockHistoryProvider creates a new mock instance.
+func NewMockHistoryProvider(ctrl *gomock.Controller) *MockHistoryProvider {
+	mock := &MockHistoryProvider{ctrl: ctrl}
+	mock.recorder = &MockHistoryProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryProvider) EXPECT() *MockHistoryProviderMockRecorder {
+	return m.recorder
+}
+
+// GetHistoricalRates mocks base method.
+func (m *MockHistoryProvider) GetHistoricalRates(ctx context.Context, title string, resolution entities.Resolution, from, to time.Time) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricalRates", ctx, title, resolution, from, to)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricalRates indicates an expected call of GetHistoricalRates.
+func (mr *MockHistoryProviderMockRecorder) GetHistoricalRates(ctx, title, resolution, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalRates", reflect.TypeOf((*MockHistoryProvider)(nil).GetHistoricalRates), ctx, title, resolution, from, to)
+}
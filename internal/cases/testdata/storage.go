@@ -8,6 +8,7 @@ import (
 	entities "Cryptoproject/internal/entities"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,6 +36,183 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// ApproveQuarantinedRate mocks base method.
+func (m *MockStorage) ApproveQuarantinedRate(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveQuarantinedRate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveQuarantinedRate indicates an expected call of ApproveQuarantinedRate.
+func (mr *MockStorageMockRecorder) ApproveQuarantinedRate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveQuarantinedRate", reflect.TypeOf((*MockStorage)(nil).ApproveQuarantinedRate), ctx, id)
+}
+
+// ConsumeAPIKeyQuota mocks base method.
+func (m *MockStorage) ConsumeAPIKeyQuota(ctx context.Context, id int64, day time.Time, quota int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeAPIKeyQuota", ctx, id, day, quota)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeAPIKeyQuota indicates an expected call of ConsumeAPIKeyQuota.
+func (mr *MockStorageMockRecorder) ConsumeAPIKeyQuota(ctx, id, day, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeAPIKeyQuota", reflect.TypeOf((*MockStorage)(nil).ConsumeAPIKeyQuota), ctx, id, day, quota)
+}
+
+// CountRateBuckets mocks base method.
+func (m *MockStorage) CountRateBuckets(ctx context.Context, title string, from, to time.Time, bucket time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRateBuckets", ctx, title, from, to, bucket)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRateBuckets indicates an expected call of CountRateBuckets.
+func (mr *MockStorageMockRecorder) CountRateBuckets(ctx, title, from, to, bucket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRateBuckets", reflect.TypeOf((*MockStorage)(nil).CountRateBuckets), ctx, title, from, to, bucket)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockStorage) CreateAPIKey(ctx context.Context, key entities.APIKey) (entities.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, key)
+	ret0, _ := ret[0].(entities.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockStorageMockRecorder) CreateAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockStorage)(nil).CreateAPIKey), ctx, key)
+}
+
+// CreatePortfolio mocks base method.
+func (m *MockStorage) CreatePortfolio(ctx context.Context, portfolio entities.Portfolio) (entities.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePortfolio", ctx, portfolio)
+	ret0, _ := ret[0].(entities.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePortfolio indicates an expected call of CreatePortfolio.
+func (mr *MockStorageMockRecorder) CreatePortfolio(ctx, portfolio interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePortfolio", reflect.TypeOf((*MockStorage)(nil).CreatePortfolio), ctx, portfolio)
+}
+
+// DeletePortfolio mocks base method.
+func (m *MockStorage) DeletePortfolio(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePortfolio", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePortfolio indicates an expected call of DeletePortfolio.
+func (mr *MockStorageMockRecorder) DeletePortfolio(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePortfolio", reflect.TypeOf((*MockStorage)(nil).DeletePortfolio), ctx, id)
+}
+
+// DiscardQuarantinedRate mocks base method.
+func (m *MockStorage) DiscardQuarantinedRate(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardQuarantinedRate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardQuarantinedRate indicates an expected call of DiscardQuarantinedRate.
+func (mr *MockStorageMockRecorder) DiscardQuarantinedRate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardQuarantinedRate", reflect.TypeOf((*MockStorage)(nil).DiscardQuarantinedRate), ctx, id)
+}
+
+// FindDuplicateRates mocks base method.
+func (m *MockStorage) FindDuplicateRates(ctx context.Context, from, to time.Time) ([]entities.QualityIssue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicateRates", ctx, from, to)
+	ret0, _ := ret[0].([]entities.QualityIssue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicateRates indicates an expected call of FindDuplicateRates.
+func (mr *MockStorageMockRecorder) FindDuplicateRates(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicateRates", reflect.TypeOf((*MockStorage)(nil).FindDuplicateRates), ctx, from, to)
+}
+
+// FindRateGaps mocks base method.
+func (m *MockStorage) FindRateGaps(ctx context.Context, from, to time.Time, minGap time.Duration) ([]entities.QualityIssue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRateGaps", ctx, from, to, minGap)
+	ret0, _ := ret[0].([]entities.QualityIssue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRateGaps indicates an expected call of FindRateGaps.
+func (mr *MockStorageMockRecorder) FindRateGaps(ctx, from, to, minGap interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRateGaps", reflect.TypeOf((*MockStorage)(nil).FindRateGaps), ctx, from, to, minGap)
+}
+
+// FindRateJumps mocks base method.
+func (m *MockStorage) FindRateJumps(ctx context.Context, from, to time.Time, sigma float64) ([]entities.QualityIssue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRateJumps", ctx, from, to, sigma)
+	ret0, _ := ret[0].([]entities.QualityIssue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRateJumps indicates an expected call of FindRateJumps.
+func (mr *MockStorageMockRecorder) FindRateJumps(ctx, from, to, sigma interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRateJumps", reflect.TypeOf((*MockStorage)(nil).FindRateJumps), ctx, from, to, sigma)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockStorage) GetAPIKeyByHash(ctx context.Context, hash string) (entities.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", ctx, hash)
+	ret0, _ := ret[0].(entities.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockStorageMockRecorder) GetAPIKeyByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockStorage)(nil).GetAPIKeyByHash), ctx, hash)
+}
+
+// GetAPIKeyUsage mocks base method.
+func (m *MockStorage) GetAPIKeyUsage(ctx context.Context, from, to time.Time) ([]entities.APIKeyUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyUsage", ctx, from, to)
+	ret0, _ := ret[0].([]entities.APIKeyUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyUsage indicates an expected call of GetAPIKeyUsage.
+func (mr *MockStorageMockRecorder) GetAPIKeyUsage(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyUsage", reflect.TypeOf((*MockStorage)(nil).GetAPIKeyUsage), ctx, from, to)
+}
+
 // GetActualCoins mocks base method.
 func (m *MockStorage) GetActualCoins(ctx context.Context, titles []string) ([]entities.Coin, error) {
 	m.ctrl.T.Helper()
@@ -51,18 +229,78 @@ func (mr *MockStorageMockRecorder) GetActualCoins(ctx, titles interface{}) *gomo
 }
 
 // GetAggregateCoins mocks base method.
-func (m *MockStorage) GetAggregateCoins(ctx context.Context, titles []string, aggType string) ([]entities.Coin, error) {
+func (m *MockStorage) GetAggregateCoins(ctx context.Context, titles []string, aggType string, from, to time.Time) ([]entities.Coin, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAggregateCoins", ctx, titles, aggType)
+	ret := m.ctrl.Call(m, "GetAggregateCoins", ctx, titles, aggType, from, to)
 	ret0, _ := ret[0].([]entities.Coin)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAggregateCoins indicates an expected call of GetAggregateCoins.
-func (mr *MockStorageMockRecorder) GetAggregateCoins(ctx, titles, aggType interface{}) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetAggregateCoins(ctx, titles, aggType, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateCoins", reflect.TypeOf((*MockStorage)(nil).GetAggregateCoins), ctx, titles, aggType, from, to)
+}
+
+// GetAggregateStats mocks base method.
+func (m *MockStorage) GetAggregateStats(ctx context.Context, titles, aggTypes []string, from, to time.Time) ([]entities.CoinStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregateStats", ctx, titles, aggTypes, from, to)
+	ret0, _ := ret[0].([]entities.CoinStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregateStats indicates an expected call of GetAggregateStats.
+func (mr *MockStorageMockRecorder) GetAggregateStats(ctx, titles, aggTypes, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateStats", reflect.TypeOf((*MockStorage)(nil).GetAggregateStats), ctx, titles, aggTypes, from, to)
+}
+
+// GetBackfillCursor mocks base method.
+func (m *MockStorage) GetBackfillCursor(ctx context.Context, job entities.BackfillJob) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBackfillCursor", ctx, job)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBackfillCursor indicates an expected call of GetBackfillCursor.
+func (mr *MockStorageMockRecorder) GetBackfillCursor(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBackfillCursor", reflect.TypeOf((*MockStorage)(nil).GetBackfillCursor), ctx, job)
+}
+
+// GetCoinHistory mocks base method.
+func (m *MockStorage) GetCoinHistory(ctx context.Context, title string, from, to time.Time, limit int) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoinHistory", ctx, title, from, to, limit)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoinHistory indicates an expected call of GetCoinHistory.
+func (mr *MockStorageMockRecorder) GetCoinHistory(ctx, title, from, to, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateCoins", reflect.TypeOf((*MockStorage)(nil).GetAggregateCoins), ctx, titles, aggType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinHistory", reflect.TypeOf((*MockStorage)(nil).GetCoinHistory), ctx, title, from, to, limit)
+}
+
+// GetCoinsAt mocks base method.
+func (m *MockStorage) GetCoinsAt(ctx context.Context, titles []string, at time.Time) ([]entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoinsAt", ctx, titles, at)
+	ret0, _ := ret[0].([]entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoinsAt indicates an expected call of GetCoinsAt.
+func (mr *MockStorageMockRecorder) GetCoinsAt(ctx, titles, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinsAt", reflect.TypeOf((*MockStorage)(nil).GetCoinsAt), ctx, titles, at)
 }
 
 // GetCoinsList mocks base method.
@@ -80,6 +318,166 @@ func (mr *MockStorageMockRecorder) GetCoinsList(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinsList", reflect.TypeOf((*MockStorage)(nil).GetCoinsList), ctx)
 }
 
+// GetPortfolio mocks base method.
+func (m *MockStorage) GetPortfolio(ctx context.Context, id int64) (entities.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortfolio", ctx, id)
+	ret0, _ := ret[0].(entities.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortfolio indicates an expected call of GetPortfolio.
+func (mr *MockStorageMockRecorder) GetPortfolio(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortfolio", reflect.TypeOf((*MockStorage)(nil).GetPortfolio), ctx, id)
+}
+
+// GetReferenceRates mocks base method.
+func (m *MockStorage) GetReferenceRates(ctx context.Context, titles []string, since time.Time) (map[string]float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferenceRates", ctx, titles, since)
+	ret0, _ := ret[0].(map[string]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferenceRates indicates an expected call of GetReferenceRates.
+func (mr *MockStorageMockRecorder) GetReferenceRates(ctx, titles, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferenceRates", reflect.TypeOf((*MockStorage)(nil).GetReferenceRates), ctx, titles, since)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockStorage) ListAPIKeys(ctx context.Context) ([]entities.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx)
+	ret0, _ := ret[0].([]entities.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockStorageMockRecorder) ListAPIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockStorage)(nil).ListAPIKeys), ctx)
+}
+
+// ListPortfolios mocks base method.
+func (m *MockStorage) ListPortfolios(ctx context.Context) ([]entities.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPortfolios", ctx)
+	ret0, _ := ret[0].([]entities.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPortfolios indicates an expected call of ListPortfolios.
+func (mr *MockStorageMockRecorder) ListPortfolios(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPortfolios", reflect.TypeOf((*MockStorage)(nil).ListPortfolios), ctx)
+}
+
+// ListQuarantinedRates mocks base method.
+func (m *MockStorage) ListQuarantinedRates(ctx context.Context, status entities.QuarantineStatus) ([]entities.QuarantinedRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQuarantinedRates", ctx, status)
+	ret0, _ := ret[0].([]entities.QuarantinedRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQuarantinedRates indicates an expected call of ListQuarantinedRates.
+func (mr *MockStorageMockRecorder) ListQuarantinedRates(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuarantinedRates", reflect.TypeOf((*MockStorage)(nil).ListQuarantinedRates), ctx, status)
+}
+
+// ListTrackedCoins mocks base method.
+func (m *MockStorage) ListTrackedCoins(ctx context.Context) ([]entities.TrackedCoin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrackedCoins", ctx)
+	ret0, _ := ret[0].([]entities.TrackedCoin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrackedCoins indicates an expected call of ListTrackedCoins.
+func (mr *MockStorageMockRecorder) ListTrackedCoins(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrackedCoins", reflect.TypeOf((*MockStorage)(nil).ListTrackedCoins), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockStorage) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockStorageMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockStorage)(nil).Ping), ctx)
+}
+
+// QuarantineRates mocks base method.
+func (m *MockStorage) QuarantineRates(ctx context.Context, rates []entities.QuarantinedRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuarantineRates", ctx, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QuarantineRates indicates an expected call of QuarantineRates.
+func (mr *MockStorageMockRecorder) QuarantineRates(ctx, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuarantineRates", reflect.TypeOf((*MockStorage)(nil).QuarantineRates), ctx, rates)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockStorage) RevokeAPIKey(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockStorageMockRecorder) RevokeAPIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockStorage)(nil).RevokeAPIKey), ctx, id)
+}
+
+// SaveBackfillCursor mocks base method.
+func (m *MockStorage) SaveBackfillCursor(ctx context.Context, job entities.BackfillJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBackfillCursor", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBackfillCursor indicates an expected call of SaveBackfillCursor.
+func (mr *MockStorageMockRecorder) SaveBackfillCursor(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBackfillCursor", reflect.TypeOf((*MockStorage)(nil).SaveBackfillCursor), ctx, job)
+}
+
+// SetCoinTracked mocks base method.
+func (m *MockStorage) SetCoinTracked(ctx context.Context, title string, tracked bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCoinTracked", ctx, title, tracked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCoinTracked indicates an expected call of SetCoinTracked.
+func (mr *MockStorageMockRecorder) SetCoinTracked(ctx, title, tracked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCoinTracked", reflect.TypeOf((*MockStorage)(nil).SetCoinTracked), ctx, title, tracked)
+}
+
 // Store mocks base method.
 func (m *MockStorage) Store(ctx context.Context, coins []entities.Coin) error {
 	m.ctrl.T.Helper()
@@ -93,3 +491,33 @@ func (mr *MockStorageMockRecorder) Store(ctx, coins interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockStorage)(nil).Store), ctx, coins)
 }
+
+// StoreHistory mocks base method.
+func (m *MockStorage) StoreHistory(ctx context.Context, coins []entities.Coin, bucket time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreHistory", ctx, coins, bucket)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreHistory indicates an expected call of StoreHistory.
+func (mr *MockStorageMockRecorder) StoreHistory(ctx, coins, bucket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreHistory", reflect.TypeOf((*MockStorage)(nil).StoreHistory), ctx, coins, bucket)
+}
+
+// UpdatePortfolio mocks base method.
+func (m *MockStorage) UpdatePortfolio(ctx context.Context, portfolio entities.Portfolio) (entities.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePortfolio", ctx, portfolio)
+	ret0, _ := ret[0].(entities.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePortfolio indicates an expected call of UpdatePortfolio.
+func (mr *MockStorageMockRecorder) UpdatePortfolio(ctx, portfolio interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePortfolio", reflect.TypeOf((*MockStorage)(nil).UpdatePortfolio), ctx, portfolio)
+}
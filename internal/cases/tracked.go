@@ -0,0 +1,53 @@
+package cases
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+// TrackCoins makes UpdateRates fetch the given coins from now on. The coins are checked against the
+// provider and their current rates are stored right away.
+func (s *Service) TrackCoins(ctx context.Context, titles []string) error {
+	if err := s.ValidateAndFetchTitles(ctx, titles); err != nil {
+		return err
+	}
+	return s.setCoinsTracked(ctx, titles, true)
+}
+
+// UntrackCoins stops updating the given coins, their stored rates are kept.
+func (s *Service) UntrackCoins(ctx context.Context, titles []string) error {
+	if len(titles) == 0 {
+		s.logger.ErrorContext(ctx, "Empty titles list provided")
+		return errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty")
+	}
+	return s.setCoinsTracked(ctx, titles, false)
+}
+
+func (s *Service) setCoinsTracked(ctx context.Context, titles []string, tracked bool) error {
+	for _, title := range titles {
+		if err := s.storage.SetCoinTracked(ctx, title, tracked); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to update tracked coin", "title", title, "tracked", tracked, "err", err)
+			return errors.Wrap(entities.ErrInternal, "failed to update tracked coin")
+		}
+	}
+
+	s.logger.InfoContext(ctx, "Tracked coins updated", "titles", titles, "tracked", tracked)
+	return nil
+}
+
+func (s *Service) ListTrackedCoins(ctx context.Context) ([]*entities.TrackedCoin, error) {
+	coins, err := s.storage.ListTrackedCoins(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list tracked coins", "err", err)
+		return nil, errors.Wrap(entities.ErrInternal, "failed to list tracked coins")
+	}
+
+	result := make([]*entities.TrackedCoin, len(coins))
+	for i := range coins {
+		result[i] = &coins[i]
+	}
+	return result, nil
+}
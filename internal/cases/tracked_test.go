@@ -0,0 +1,71 @@
+package cases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func TestService_TrackCoins(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, mockProvider := setupService(t)
+
+	rates := []entities.Coin{{Title: "SOL", Cost: 150}}
+	gomock.InOrder(
+		mockProvider.EXPECT().GetActualRates(gomock.Any(), []string{"SOL"}).Return(rates, nil),
+		mockStorage.EXPECT().Store(gomock.Any(), rates).Return(nil),
+		mockStorage.EXPECT().SetCoinTracked(gomock.Any(), "SOL", true).Return(nil),
+	)
+
+	require.NoError(t, service.TrackCoins(context.Background(), []string{"SOL"}))
+}
+
+func TestService_TrackCoins_UnknownCoin(t *testing.T) {
+	t.Parallel()
+
+	service, _, mockProvider := setupService(t)
+
+	mockProvider.EXPECT().GetActualRates(gomock.Any(), []string{"NOPE"}).Return([]entities.Coin{}, nil)
+
+	err := service.TrackCoins(context.Background(), []string{"NOPE"})
+	require.ErrorIs(t, err, entities.ErrNotFound)
+}
+
+func TestService_UntrackCoins(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	mockStorage.EXPECT().SetCoinTracked(gomock.Any(), "BTC", false).Return(nil)
+	mockStorage.EXPECT().SetCoinTracked(gomock.Any(), "ETH", false).Return(entities.ErrInternal)
+
+	err := service.UntrackCoins(context.Background(), []string{"BTC", "ETH"})
+	require.ErrorIs(t, err, entities.ErrInternal)
+
+	err = service.UntrackCoins(context.Background(), nil)
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestService_ListTrackedCoins(t *testing.T) {
+	t.Parallel()
+
+	service, mockStorage, _ := setupService(t)
+
+	updatedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockStorage.EXPECT().ListTrackedCoins(gomock.Any()).Return([]entities.TrackedCoin{
+		{Title: "BTC", Tracked: true, UpdatedAt: updatedAt},
+		{Title: "DOGE", Tracked: false, UpdatedAt: updatedAt},
+	}, nil)
+
+	coins, err := service.ListTrackedCoins(context.Background())
+	require.NoError(t, err)
+	require.Len(t, coins, 2)
+	require.Equal(t, "BTC", coins[0].Title)
+	require.False(t, coins[1].Tracked)
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+func (c *CLI) backfill(ctx context.Context, args []string) error {
+	flags := newFlagSet("backfill")
+	window := addWindowFlags(flags)
+	rawResolution := flags.String("resolution", string(entities.ResolutionHour), "spacing of the filled rates, hour or day")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.Wrap(entities.ErrInvalidParam, "backfill expects one title")
+	}
+	from, to, err := window()
+	if err != nil {
+		return err
+	}
+	if from.IsZero() {
+		return errors.Wrap(entities.ErrInvalidParam, "backfill expects --from")
+	}
+	resolution, err := entities.ParseResolution(*rawResolution)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.service.Backfill(ctx, strings.ToUpper(flags.Arg(0)), resolution, from, to)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "Backfilled %s from %s to %s by %s: %d fetched, %d inserted, %d buckets already stored\n",
+		result.Title, result.From.Format(time.RFC3339), result.To.Format(time.RFC3339), result.Resolution,
+		result.Fetched, result.Inserted, result.Skipped)
+	return nil
+}
@@ -0,0 +1,45 @@
+package cli_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func TestCLI_Backfill(t *testing.T) {
+	t.Parallel()
+
+	c, service, _, out := setupCLI(t)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(30 * 24 * time.Hour)
+	service.EXPECT().Backfill(gomock.Any(), "BTC", entities.ResolutionDay, from, to).Return(&entities.BackfillResult{
+		Title: "BTC", Resolution: entities.ResolutionDay, From: from, To: to, Fetched: 30, Inserted: 28, Skipped: 2,
+	}, nil)
+
+	err := c.Run(context.Background(), []string{"backfill", "btc", "--resolution", "day",
+		"--from", "2024-01-01T00:00:00Z", "--to", "2024-01-31T00:00:00Z"})
+
+	require.NoError(t, err)
+	require.Equal(t, "Backfilled BTC from 2024-01-01T00:00:00Z to 2024-01-31T00:00:00Z by day: "+
+		"30 fetched, 28 inserted, 2 buckets already stored\n", out.String())
+}
+
+func TestCLI_Backfill_InvalidUsage(t *testing.T) {
+	t.Parallel()
+
+	c, _, _, _ := setupCLI(t)
+
+	for _, args := range [][]string{
+		{"backfill", "--from", "2024-01-01T00:00:00Z"},
+		{"backfill", "BTC"},
+		{"backfill", "BTC", "--from", "2024-01-01T00:00:00Z", "--resolution", "minute"},
+	} {
+		require.ErrorIs(t, c.Run(context.Background(), args), entities.ErrInvalidParam, args)
+	}
+}
@@ -0,0 +1,294 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"Cryptoproject/internal/entities"
+)
+
+const usage = `Usage: cryptoctl [--config FILE] <command> [arguments]
+
+Commands:
+  migrate up                      apply pending database migrations
+  migrate down [--steps N]        roll back the last N migrations, 1 by default
+  migrate rebucket                re-bin stored rates after a min-sample-spacing change, keeping the newest per bucket
+  update                          fetch and store the current rates of all tracked coins once
+  coins list                      list coins added or removed by an operator
+  coins add TITLE...              track coins, their current rates are stored right away
+  coins remove TITLE...           stop updating coins, their stored rates are kept
+  rates last TITLE...             print the latest stored rates
+  rates history TITLE [flags]     print stored rates of a coin, newest first
+  export [flags]                  write stored rates as CSV or JSON
+  backfill TITLE --from T [flags] fill gaps in stored rates from the provider history
+`
+
+// exportPageSize is the largest history page the service hands out.
+const exportPageSize = 10000
+
+//go:generate mockgen -source=cli.go -destination=./testdata/cli.go -package=testdata
+type Service interface {
+	UpdateRates(ctx context.Context) error
+	TrackCoins(ctx context.Context, titles []string) error
+	UntrackCoins(ctx context.Context, titles []string) error
+	ListTrackedCoins(ctx context.Context) ([]*entities.TrackedCoin, error)
+	GetStoredRates(ctx context.Context, titles []string) ([]*entities.Coin, error)
+	GetCoinHistory(ctx context.Context, title string, from, to time.Time, limit int) ([]*entities.Coin, error)
+	Backfill(ctx context.Context, title string, resolution entities.Resolution, from, to time.Time) (*entities.BackfillResult, error)
+}
+
+type Migrator interface {
+	Migrate(ctx context.Context) ([]string, error)
+	Rollback(ctx context.Context, steps int) ([]string, error)
+	Rebucket(ctx context.Context) (int64, error)
+}
+
+// CLI runs the administrative commands of cryptoctl against the service and the database.
+type CLI struct {
+	service  Service
+	migrator Migrator
+	out      io.Writer
+}
+
+type CLIOption func(*CLI)
+
+func WithOutput(out io.Writer) CLIOption {
+	return func(c *CLI) {
+		c.out = out
+	}
+}
+
+func NewCLI(service Service, migrator Migrator, opts ...CLIOption) (*CLI, error) {
+	if service == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "service not provided")
+	}
+	if migrator == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "migrator not provided")
+	}
+
+	c := &CLI{
+		service:  service,
+		migrator: migrator,
+		out:      os.Stdout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.out == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "output cannot be nil")
+	}
+	return c, nil
+}
+
+// Usage describes the commands accepted by Run.
+func Usage() string {
+	return usage
+}
+
+// Run executes the command given by args, such as ["coins", "add", "BTC"]. Wrong usage is reported
+// as entities.ErrInvalidParam.
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "command not provided")
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "migrate":
+		return c.migrate(ctx, args)
+	case "update":
+		return c.update(ctx, args)
+	case "coins":
+		return c.coins(ctx, args)
+	case "rates":
+		return c.rates(ctx, args)
+	case "export":
+		return c.export(ctx, args)
+	case "backfill":
+		return c.backfill(ctx, args)
+	default:
+		return errors.Wrapf(entities.ErrInvalidParam, "unknown command %q", command)
+	}
+}
+
+func (c *CLI) migrate(ctx context.Context, args []string) error {
+	flags := newFlagSet("migrate")
+	steps := flags.Int("steps", 1, "number of migrations to roll back")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var names []string
+	var err error
+	switch flags.Arg(0) {
+	case "up":
+		names, err = c.migrator.Migrate(ctx)
+	case "down":
+		names, err = c.migrator.Rollback(ctx, *steps)
+	case "rebucket":
+		removed, err := c.migrator.Rebucket(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "Rates rebucketed, %d removed\n", removed)
+		return nil
+	default:
+		return errors.Wrap(entities.ErrInvalidParam, "migrate expects up, down or rebucket")
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(names) == 0 {
+		fmt.Fprintln(c.out, "No migrations to run")
+		return nil
+	}
+	for _, name := range names {
+		fmt.Fprintf(c.out, "%s %s\n", flags.Arg(0), name)
+	}
+	return nil
+}
+
+func (c *CLI) update(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "update takes no arguments")
+	}
+	if err := c.service.UpdateRates(ctx); err != nil {
+		return err
+	}
+	fmt.Fprintln(c.out, "Rates updated")
+	return nil
+}
+
+func (c *CLI) coins(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "coins expects list, add or remove")
+	}
+
+	titles := normalizeTitles(args[1:])
+	switch args[0] {
+	case "list":
+		coins, err := c.service.ListTrackedCoins(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TITLE\tTRACKED\tUPDATED AT")
+		for _, coin := range coins {
+			fmt.Fprintf(w, "%s\t%t\t%s\n", coin.Title, coin.Tracked, coin.UpdatedAt.UTC().Format(time.RFC3339))
+		}
+		return w.Flush()
+	case "add":
+		if len(titles) == 0 {
+			return errors.Wrap(entities.ErrInvalidParam, "coins add expects at least one title")
+		}
+		if err := c.service.TrackCoins(ctx, titles); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "Tracking %s\n", strings.Join(titles, ", "))
+		return nil
+	case "remove":
+		if len(titles) == 0 {
+			return errors.Wrap(entities.ErrInvalidParam, "coins remove expects at least one title")
+		}
+		if err := c.service.UntrackCoins(ctx, titles); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "No longer tracking %s\n", strings.Join(titles, ", "))
+		return nil
+	default:
+		return errors.Wrapf(entities.ErrInvalidParam, "unknown coins command %q", args[0])
+	}
+}
+
+func (c *CLI) rates(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "rates expects last or history")
+	}
+
+	var coins []*entities.Coin
+	switch args[0] {
+	case "last":
+		titles := normalizeTitles(args[1:])
+		if len(titles) == 0 {
+			return errors.Wrap(entities.ErrInvalidParam, "rates last expects at least one title")
+		}
+		var err error
+		if coins, err = c.service.GetStoredRates(ctx, titles); err != nil {
+			return err
+		}
+	case "history":
+		flags := newFlagSet("rates history")
+		window := addWindowFlags(flags)
+		limit := flags.Int("limit", 100, "maximum number of rates")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			return errors.Wrap(entities.ErrInvalidParam, "rates history expects one title")
+		}
+		from, to, err := window()
+		if err != nil {
+			return err
+		}
+		if coins, err = c.service.GetCoinHistory(ctx, strings.ToUpper(flags.Arg(0)), from, to, *limit); err != nil {
+			return err
+		}
+	default:
+		return errors.Wrapf(entities.ErrInvalidParam, "unknown rates command %q", args[0])
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TITLE\tCOST\tACTUAL AT")
+	for _, coin := range coins {
+		fmt.Fprintf(w, "%s\t%g\t%s\n", coin.Title, coin.Cost, coin.ActualAt.UTC().Format(time.RFC3339))
+	}
+	return w.Flush()
+}
+
+func newFlagSet(name string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// addWindowFlags registers --from and --to and returns a function parsing them once flags are parsed.
+func addWindowFlags(flags *pflag.FlagSet) func() (time.Time, time.Time, error) {
+	rawFrom := flags.String("from", "", "RFC 3339 start of the window, the beginning of history by default")
+	rawTo := flags.String("to", "", "RFC 3339 end of the window, now by default")
+	return func() (from, to time.Time, err error) {
+		if *rawFrom != "" {
+			if from, err = time.Parse(time.RFC3339, *rawFrom); err != nil {
+				return from, to, errors.Wrapf(entities.ErrInvalidParam, "invalid --from: %v", err)
+			}
+		}
+		if *rawTo != "" {
+			if to, err = time.Parse(time.RFC3339, *rawTo); err != nil {
+				return from, to, errors.Wrapf(entities.ErrInvalidParam, "invalid --to: %v", err)
+			}
+		}
+		return from, to, nil
+	}
+}
+
+// normalizeTitles splits comma separated titles and upper-cases them.
+func normalizeTitles(args []string) []string {
+	var titles []string
+	for _, arg := range args {
+		for _, title := range strings.Split(arg, ",") {
+			if title = strings.ToUpper(strings.TrimSpace(title)); title != "" {
+				titles = append(titles, title)
+			}
+		}
+	}
+	return titles
+}
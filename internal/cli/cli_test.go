@@ -0,0 +1,181 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/cli"
+	mocks "Cryptoproject/internal/cli/testdata"
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+func setupCLI(t *testing.T) (*cli.CLI, *mocks.MockService, *mocks.MockMigrator, *bytes.Buffer) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	service := mocks.NewMockService(ctrl)
+	migrator := mocks.NewMockMigrator(ctrl)
+	var out bytes.Buffer
+
+	c, err := cli.NewCLI(service, migrator, cli.WithOutput(&out))
+	require.NoError(t, err)
+	return c, service, migrator, &out
+}
+
+func TestCLI_Migrate(t *testing.T) {
+	t.Parallel()
+
+	c, _, migrator, out := setupCLI(t)
+
+	migrator.EXPECT().Migrate(gomock.Any()).Return([]string{"create_coins_table", "create_tracked_coins_table"}, nil)
+	require.NoError(t, c.Run(context.Background(), []string{"migrate", "up"}))
+	require.Equal(t, "up create_coins_table\nup create_tracked_coins_table\n", out.String())
+
+	out.Reset()
+	migrator.EXPECT().Rollback(gomock.Any(), 2).Return([]string{"create_tracked_coins_table", "create_api_keys_table"}, nil)
+	require.NoError(t, c.Run(context.Background(), []string{"migrate", "down", "--steps", "2"}))
+	require.Contains(t, out.String(), "down create_api_keys_table")
+
+	out.Reset()
+	migrator.EXPECT().Migrate(gomock.Any()).Return(nil, nil)
+	require.NoError(t, c.Run(context.Background(), []string{"migrate", "up"}))
+	require.Equal(t, "No migrations to run\n", out.String())
+
+	out.Reset()
+	migrator.EXPECT().Rebucket(gomock.Any()).Return(int64(3), nil)
+	require.NoError(t, c.Run(context.Background(), []string{"migrate", "rebucket"}))
+	require.Equal(t, "Rates rebucketed, 3 removed\n", out.String())
+
+	require.ErrorIs(t, c.Run(context.Background(), []string{"migrate", "sideways"}), entities.ErrInvalidParam)
+}
+
+func TestCLI_Update(t *testing.T) {
+	t.Parallel()
+
+	c, service, _, out := setupCLI(t)
+
+	service.EXPECT().UpdateRates(gomock.Any()).Return(nil)
+	require.NoError(t, c.Run(context.Background(), []string{"update"}))
+	require.Equal(t, "Rates updated\n", out.String())
+
+	service.EXPECT().UpdateRates(gomock.Any()).Return(entities.ErrUnavailable)
+	require.ErrorIs(t, c.Run(context.Background(), []string{"update"}), entities.ErrUnavailable)
+}
+
+func TestCLI_Coins(t *testing.T) {
+	t.Parallel()
+
+	c, service, _, out := setupCLI(t)
+
+	service.EXPECT().TrackCoins(gomock.Any(), []string{"BTC", "ETH", "SOL"}).Return(nil)
+	require.NoError(t, c.Run(context.Background(), []string{"coins", "add", "btc,eth", "sol"}))
+	require.Equal(t, "Tracking BTC, ETH, SOL\n", out.String())
+
+	service.EXPECT().UntrackCoins(gomock.Any(), []string{"DOGE"}).Return(nil)
+	require.NoError(t, c.Run(context.Background(), []string{"coins", "remove", "DOGE"}))
+
+	out.Reset()
+	updatedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.EXPECT().ListTrackedCoins(gomock.Any()).Return([]*entities.TrackedCoin{
+		{Title: "BTC", Tracked: true, UpdatedAt: updatedAt},
+		{Title: "DOGE", Tracked: false, UpdatedAt: updatedAt},
+	}, nil)
+	require.NoError(t, c.Run(context.Background(), []string{"coins", "list"}))
+	require.Equal(t, ""+
+		"TITLE  TRACKED  UPDATED AT\n"+
+		"BTC    true     2026-03-01T12:00:00Z\n"+
+		"DOGE   false    2026-03-01T12:00:00Z\n", out.String())
+
+	require.ErrorIs(t, c.Run(context.Background(), []string{"coins", "add"}), entities.ErrInvalidParam)
+}
+
+func TestCLI_Rates(t *testing.T) {
+	t.Parallel()
+
+	c, service, _, out := setupCLI(t)
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC"}).Return([]*entities.Coin{{Title: "BTC", Cost: 50000.5, ActualAt: at}}, nil)
+	require.NoError(t, c.Run(context.Background(), []string{"rates", "last", "BTC"}))
+	require.Contains(t, out.String(), "BTC    50000.5  2026-03-01T12:00:00Z")
+
+	from := at.Add(-time.Hour)
+	service.EXPECT().GetCoinHistory(gomock.Any(), "ETH", from, time.Time{}, 5).Return([]*entities.Coin{{Title: "ETH", Cost: 3000, ActualAt: at}}, nil)
+	require.NoError(t, c.Run(context.Background(), []string{"rates", "history", "eth", "--from", from.Format(time.RFC3339), "--limit", "5"}))
+
+	err := c.Run(context.Background(), []string{"rates", "history", "ETH", "--from", "yesterday"})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestCLI_ExportPagesThroughHistory(t *testing.T) {
+	t.Parallel()
+
+	c, service, _, out := setupCLI(t)
+
+	newest := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	firstPage := make([]*entities.Coin, 10000)
+	for i := range firstPage {
+		firstPage[i] = &entities.Coin{Title: "BTC", Cost: 50000, ActualAt: newest.Add(-time.Duration(i) * time.Minute)}
+	}
+	oldest := firstPage[len(firstPage)-1].ActualAt
+	secondPage := []*entities.Coin{{Title: "BTC", Cost: 40000, ActualAt: oldest.Add(-time.Minute)}}
+
+	gomock.InOrder(
+		service.EXPECT().GetCoinHistory(gomock.Any(), "BTC", time.Time{}, time.Time{}, 10000).Return(firstPage, nil),
+		service.EXPECT().GetCoinHistory(gomock.Any(), "BTC", time.Time{}, oldest.Add(-time.Microsecond), 10000).Return(secondPage, nil),
+	)
+
+	require.NoError(t, c.Run(context.Background(), []string{"export", "--titles", "BTC", "--format", "json"}))
+
+	var exported []dto.HistoricalCoinDTO
+	require.NoError(t, json.Unmarshal(out.Bytes(), &exported))
+	require.Len(t, exported, 10001)
+	require.Equal(t, float64(40000), exported[0].Cost, "export is chronological")
+	require.Equal(t, newest, exported[len(exported)-1].ActualAt)
+}
+
+func TestCLI_ExportCSVToFile(t *testing.T) {
+	t.Parallel()
+
+	c, service, _, _ := setupCLI(t)
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.EXPECT().GetCoinHistory(gomock.Any(), "BTC", time.Time{}, time.Time{}, 10000).
+		Return([]*entities.Coin{{Title: "BTC", Cost: 50000.25, ActualAt: at}}, nil)
+	service.EXPECT().GetCoinHistory(gomock.Any(), "ETH", time.Time{}, time.Time{}, 10000).
+		Return(nil, entities.ErrNotFound)
+
+	path := filepath.Join(t.TempDir(), "rates.csv")
+	require.NoError(t, c.Run(context.Background(), []string{"export", "--titles", "BTC,ETH", "--output", path}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "title,cost,actual_at\nBTC,50000.25,2026-03-01T12:00:00Z\n", string(content))
+}
+
+func TestCLI_InvalidUsage(t *testing.T) {
+	t.Parallel()
+
+	c, _, _, _ := setupCLI(t)
+
+	for _, args := range [][]string{
+		nil,
+		{"unknown"},
+		{"update", "now"},
+		{"coins", "rename"},
+		{"rates"},
+		{"export"},
+		{"export", "--titles", "BTC", "--format", "xml"},
+	} {
+		require.ErrorIs(t, c.Run(context.Background(), args), entities.ErrInvalidParam, args)
+	}
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// export writes the stored rates of the given coins in chronological order. History is read in pages
+// from the newest rate backwards.
+func (c *CLI) export(ctx context.Context, args []string) error {
+	flags := newFlagSet("export")
+	rawTitles := flags.StringSlice("titles", nil, "comma separated coins to export")
+	format := flags.String("format", "csv", "csv or json")
+	output := flags.String("output", "", "file to write, standard output by default")
+	window := addWindowFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	from, to, err := window()
+	if err != nil {
+		return err
+	}
+	if *format != "csv" && *format != "json" {
+		return errors.Wrapf(entities.ErrInvalidParam, "unknown export format %q", *format)
+	}
+
+	titles := normalizeTitles(*rawTitles)
+	if len(titles) == 0 {
+		return errors.Wrap(entities.ErrInvalidParam, "export expects --titles")
+	}
+
+	var rates []*entities.Coin
+	for _, title := range titles {
+		history, err := c.history(ctx, title, from, to)
+		if err != nil {
+			return err
+		}
+		rates = append(rates, history...)
+	}
+
+	write := writeCSV
+	if *format == "json" {
+		write = writeJSON
+	}
+	if *output == "" {
+		return write(c.out, rates)
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return errors.Wrapf(entities.ErrInvalidParam, "failed to create %s: %v", *output, err)
+	}
+	if err := write(file, rates); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (c *CLI) history(ctx context.Context, title string, from, to time.Time) ([]*entities.Coin, error) {
+	var result []*entities.Coin
+	for {
+		page, err := c.service.GetCoinHistory(ctx, title, from, to, exportPageSize)
+		if errors.Is(err, entities.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page...)
+
+		oldest := page[len(page)-1].ActualAt
+		if len(page) < exportPageSize || (!from.IsZero() && !oldest.After(from)) {
+			break
+		}
+		to = oldest.Add(-time.Microsecond)
+	}
+
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+func writeCSV(out io.Writer, rates []*entities.Coin) error {
+	w := csv.NewWriter(out)
+	_ = w.Write([]string{"title", "cost", "actual_at"})
+	for _, coin := range rates {
+		_ = w.Write([]string{coin.Title, strconv.FormatFloat(coin.Cost, 'f', -1, 64), coin.ActualAt.UTC().Format(time.RFC3339Nano)})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return errors.Wrapf(entities.ErrInternal, "failed to write CSV: %v", err)
+	}
+	return nil
+}
+
+func writeJSON(out io.Writer, rates []*entities.Coin) error {
+	dtos := make([]dto.HistoricalCoinDTO, len(rates))
+	for i, coin := range rates {
+		dtos[i] = dto.HistoricalCoinDTO{
+			Title:    coin.Title,
+			Cost:     coin.Cost,
+			ActualAt: coin.ActualAt,
+		}
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(dtos); err != nil {
+		return errors.Wrapf(entities.ErrInternal, "failed to write JSON: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cli.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	entities "Cryptoproject/internal/entities"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Backfill mocks base method.
+func (m *MockService) Backfill(ctx context.Context, title string, resolution entities.Resolution, from, to time.Time) (*entities.BackfillResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backfill", ctx, title, resolution, from, to)
+	ret0, _ := ret[0].(*entities.BackfillResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backfill indicates an expected call of Backfill.
+func (mr *MockServiceMockRecorder) Backfill(ctx, title, resolution, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backfill", reflect.TypeOf((*MockService)(nil).Backfill), ctx, title, resolution, from, to)
+}
+
+// GetCoinHistory mocks base method.
+func (m *MockService) GetCoinHistory(ctx context.Context, title string, from, to time.Time, limit int) ([]*entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoinHistory", ctx, title, from, to, limit)
+	ret0, _ := ret[0].([]*entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoinHistory indicates an expected call of GetCoinHistory.
+func (mr *MockServiceMockRecorder) GetCoinHistory(ctx, title, from, to, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinHistory", reflect.TypeOf((*MockService)(nil).GetCoinHistory), ctx, title, from, to, limit)
+}
+
+// GetStoredRates mocks base method.
+func (m *MockService) GetStoredRates(ctx context.Context, titles []string) ([]*entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStoredRates", ctx, titles)
+	ret0, _ := ret[0].([]*entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStoredRates indicates an expected call of GetStoredRates.
+func (mr *MockServiceMockRecorder) GetStoredRates(ctx, titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoredRates", reflect.TypeOf((*MockService)(nil).GetStoredRates), ctx, titles)
+}
+
+// ListTrackedCoins mocks base method.
+func (m *MockService) ListTrackedCoins(ctx context.Context) ([]*entities.TrackedCoin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrackedCoins", ctx)
+	ret0, _ := ret[0].([]*entities.TrackedCoin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrackedCoins indicates an expected call of ListTrackedCoins.
+func (mr *MockServiceMockRecorder) ListTrackedCoins(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrackedCoins", reflect.TypeOf((*MockService)(nil).ListTrackedCoins), ctx)
+}
+
+// TrackCoins mocks base method.
+func (m *MockService) TrackCoins(ctx context.Context, titles []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackCoins", ctx, titles)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackCoins indicates an expected call of TrackCoins.
+func (mr *MockServiceMockRecorder) TrackCoins(ctx, titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackCoins", reflect.TypeOf((*MockService)(nil).TrackCoins), ctx, titles)
+}
+
+// UntrackCoins mocks base method.
+func (m *MockService) UntrackCoins(ctx context.Context, titles []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntrackCoins", ctx, titles)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UntrackCoins indicates an expected call of UntrackCoins.
+func (mr *MockServiceMockRecorder) UntrackCoins(ctx, titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntrackCoins", reflect.TypeOf((*MockService)(nil).UntrackCoins), ctx, titles)
+}
+
+// UpdateRates mocks base method.
+func (m *MockService) UpdateRates(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRates", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRates indicates an expected call of UpdateRates.
+func (mr *MockServiceMockRecorder) UpdateRates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRates", reflect.TypeOf((*MockService)(nil).UpdateRates), ctx)
+}
+
+// MockMigrator is a mock of Migrator interface.
+type MockMigrator struct {
+	ctrl     *gomock.Controller
+	recorder *MockMigratorMockRecorder
+}
+
+// MockMigratorMockRecorder is the mock recorder for MockMigrator.
+type MockMigratorMockRecorder struct {
+	mock *MockMigrator
+}
+
+// NewMockMigrator creates a new mock instance.
+func NewMockMigrator(ctrl *gomock.Controller) *MockMigrator {
+	mock := &MockMigrator{ctrl: ctrl}
+	mock.recorder = &MockMigratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMigrator) EXPECT() *MockMigratorMockRecorder {
+	return m.recorder
+}
+
+// Migrate mocks base method.
+func (m *MockMigrator) Migrate(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migrate", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Migrate indicates an expected call of Migrate.
+func (mr *MockMigratorMockRecorder) Migrate(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockMigrator)(nil).Migrate), ctx)
+}
+
+// Rebucket mocks base method.
+func (m *MockMigrator) Rebucket(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebucket", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rebucket indicates an expected call of Rebucket.
+func (mr *MockMigratorMockRecorder) Rebucket(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebucket", reflect.TypeOf((*MockMigrator)(nil).Rebucket), ctx)
+}
+
+// Rollback mocks base method.
+func (m *MockMigrator) Rollback(ctx context.Context, steps int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", ctx, steps)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockMigratorMockRecorder) Rollback(ctx, steps interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockMigrator)(nil).Rollback), ctx, steps)
+}
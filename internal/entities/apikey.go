@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type APIKey struct {
+	ID         int64
+	Name       string
+	Prefix     string
+	Hash       string
+	RateLimit  float64
+	Burst      int
+	DailyQuota int64
+	CreatedAt  time.Time
+	RevokedAt  time.Time
+}
+
+type APIKeyUsage struct {
+	KeyID    int64
+	Day      time.Time
+	Requests int64
+}
+
+// NewAPIKey validates key limits. RateLimit is in requests per second, a zero DailyQuota means unlimited.
+func NewAPIKey(name string, rateLimit float64, burst int, dailyQuota int64) (*APIKey, error) {
+	if name == "" {
+		return nil, errors.Wrap(ErrInvalidParam, "API key name cannot be empty")
+	}
+	if rateLimit <= 0 {
+		return nil, errors.Wrap(ErrInvalidParam, "rate limit must be greater than zero")
+	}
+	if burst <= 0 {
+		return nil, errors.Wrap(ErrInvalidParam, "burst must be greater than zero")
+	}
+	if dailyQuota < 0 {
+		return nil, errors.Wrap(ErrInvalidParam, "daily quota cannot be negative")
+	}
+
+	return &APIKey{
+		Name:       name,
+		RateLimit:  rateLimit,
+		Burst:      burst,
+		DailyQuota: dailyQuota,
+	}, nil
+}
+
+func (k APIKey) Revoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+// HashAPIKey returns the hex encoded SHA-256 digest under which a key is stored.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,45 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_NewAPIKey_Success(t *testing.T) {
+	t.Parallel()
+	key, err := entities.NewAPIKey("billing", 5, 10, 1000)
+	require.NoError(t, err)
+	require.Equal(t, &entities.APIKey{
+		Name:       "billing",
+		RateLimit:  5,
+		Burst:      10,
+		DailyQuota: 1000,
+	}, key)
+	require.False(t, key.Revoked())
+}
+func Test_NewAPIKey_InvalidParams(t *testing.T) {
+	t.Parallel()
+	for name, tc := range map[string]struct {
+		name       string
+		rateLimit  float64
+		burst      int
+		dailyQuota int64
+	}{
+		"empty name":     {"", 5, 10, 0},
+		"zero rate":      {"billing", 0, 10, 0},
+		"zero burst":     {"billing", 5, 0, 0},
+		"negative quota": {"billing", 5, 10, -1},
+	} {
+		key, err := entities.NewAPIKey(tc.name, tc.rateLimit, tc.burst, tc.dailyQuota)
+		require.ErrorIs(t, err, entities.ErrInvalidParam, name)
+		require.Nil(t, key, name)
+	}
+}
+func Test_HashAPIKey(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", entities.HashAPIKey("hello"))
+	require.NotEqual(t, entities.HashAPIKey("hello"), entities.HashAPIKey("hello2"))
+}
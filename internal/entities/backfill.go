@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Resolution is the spacing of historical rates fetched from a provider, one rate per bucket.
+type Resolution string
+
+const (
+	ResolutionHour Resolution = "hour"
+	ResolutionDay  Resolution = "day"
+)
+
+func ParseResolution(name string) (Resolution, error) {
+	switch resolution := Resolution(name); resolution {
+	case ResolutionHour, ResolutionDay:
+		return resolution, nil
+	default:
+		return "", errors.Wrapf(ErrInvalidParam, "invalid resolution %q, must be one of hour, day", name)
+	}
+}
+
+// Bucket returns the length of one bucket, zero for an unknown resolution.
+func (r Resolution) Bucket() time.Duration {
+	switch r {
+	case ResolutionHour:
+		return time.Hour
+	case ResolutionDay:
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// BackfillJob identifies a backfill of one coin over a bucket aligned range. Cursor is the start of the
+// first bucket not yet processed, the zero time if the job never ran.
+type BackfillJob struct {
+	Title      string
+	Resolution Resolution
+	From       time.Time
+	To         time.Time
+	Cursor     time.Time
+}
+
+// BackfillResult summarizes a backfill run. Skipped counts the buckets that already held a stored rate,
+// only rates falling into empty buckets are inserted.
+type BackfillResult struct {
+	Title      string
+	Resolution Resolution
+	From       time.Time
+	To         time.Time
+	Fetched    int
+	Inserted   int
+	Skipped    int
+}
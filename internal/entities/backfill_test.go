@@ -0,0 +1,27 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func TestParseResolution(t *testing.T) {
+	t.Parallel()
+
+	resolution, err := entities.ParseResolution("hour")
+	require.NoError(t, err)
+	require.Equal(t, entities.ResolutionHour, resolution)
+	require.Equal(t, time.Hour, resolution.Bucket())
+
+	resolution, err = entities.ParseResolution("day")
+	require.NoError(t, err)
+	require.Equal(t, 24*time.Hour, resolution.Bucket())
+
+	_, err = entities.ParseResolution("minute")
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.Zero(t, entities.Resolution("minute").Bucket())
+}
@@ -1,12 +1,17 @@
 package entities
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 )
 
 type Coin struct {
-	Title string
-	Cost  float64
+	Title    string
+	Cost     float64
+	ActualAt time.Time
+	// Source names the provider the rate came from.
+	Source string
 }
 
 func NewCoin(title string, cost float64) (*Coin, error) {
@@ -22,3 +27,11 @@ func NewCoin(title string, cost float64) (*Coin, error) {
 		Cost:  cost,
 	}, nil
 }
+
+// TrackedCoin records an operator decision about a coin: tracked coins are updated even before any rate
+// of them is stored, untracked coins are no longer updated although their stored rates remain.
+type TrackedCoin struct {
+	Title     string
+	Tracked   bool
+	UpdatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package entities
+
+import (
+	"time"
+)
+
+type Conversion struct {
+	From   Coin
+	To     Coin
+	Amount float64
+	Rate   float64
+	Result float64
+	At     time.Time
+}
@@ -6,4 +6,10 @@ var (
 	ErrInvalidParam = errors.New("invalid param")
 	ErrInternal     = errors.New("internal error")
 	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrUnavailable  = errors.New("unavailable")
+	ErrTooLarge     = errors.New("too large")
+	ErrConflict     = errors.New("conflict")
 )
@@ -0,0 +1,49 @@
+package entities
+
+type HealthStatus string
+
+const (
+	HealthStatusHealthy   HealthStatus = "healthy"
+	HealthStatusDegraded  HealthStatus = "degraded"
+	HealthStatusUnhealthy HealthStatus = "unhealthy"
+)
+
+var healthSeverity = map[HealthStatus]int{
+	HealthStatusHealthy:   0,
+	HealthStatusDegraded:  1,
+	HealthStatusUnhealthy: 2,
+}
+
+// CircuitState is the state of a circuit breaker guarding calls to an external dependency.
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+type DependencyHealth struct {
+	Name   string
+	Status HealthStatus
+	Detail string
+}
+
+type Health struct {
+	Status       HealthStatus
+	Dependencies []DependencyHealth
+}
+
+// NewHealth reports the worst status among the dependencies, a process without dependencies is healthy.
+func NewHealth(dependencies ...DependencyHealth) *Health {
+	health := &Health{
+		Status:       HealthStatusHealthy,
+		Dependencies: dependencies,
+	}
+	for _, dependency := range dependencies {
+		if healthSeverity[dependency.Status] > healthSeverity[health.Status] {
+			health.Status = dependency.Status
+		}
+	}
+	return health
+}
@@ -0,0 +1,35 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func TestNewHealth(t *testing.T) {
+	t.Parallel()
+
+	healthy := entities.DependencyHealth{Name: "database", Status: entities.HealthStatusHealthy}
+	degraded := entities.DependencyHealth{Name: "provider", Status: entities.HealthStatusDegraded}
+	unhealthy := entities.DependencyHealth{Name: "updates", Status: entities.HealthStatusUnhealthy}
+
+	for name, tc := range map[string]struct {
+		dependencies []entities.DependencyHealth
+		status       entities.HealthStatus
+	}{
+		"no dependencies": {nil, entities.HealthStatusHealthy},
+		"all healthy":     {[]entities.DependencyHealth{healthy, healthy}, entities.HealthStatusHealthy},
+		"degraded":        {[]entities.DependencyHealth{healthy, degraded}, entities.HealthStatusDegraded},
+		"unhealthy wins":  {[]entities.DependencyHealth{unhealthy, degraded, healthy}, entities.HealthStatusUnhealthy},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			health := entities.NewHealth(tc.dependencies...)
+			require.Equal(t, tc.status, health.Status)
+			require.Equal(t, tc.dependencies, health.Dependencies)
+		})
+	}
+}
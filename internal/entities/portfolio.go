@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type Position struct {
+	Title     string
+	Quantity  float64
+	CostBasis float64
+}
+
+type Portfolio struct {
+	ID        int64
+	Name      string
+	Positions []Position
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type PositionValuation struct {
+	Position
+	Price      float64
+	PriceAt    time.Time
+	Value      float64
+	Cost       float64
+	PnL        float64
+	PnLPercent float64
+}
+
+type PortfolioValuation struct {
+	Positions  []PositionValuation
+	TotalValue float64
+	TotalCost  float64
+	TotalPnL   float64
+	At         time.Time
+}
+
+func NewPosition(title string, quantity, costBasis float64) (*Position, error) {
+	if title == "" {
+		return nil, errors.Wrap(ErrInvalidParam, "title cannot be empty")
+	}
+	if quantity <= 0 {
+		return nil, errors.Wrapf(ErrInvalidParam, "quantity of %q must be greater than zero", title)
+	}
+	if costBasis < 0 {
+		return nil, errors.Wrapf(ErrInvalidParam, "cost basis of %q cannot be negative", title)
+	}
+
+	return &Position{
+		Title:     title,
+		Quantity:  quantity,
+		CostBasis: costBasis,
+	}, nil
+}
+
+func NewPortfolio(name string, positions []Position) (*Portfolio, error) {
+	if name == "" {
+		return nil, errors.Wrap(ErrInvalidParam, "portfolio name cannot be empty")
+	}
+	if err := ValidatePositions(positions); err != nil {
+		return nil, err
+	}
+
+	return &Portfolio{
+		Name:      name,
+		Positions: positions,
+	}, nil
+}
+
+func ValidatePositions(positions []Position) error {
+	if len(positions) == 0 {
+		return errors.Wrap(ErrInvalidParam, "positions list cannot be empty")
+	}
+
+	seenTitles := make(map[string]struct{}, len(positions))
+	for _, position := range positions {
+		if _, err := NewPosition(position.Title, position.Quantity, position.CostBasis); err != nil {
+			return err
+		}
+		if _, exists := seenTitles[position.Title]; exists {
+			return errors.Wrapf(ErrInvalidParam, "duplicate position for %q", position.Title)
+		}
+		seenTitles[position.Title] = struct{}{}
+	}
+	return nil
+}
+
+func (p Position) Valuate(coin Coin) PositionValuation {
+	valuation := PositionValuation{
+		Position: p,
+		Price:    coin.Cost,
+		PriceAt:  coin.ActualAt,
+		Value:    p.Quantity * coin.Cost,
+		Cost:     p.Quantity * p.CostBasis,
+	}
+	if valuation.Cost > 0 {
+		valuation.PnL = valuation.Value - valuation.Cost
+		valuation.PnLPercent = valuation.PnL * 100 / valuation.Cost
+	}
+	return valuation
+}
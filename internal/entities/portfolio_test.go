@@ -0,0 +1,67 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_NewPortfolio_Success(t *testing.T) {
+	t.Parallel()
+	positions := []entities.Position{
+		{Title: "BTC", Quantity: 0.5, CostBasis: 40000},
+		{Title: "ETH", Quantity: 2},
+	}
+	portfolio, err := entities.NewPortfolio("main", positions)
+	require.NoError(t, err)
+	require.Equal(t, &entities.Portfolio{
+		Name:      "main",
+		Positions: positions,
+	}, portfolio)
+}
+func Test_NewPortfolio_EmptyName(t *testing.T) {
+	t.Parallel()
+	portfolio, err := entities.NewPortfolio("", []entities.Position{{Title: "BTC", Quantity: 1}})
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+	require.Nil(t, portfolio)
+}
+func Test_NewPortfolio_InvalidPositions(t *testing.T) {
+	t.Parallel()
+	for name, positions := range map[string][]entities.Position{
+		"empty":              nil,
+		"empty title":        {{Title: "", Quantity: 1}},
+		"zero quantity":      {{Title: "BTC", Quantity: 0}},
+		"negative costBasis": {{Title: "BTC", Quantity: 1, CostBasis: -1}},
+		"duplicate title":    {{Title: "BTC", Quantity: 1}, {Title: "BTC", Quantity: 2}},
+	} {
+		portfolio, err := entities.NewPortfolio("main", positions)
+		require.ErrorIs(t, err, entities.ErrInvalidParam, name)
+		require.Nil(t, portfolio, name)
+	}
+}
+func Test_Position_Valuate(t *testing.T) {
+	t.Parallel()
+	priceAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	position := entities.Position{Title: "BTC", Quantity: 2, CostBasis: 40000}
+	valuation := position.Valuate(entities.Coin{Title: "BTC", Cost: 50000, ActualAt: priceAt})
+	require.Equal(t, entities.PositionValuation{
+		Position:   position,
+		Price:      50000,
+		PriceAt:    priceAt,
+		Value:      100000,
+		Cost:       80000,
+		PnL:        20000,
+		PnLPercent: 25,
+	}, valuation)
+}
+func Test_Position_Valuate_WithoutCostBasis(t *testing.T) {
+	t.Parallel()
+	position := entities.Position{Title: "ETH", Quantity: 3}
+	valuation := position.Valuate(entities.Coin{Title: "ETH", Cost: 3000})
+	require.Equal(t, float64(9000), valuation.Value)
+	require.Zero(t, valuation.PnL)
+	require.Zero(t, valuation.PnLPercent)
+}
@@ -0,0 +1,54 @@
+package entities
+
+import "time"
+
+type QualityIssueKind string
+
+const (
+	// QualityGap is a stretch without stored rates longer than the update interval allows.
+	QualityGap QualityIssueKind = "gap"
+	// QualityJump is a change between consecutive rates far outside the usual changes of the coin.
+	QualityJump QualityIssueKind = "jump"
+	// QualityDuplicate is a timestamp stored more than once for a coin.
+	QualityDuplicate QualityIssueKind = "duplicate"
+)
+
+// QualityIssueKinds lists every kind of issue in the order reports present them.
+var QualityIssueKinds = []QualityIssueKind{QualityGap, QualityJump, QualityDuplicate}
+
+// QualityIssue is a suspicious stretch of the stored rates of a coin. From and To are the timestamps of the
+// rates around a gap or a jump, both are the duplicated timestamp of a duplicate.
+type QualityIssue struct {
+	Title string
+	Kind  QualityIssueKind
+	From  time.Time
+	To    time.Time
+	// FromCost, ToCost and Sigmas describe a jump, Sigmas being its distance from the mean change of the coin
+	// in standard deviations.
+	FromCost float64
+	ToCost   float64
+	Sigmas   float64
+	// Count is the number of rates sharing the timestamp of a duplicate.
+	Count int
+}
+
+// QualityReport lists the issues found among the rates stored between From and To.
+type QualityReport struct {
+	From             time.Time
+	To               time.Time
+	ExpectedInterval time.Duration
+	JumpSigma        float64
+	Issues           []QualityIssue
+}
+
+// Counts returns the number of issues per coin and kind.
+func (r *QualityReport) Counts() map[string]map[QualityIssueKind]int {
+	counts := make(map[string]map[QualityIssueKind]int)
+	for _, issue := range r.Issues {
+		if counts[issue.Title] == nil {
+			counts[issue.Title] = make(map[QualityIssueKind]int)
+		}
+		counts[issue.Title][issue.Kind]++
+	}
+	return counts
+}
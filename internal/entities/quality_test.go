@@ -0,0 +1,25 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func TestQualityReport_Counts(t *testing.T) {
+	t.Parallel()
+
+	report := &entities.QualityReport{Issues: []entities.QualityIssue{
+		{Title: "BTC", Kind: entities.QualityGap},
+		{Title: "BTC", Kind: entities.QualityGap},
+		{Title: "BTC", Kind: entities.QualityJump},
+		{Title: "ETH", Kind: entities.QualityDuplicate},
+	}}
+
+	require.Equal(t, map[string]map[entities.QualityIssueKind]int{
+		"BTC": {entities.QualityGap: 2, entities.QualityJump: 1},
+		"ETH": {entities.QualityDuplicate: 1},
+	}, report.Counts())
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type QuarantineStatus string
+
+const (
+	QuarantinePending   QuarantineStatus = "pending"
+	QuarantineApproved  QuarantineStatus = "approved"
+	QuarantineDiscarded QuarantineStatus = "discarded"
+)
+
+func ParseQuarantineStatus(name string) (QuarantineStatus, error) {
+	switch status := QuarantineStatus(name); status {
+	case QuarantinePending, QuarantineApproved, QuarantineDiscarded:
+		return status, nil
+	default:
+		return "", errors.Wrapf(ErrInvalidParam, "invalid quarantine status %q, must be one of pending, approved, discarded", name)
+	}
+}
+
+// QuarantinedRate is a rate held back from storage because it strayed too far from the recent rates of the
+// coin. An approved rate is stored with its original timestamp, a discarded one is dropped.
+type QuarantinedRate struct {
+	ID            int64
+	Title         string
+	Cost          float64
+	ActualAt      time.Time
+	Source        string
+	ReferenceCost float64
+	Status        QuarantineStatus
+	QuarantinedAt time.Time
+	ReviewedAt    time.Time
+}
+
+// Deviation returns the relative distance of the rate from its reference, 0.5 being 50% off.
+func (r *QuarantinedRate) Deviation() float64 {
+	return RelativeDeviation(r.Cost, r.ReferenceCost)
+}
+
+// RelativeDeviation returns how far cost lies from reference relative to the reference.
+func RelativeDeviation(cost, reference float64) float64 {
+	return math.Abs(cost-reference) / reference
+}
@@ -0,0 +1,30 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func TestParseQuarantineStatus(t *testing.T) {
+	t.Parallel()
+
+	status, err := entities.ParseQuarantineStatus("approved")
+	require.NoError(t, err)
+	require.Equal(t, entities.QuarantineApproved, status)
+
+	_, err = entities.ParseQuarantineStatus("rejected")
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
+
+func TestQuarantinedRate_Deviation(t *testing.T) {
+	t.Parallel()
+
+	rate := entities.QuarantinedRate{Cost: 6000000, ReferenceCost: 60000}
+	require.InDelta(t, 99, rate.Deviation(), 1e-9)
+
+	rate = entities.QuarantinedRate{Cost: 30000, ReferenceCost: 60000}
+	require.InDelta(t, 0.5, rate.Deviation(), 1e-9)
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var aggTypes = map[string]struct{}{
+	"MIN": {}, "MAX": {}, "AVG": {},
+	"CHANGE": {}, "CHANGE_PCT": {}, "STDDEV": {},
+	"FIRST": {}, "LAST": {}, "MEDIAN": {}, "COUNT": {},
+}
+
+// CoinStats holds the aggregates of one coin keyed by aggregation type, aggregates that are undefined
+// for its rates are absent.
+type CoinStats struct {
+	Title     string
+	Values    map[string]float64
+	UpdatedAt time.Time
+}
+
+func ValidateAggType(aggType string) error {
+	if _, exists := aggTypes[aggType]; !exists {
+		return errors.Wrapf(ErrInvalidParam, "invalid aggregation type %q", aggType)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+)
+
+func Test_ValidateAggType(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, entities.ValidateAggType("CHANGE_PCT"))
+	require.ErrorIs(t, entities.ValidateAggType("SUM"), entities.ErrInvalidParam)
+	require.ErrorIs(t, entities.ValidateAggType(""), entities.ErrInvalidParam)
+}
@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"Cryptoproject/internal/entities"
+)
+
+func toStatus(err error) error {
+	code := codes.Unknown
+
+	switch {
+	case errors.Is(err, entities.ErrInvalidParam):
+		code = codes.InvalidArgument
+	case errors.Is(err, entities.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, entities.ErrUnauthorized):
+		code = codes.Unauthenticated
+	case errors.Is(err, entities.ErrForbidden):
+		code = codes.PermissionDenied
+	case errors.Is(err, entities.ErrRateLimited):
+		code = codes.ResourceExhausted
+	case errors.Is(err, entities.ErrTooLarge):
+		code = codes.ResourceExhausted
+	case errors.Is(err, entities.ErrConflict):
+		code = codes.FailedPrecondition
+	case errors.Is(err, entities.ErrUnavailable):
+		code = codes.Unavailable
+	case errors.Is(err, entities.ErrInternal):
+		code = codes.Internal
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,484 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rates.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Rate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Cost          float64                `protobuf:"fixed64,2,opt,name=cost,proto3" json:"cost,omitempty"`
+	ActualAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=actual_at,json=actualAt,proto3" json:"actual_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rate) Reset() {
+	*x = Rate{}
+	mi := &file_rates_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rate) ProtoMessage() {}
+
+func (x *Rate) ProtoReflect() protoreflect.Message {
+	mi := &file_rates_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rate.ProtoReflect.Descriptor instead.
+func (*Rate) Descriptor() ([]byte, []int) {
+	return file_rates_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Rate) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Rate) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *Rate) GetActualAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActualAt
+	}
+	return nil
+}
+
+type CoinStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Stats         map[string]float64     `protobuf:"bytes,2,rep,name=stats,proto3" json:"stats,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoinStats) Reset() {
+	*x = CoinStats{}
+	mi := &file_rates_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoinStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoinStats) ProtoMessage() {}
+
+func (x *CoinStats) ProtoReflect() protoreflect.Message {
+	mi := &file_rates_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoinStats.ProtoReflect.Descriptor instead.
+func (*CoinStats) Descriptor() ([]byte, []int) {
+	return file_rates_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CoinStats) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CoinStats) GetStats() map[string]float64 {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetLastRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Titles        []string               `protobuf:"bytes,1,rep,name=titles,proto3" json:"titles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLastRatesRequest) Reset() {
+	*x = GetLastRatesRequest{}
+	mi := &file_rates_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLastRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLastRatesRequest) ProtoMessage() {}
+
+func (x *GetLastRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rates_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLastRatesRequest.ProtoReflect.Descriptor instead.
+func (*GetLastRatesRequest) Descriptor() ([]byte, []int) {
+	return file_rates_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetLastRatesRequest) GetTitles() []string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+type GetLastRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*Rate                `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLastRatesResponse) Reset() {
+	*x = GetLastRatesResponse{}
+	mi := &file_rates_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLastRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLastRatesResponse) ProtoMessage() {}
+
+func (x *GetLastRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rates_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLastRatesResponse.ProtoReflect.Descriptor instead.
+func (*GetLastRatesResponse) Descriptor() ([]byte, []int) {
+	return file_rates_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetLastRatesResponse) GetRates() []*Rate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+type GetAggregateRatesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Titles []string               `protobuf:"bytes,1,rep,name=titles,proto3" json:"titles,omitempty"`
+	// Aggregation types: MIN, MAX, AVG, CHANGE, CHANGE_PCT, STDDEV, FIRST, LAST, MEDIAN, COUNT.
+	AggTypes []string `protobuf:"bytes,2,rep,name=agg_types,json=aggTypes,proto3" json:"agg_types,omitempty"`
+	// Window of stored rates the statistics cover, an unset from starts at the beginning of history
+	// and an unset to ends now.
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAggregateRatesRequest) Reset() {
+	*x = GetAggregateRatesRequest{}
+	mi := &file_rates_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAggregateRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAggregateRatesRequest) ProtoMessage() {}
+
+func (x *GetAggregateRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rates_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAggregateRatesRequest.ProtoReflect.Descriptor instead.
+func (*GetAggregateRatesRequest) Descriptor() ([]byte, []int) {
+	return file_rates_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetAggregateRatesRequest) GetTitles() []string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+func (x *GetAggregateRatesRequest) GetAggTypes() []string {
+	if x != nil {
+		return x.AggTypes
+	}
+	return nil
+}
+
+func (x *GetAggregateRatesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetAggregateRatesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetAggregateRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coins         []*CoinStats           `protobuf:"bytes,1,rep,name=coins,proto3" json:"coins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAggregateRatesResponse) Reset() {
+	*x = GetAggregateRatesResponse{}
+	mi := &file_rates_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAggregateRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAggregateRatesResponse) ProtoMessage() {}
+
+func (x *GetAggregateRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rates_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAggregateRatesResponse.ProtoReflect.Descriptor instead.
+func (*GetAggregateRatesResponse) Descriptor() ([]byte, []int) {
+	return file_rates_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAggregateRatesResponse) GetCoins() []*CoinStats {
+	if x != nil {
+		return x.Coins
+	}
+	return nil
+}
+
+type SubscribeRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Titles        []string               `protobuf:"bytes,1,rep,name=titles,proto3" json:"titles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRatesRequest) Reset() {
+	*x = SubscribeRatesRequest{}
+	mi := &file_rates_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRatesRequest) ProtoMessage() {}
+
+func (x *SubscribeRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rates_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRatesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRatesRequest) Descriptor() ([]byte, []int) {
+	return file_rates_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SubscribeRatesRequest) GetTitles() []string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+var File_rates_proto protoreflect.FileDescriptor
+
+const file_rates_proto_rawDesc = "" +
+	"\n" +
+	"\vrates.proto\x12\brates.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"i\n" +
+	"\x04Rate\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04cost\x18\x02 \x01(\x01R\x04cost\x127\n" +
+	"\tactual_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bactualAt\"\x91\x01\n" +
+	"\tCoinStats\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x124\n" +
+	"\x05stats\x18\x02 \x03(\v2\x1e.rates.v1.CoinStats.StatsEntryR\x05stats\x1a8\n" +
+	"\n" +
+	"StatsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"-\n" +
+	"\x13GetLastRatesRequest\x12\x16\n" +
+	"\x06titles\x18\x01 \x03(\tR\x06titles\"<\n" +
+	"\x14GetLastRatesResponse\x12$\n" +
+	"\x05rates\x18\x01 \x03(\v2\x0e.rates.v1.RateR\x05rates\"\xab\x01\n" +
+	"\x18GetAggregateRatesRequest\x12\x16\n" +
+	"\x06titles\x18\x01 \x03(\tR\x06titles\x12\x1b\n" +
+	"\tagg_types\x18\x02 \x03(\tR\baggTypes\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"F\n" +
+	"\x19GetAggregateRatesResponse\x12)\n" +
+	"\x05coins\x18\x01 \x03(\v2\x13.rates.v1.CoinStatsR\x05coins\"/\n" +
+	"\x15SubscribeRatesRequest\x12\x16\n" +
+	"\x06titles\x18\x01 \x03(\tR\x06titles2\x80\x02\n" +
+	"\fRatesService\x12M\n" +
+	"\fGetLastRates\x12\x1d.rates.v1.GetLastRatesRequest\x1a\x1e.rates.v1.GetLastRatesResponse\x12\\\n" +
+	"\x11GetAggregateRates\x12\".rates.v1.GetAggregateRatesRequest\x1a#.rates.v1.GetAggregateRatesResponse\x12C\n" +
+	"\x0eSubscribeRates\x12\x1f.rates.v1.SubscribeRatesRequest\x1a\x0e.rates.v1.Rate0\x01B&Z$Cryptoproject/internal/ports/grpc/pbb\x06proto3"
+
+var (
+	file_rates_proto_rawDescOnce sync.Once
+	file_rates_proto_rawDescData []byte
+)
+
+func file_rates_proto_rawDescGZIP() []byte {
+	file_rates_proto_rawDescOnce.Do(func() {
+		file_rates_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rates_proto_rawDesc), len(file_rates_proto_rawDesc)))
+	})
+	return file_rates_proto_rawDescData
+}
+
+var file_rates_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_rates_proto_goTypes = []any{
+	(*Rate)(nil),                      // 0: rates.v1.Rate
+	(*CoinStats)(nil),                 // 1: rates.v1.CoinStats
+	(*GetLastRatesRequest)(nil),       // 2: rates.v1.GetLastRatesRequest
+	(*GetLastRatesResponse)(nil),      // 3: rates.v1.GetLastRatesResponse
+	(*GetAggregateRatesRequest)(nil),  // 4: rates.v1.GetAggregateRatesRequest
+	(*GetAggregateRatesResponse)(nil), // 5: rates.v1.GetAggregateRatesResponse
+	(*SubscribeRatesRequest)(nil),     // 6: rates.v1.SubscribeRatesRequest
+	nil,                               // 7: rates.v1.CoinStats.StatsEntry
+	(*timestamppb.Timestamp)(nil),     // 8: google.protobuf.Timestamp
+}
+var file_rates_proto_depIdxs = []int32{
+	8, // 0: rates.v1.Rate.actual_at:type_name -> google.protobuf.Timestamp
+	7, // 1: rates.v1.CoinStats.stats:type_name -> rates.v1.CoinStats.StatsEntry
+	0, // 2: rates.v1.GetLastRatesResponse.rates:type_name -> rates.v1.Rate
+	8, // 3: rates.v1.GetAggregateRatesRequest.from:type_name -> google.protobuf.Timestamp
+	8, // 4: rates.v1.GetAggregateRatesRequest.to:type_name -> google.protobuf.Timestamp
+	1, // 5: rates.v1.GetAggregateRatesResponse.coins:type_name -> rates.v1.CoinStats
+	2, // 6: rates.v1.RatesService.GetLastRates:input_type -> rates.v1.GetLastRatesRequest
+	4, // 7: rates.v1.RatesService.GetAggregateRates:input_type -> rates.v1.GetAggregateRatesRequest
+	6, // 8: rates.v1.RatesService.SubscribeRates:input_type -> rates.v1.SubscribeRatesRequest
+	3, // 9: rates.v1.RatesService.GetLastRates:output_type -> rates.v1.GetLastRatesResponse
+	5, // 10: rates.v1.RatesService.GetAggregateRates:output_type -> rates.v1.GetAggregateRatesResponse
+	0, // 11: rates.v1.RatesService.SubscribeRates:output_type -> rates.v1.Rate
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_rates_proto_init() }
+func file_rates_proto_init() {
+	if File_rates_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rates_proto_rawDesc), len(file_rates_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rates_proto_goTypes,
+		DependencyIndexes: file_rates_proto_depIdxs,
+		MessageInfos:      file_rates_proto_msgTypes,
+	}.Build()
+	File_rates_proto = out.File
+	file_rates_proto_goTypes = nil
+	file_rates_proto_depIdxs = nil
+}
@@ -0,0 +1,211 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rates.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RatesService_GetLastRates_FullMethodName      = "/rates.v1.RatesService/GetLastRates"
+	RatesService_GetAggregateRates_FullMethodName = "/rates.v1.RatesService/GetAggregateRates"
+	RatesService_SubscribeRates_FullMethodName    = "/rates.v1.RatesService/SubscribeRates"
+)
+
+// RatesServiceClient is the client API for RatesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RatesService provides cryptocurrency rate information.
+type RatesServiceClient interface {
+	// GetLastRates returns the latest rates of the requested coins.
+	GetLastRates(ctx context.Context, in *GetLastRatesRequest, opts ...grpc.CallOption) (*GetLastRatesResponse, error)
+	// GetAggregateRates returns the requested statistics of the requested coins.
+	GetAggregateRates(ctx context.Context, in *GetAggregateRatesRequest, opts ...grpc.CallOption) (*GetAggregateRatesResponse, error)
+	// SubscribeRates streams every stored rate of the requested coins.
+	SubscribeRates(ctx context.Context, in *SubscribeRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Rate], error)
+}
+
+type ratesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRatesServiceClient(cc grpc.ClientConnInterface) RatesServiceClient {
+	return &ratesServiceClient{cc}
+}
+
+func (c *ratesServiceClient) GetLastRates(ctx context.Context, in *GetLastRatesRequest, opts ...grpc.CallOption) (*GetLastRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLastRatesResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetLastRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) GetAggregateRates(ctx context.Context, in *GetAggregateRatesRequest, opts ...grpc.CallOption) (*GetAggregateRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAggregateRatesResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetAggregateRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) SubscribeRates(ctx context.Context, in *SubscribeRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Rate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RatesService_ServiceDesc.Streams[0], RatesService_SubscribeRates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRatesRequest, Rate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RatesService_SubscribeRatesClient = grpc.ServerStreamingClient[Rate]
+
+// RatesServiceServer is the server API for RatesService service.
+// All implementations must embed UnimplementedRatesServiceServer
+// for forward compatibility.
+//
+// RatesService provides cryptocurrency rate information.
+type RatesServiceServer interface {
+	// GetLastRates returns the latest rates of the requested coins.
+	GetLastRates(context.Context, *GetLastRatesRequest) (*GetLastRatesResponse, error)
+	// GetAggregateRates returns the requested statistics of the requested coins.
+	GetAggregateRates(context.Context, *GetAggregateRatesRequest) (*GetAggregateRatesResponse, error)
+	// SubscribeRates streams every stored rate of the requested coins.
+	SubscribeRates(*SubscribeRatesRequest, grpc.ServerStreamingServer[Rate]) error
+	mustEmbedUnimplementedRatesServiceServer()
+}
+
+// UnimplementedRatesServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRatesServiceServer struct{}
+
+func (UnimplementedRatesServiceServer) GetLastRates(context.Context, *GetLastRatesRequest) (*GetLastRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLastRates not implemented")
+}
+func (UnimplementedRatesServiceServer) GetAggregateRates(context.Context, *GetAggregateRatesRequest) (*GetAggregateRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAggregateRates not implemented")
+}
+func (UnimplementedRatesServiceServer) SubscribeRates(*SubscribeRatesRequest, grpc.ServerStreamingServer[Rate]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeRates not implemented")
+}
+func (UnimplementedRatesServiceServer) mustEmbedUnimplementedRatesServiceServer() {}
+func (UnimplementedRatesServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeRatesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RatesServiceServer will
+// result in compilation errors.
+type UnsafeRatesServiceServer interface {
+	mustEmbedUnimplementedRatesServiceServer()
+}
+
+func RegisterRatesServiceServer(s grpc.ServiceRegistrar, srv RatesServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRatesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RatesService_ServiceDesc, srv)
+}
+
+func _RatesService_GetLastRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLastRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetLastRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetLastRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetLastRates(ctx, req.(*GetLastRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_GetAggregateRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAggregateRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetAggregateRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetAggregateRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetAggregateRates(ctx, req.(*GetAggregateRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_SubscribeRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RatesServiceServer).SubscribeRates(m, &grpc.GenericServerStream[SubscribeRatesRequest, Rate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RatesService_SubscribeRatesServer = grpc.ServerStreamingServer[Rate]
+
+// RatesService_ServiceDesc is the grpc.ServiceDesc for RatesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RatesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rates.v1.RatesService",
+	HandlerType: (*RatesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLastRates",
+			Handler:    _RatesService_GetLastRates_Handler,
+		},
+		{
+			MethodName: "GetAggregateRates",
+			Handler:    _RatesService_GetAggregateRates_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeRates",
+			Handler:       _RatesService_SubscribeRates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rates.proto",
+}
@@ -0,0 +1,231 @@
+package grpc
+
+//go:generate buf generate
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/internal/ports/grpc/pb"
+)
+
+type Server struct {
+	pb.UnimplementedRatesServiceServer
+
+	Addr       string
+	GrpcServer *grpc.Server
+	Service    Service
+	Broker     Broker
+	logger     *slog.Logger
+}
+
+type ServerOption func(*Server)
+
+func WithBroker(broker Broker) ServerOption {
+	return func(s *Server) {
+		s.Broker = broker
+	}
+}
+
+func WithLogger(logger *slog.Logger) ServerOption {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+func NewServer(addr string, srv Service, opts ...ServerOption) (*Server, error) {
+	if addr == "" {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "address cannot be empty")
+	}
+	if srv == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "service not provided")
+	}
+
+	srvInstance := &Server{
+		Addr:       addr,
+		GrpcServer: grpc.NewServer(),
+		Service:    srv,
+		logger:     slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(srvInstance)
+	}
+
+	if srvInstance.logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "logger cannot be nil")
+	}
+
+	pb.RegisterRatesServiceServer(srvInstance.GrpcServer, srvInstance)
+
+	return srvInstance, nil
+}
+
+func (srv *Server) Start() error {
+	lis, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %s", srv.Addr)
+	}
+
+	srv.logger.Info("gRPC server started", "addr", srv.Addr)
+	return srv.Serve(lis)
+}
+
+func (srv *Server) Serve(lis net.Listener) error {
+	return srv.GrpcServer.Serve(lis)
+}
+
+// Shutdown stops accepting new RPCs and waits for in-flight ones until ctx is done,
+// after which remaining RPCs and streams are cancelled.
+func (srv *Server) Shutdown(ctx context.Context) error {
+	srv.logger.Info("gRPC server shutting down")
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GrpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		srv.GrpcServer.Stop()
+		return ctx.Err()
+	}
+}
+
+func (srv *Server) GetLastRates(ctx context.Context, req *pb.GetLastRatesRequest) (*pb.GetLastRatesResponse, error) {
+	titles := removeEmptyStrings(req.GetTitles())
+	if len(titles) == 0 {
+		return nil, toStatus(errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty"))
+	}
+
+	coins, err := srv.Service.GetLastRates(ctx, titles)
+	if err != nil {
+		srv.logger.Error("Failed to get last rates", "err", err)
+		return nil, toStatus(err)
+	}
+
+	rates := make([]*pb.Rate, len(coins))
+	for i, coin := range coins {
+		rates[i] = toRate(*coin)
+	}
+
+	return &pb.GetLastRatesResponse{Rates: rates}, nil
+}
+
+func (srv *Server) GetAggregateRates(ctx context.Context, req *pb.GetAggregateRatesRequest) (*pb.GetAggregateRatesResponse, error) {
+	titles := removeEmptyStrings(req.GetTitles())
+	if len(titles) == 0 {
+		return nil, toStatus(errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty"))
+	}
+	if len(req.GetAggTypes()) == 0 {
+		return nil, toStatus(errors.Wrap(entities.ErrInvalidParam, "aggregation types list cannot be empty"))
+	}
+	for _, aggType := range req.GetAggTypes() {
+		if err := entities.ValidateAggType(aggType); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+
+	from, err := fromTimestamp(req.GetFrom(), "from")
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	to, err := fromTimestamp(req.GetTo(), "to")
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	stats, err := srv.Service.GetAggregateStats(ctx, titles, req.GetAggTypes(), from, to)
+	if err != nil {
+		srv.logger.Error("Failed to get aggregate rates", "err", err)
+		return nil, toStatus(err)
+	}
+
+	coins := make([]*pb.CoinStats, len(stats))
+	for i, coinStats := range stats {
+		coins[i] = &pb.CoinStats{
+			Title: coinStats.Title,
+			Stats: coinStats.Values,
+		}
+	}
+
+	return &pb.GetAggregateRatesResponse{Coins: coins}, nil
+}
+
+func (srv *Server) SubscribeRates(req *pb.SubscribeRatesRequest, stream grpc.ServerStreamingServer[pb.Rate]) error {
+	if srv.Broker == nil {
+		return status.Error(codes.Unimplemented, "rate subscriptions are not enabled")
+	}
+
+	titles := removeEmptyStrings(req.GetTitles())
+	if len(titles) == 0 {
+		return toStatus(errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty"))
+	}
+
+	sub := srv.Broker.Subscribe(titles)
+	defer srv.Broker.Unsubscribe(sub)
+
+	srv.logger.Info("gRPC rates subscription opened", "titles", titles)
+	for {
+		select {
+		case <-stream.Context().Done():
+			srv.logger.Info("gRPC rates subscription closed by client", "titles", titles)
+			return nil
+		case coin, ok := <-sub.Events():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					srv.logger.Error("gRPC rates subscriber dropped", "titles", titles, "err", err)
+					return status.Error(codes.ResourceExhausted, err.Error())
+				}
+				return nil
+			}
+			if err := stream.Send(toRate(coin)); err != nil {
+				srv.logger.Error("Failed to send rate", "err", err)
+				return err
+			}
+		}
+	}
+}
+
+func toRate(coin entities.Coin) *pb.Rate {
+	rate := &pb.Rate{
+		Title: coin.Title,
+		Cost:  coin.Cost,
+	}
+	if !coin.ActualAt.IsZero() {
+		rate.ActualAt = timestamppb.New(coin.ActualAt)
+	}
+	return rate
+}
+
+// fromTimestamp converts an optional timestamp, leaving an unset one as the zero time.
+func fromTimestamp(ts *timestamppb.Timestamp, name string) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, errors.Wrapf(entities.ErrInvalidParam, "invalid %s timestamp: %v", name, err)
+	}
+	return ts.AsTime(), nil
+}
+
+func removeEmptyStrings(slices []string) []string {
+	var result []string
+	for _, str := range slices {
+		if str != "" {
+			result = append(result, str)
+		}
+	}
+	return result
+}
@@ -0,0 +1,212 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"Cryptoproject/internal/adapters/broker"
+	"Cryptoproject/internal/entities"
+	mygrpc "Cryptoproject/internal/ports/grpc"
+	"Cryptoproject/internal/ports/grpc/pb"
+	mocks "Cryptoproject/internal/ports/grpc/testdata"
+)
+
+func setupServer(t *testing.T) (pb.RatesServiceClient, *mocks.MockService, *broker.Broker) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+
+	b, err := broker.NewBroker()
+	require.NoError(t, err)
+
+	server, err := mygrpc.NewServer(":0", mockService, mygrpc.WithBroker(b))
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(func() { _ = server.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewRatesServiceClient(conn), mockService, b
+}
+
+func TestServer_GetLastRates_Success(t *testing.T) {
+	t.Parallel()
+
+	client, mockService, _ := setupServer(t)
+
+	actualAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService.EXPECT().GetLastRates(gomock.Any(), []string{"BTC", "ETH"}).Return([]*entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: actualAt},
+		{Title: "ETH", Cost: 3000, ActualAt: actualAt},
+	}, nil)
+
+	resp, err := client.GetLastRates(context.Background(), &pb.GetLastRatesRequest{Titles: []string{"BTC", "", "ETH"}})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetRates(), 2)
+	require.Equal(t, "BTC", resp.GetRates()[0].GetTitle())
+	require.Equal(t, float64(50000), resp.GetRates()[0].GetCost())
+	require.Equal(t, actualAt, resp.GetRates()[0].GetActualAt().AsTime())
+}
+
+func TestServer_GetLastRates_EmptyTitles(t *testing.T) {
+	t.Parallel()
+
+	client, _, _ := setupServer(t)
+
+	_, err := client.GetLastRates(context.Background(), &pb.GetLastRatesRequest{})
+
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_GetAggregateRates_Success(t *testing.T) {
+	t.Parallel()
+
+	client, mockService, _ := setupServer(t)
+
+	mockService.EXPECT().GetAggregateStats(gomock.Any(), []string{"BTC"}, []string{"MIN", "MAX"}, gomock.Any(), gomock.Any()).Return([]*entities.CoinStats{
+		{Title: "BTC", Values: map[string]float64{"MIN": 40000, "MAX": 60000}},
+	}, nil)
+
+	resp, err := client.GetAggregateRates(context.Background(), &pb.GetAggregateRatesRequest{
+		Titles:   []string{"BTC"},
+		AggTypes: []string{"MIN", "MAX"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetCoins(), 1)
+	require.Equal(t, map[string]float64{"MIN": 40000, "MAX": 60000}, resp.GetCoins()[0].GetStats())
+}
+
+func TestServer_GetAggregateRates_Window(t *testing.T) {
+	t.Parallel()
+
+	client, mockService, _ := setupServer(t)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	mockService.EXPECT().GetAggregateStats(gomock.Any(), []string{"BTC"}, []string{"CHANGE_PCT"}, from, to).Return([]*entities.CoinStats{
+		{Title: "BTC", Values: map[string]float64{}},
+	}, nil)
+
+	resp, err := client.GetAggregateRates(context.Background(), &pb.GetAggregateRatesRequest{
+		Titles:   []string{"BTC"},
+		AggTypes: []string{"CHANGE_PCT"},
+		From:     timestamppb.New(from),
+		To:       timestamppb.New(to),
+	})
+
+	require.NoError(t, err)
+	require.Empty(t, resp.GetCoins()[0].GetStats(), "an undefined statistic is absent")
+
+	_, err = client.GetAggregateRates(context.Background(), &pb.GetAggregateRatesRequest{
+		Titles:   []string{"BTC"},
+		AggTypes: []string{"MIN"},
+		From:     &timestamppb.Timestamp{Nanos: -1},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_GetAggregateRates_InvalidAggType(t *testing.T) {
+	t.Parallel()
+
+	client, _, _ := setupServer(t)
+
+	_, err := client.GetAggregateRates(context.Background(), &pb.GetAggregateRatesRequest{
+		Titles:   []string{"BTC"},
+		AggTypes: []string{"SUM"},
+	})
+
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_ErrorCodeMapping(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		err  error
+		code codes.Code
+	}{
+		"invalid param": {errors.Wrap(entities.ErrInvalidParam, "bad"), codes.InvalidArgument},
+		"not found":     {errors.Wrap(entities.ErrNotFound, "missing"), codes.NotFound},
+		"unauthorized":  {errors.Wrap(entities.ErrUnauthorized, "no key"), codes.Unauthenticated},
+		"forbidden":     {errors.Wrap(entities.ErrForbidden, "no scope"), codes.PermissionDenied},
+		"rate limited":  {errors.Wrap(entities.ErrRateLimited, "quota"), codes.ResourceExhausted},
+		"unavailable":   {errors.Wrap(entities.ErrUnavailable, "circuit open"), codes.Unavailable},
+		"too large":     {errors.Wrap(entities.ErrTooLarge, "message limit"), codes.ResourceExhausted},
+		"conflict":      {errors.Wrap(entities.ErrConflict, "newer rate"), codes.FailedPrecondition},
+		"internal":      {errors.Wrap(entities.ErrInternal, "broken"), codes.Internal},
+		"deadline":      {errors.Wrap(context.DeadlineExceeded, "slow"), codes.DeadlineExceeded},
+		"unknown":       {errors.New("unexpected"), codes.Unknown},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client, mockService, _ := setupServer(t)
+
+			mockService.EXPECT().GetLastRates(gomock.Any(), []string{"BTC"}).Return(nil, tc.err)
+
+			_, err := client.GetLastRates(context.Background(), &pb.GetLastRatesRequest{Titles: []string{"BTC"}})
+
+			require.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
+
+func TestServer_SubscribeRates(t *testing.T) {
+	t.Parallel()
+
+	client, _, b := setupServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.SubscribeRates(ctx, &pb.SubscribeRatesRequest{Titles: []string{"ETH"}})
+	require.NoError(t, err)
+
+	// The subscription is registered asynchronously, keep publishing until the first rate arrives.
+	received := make(chan *pb.Rate, 1)
+	go func() {
+		rate, err := stream.Recv()
+		if err == nil {
+			received <- rate
+		}
+	}()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case rate := <-received:
+			require.Equal(t, "ETH", rate.GetTitle())
+			require.Equal(t, float64(3000), rate.GetCost())
+			return
+		case <-ticker.C:
+			b.Publish(ctx, []entities.Coin{{Title: "BTC", Cost: 50000}, {Title: "ETH", Cost: 3000}})
+		case <-ctx.Done():
+			t.Fatal("rate was not received")
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"Cryptoproject/internal/adapters/broker"
+	"Cryptoproject/internal/entities"
+)
+
+//go:generate mockgen -source=service.go -destination=./testdata/service.go -package=testdata
+type Service interface {
+	GetLastRates(ctx context.Context, title []string) ([]*entities.Coin, error)
+	GetAggregateStats(ctx context.Context, title []string, aggTypes []string, from, to time.Time) ([]*entities.CoinStats, error)
+}
+
+type Broker interface {
+	Subscribe(titles []string) *broker.Subscription
+	Unsubscribe(sub *broker.Subscription)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	broker "Cryptoproject/internal/adapters/broker"
+	entities "Cryptoproject/internal/entities"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAggregateStats mocks base method.
+func (m *MockService) GetAggregateStats(ctx context.Context, title, aggTypes []string, from, to time.Time) ([]*entities.CoinStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregateStats", ctx, title, aggTypes, from, to)
+	ret0, _ := ret[0].([]*entities.CoinStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregateStats indicates an expected call of GetAggregateStats.
+func (mr *MockServiceMockRecorder) GetAggregateStats(ctx, title, aggTypes, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateStats", reflect.TypeOf((*MockService)(nil).GetAggregateStats), ctx, title, aggTypes, from, to)
+}
+
+// GetLastRates mocks base method.
+func (m *MockService) GetLastRates(ctx context.Context, title []string) ([]*entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastRates", ctx, title)
+	ret0, _ := ret[0].([]*entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastRates indicates an expected call of GetLastRates.
+func (mr *MockServiceMockRecorder) GetLastRates(ctx, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastRates", reflect.TypeOf((*MockService)(nil).GetLastRates), ctx, title)
+}
+
+// MockBroker is a mock of Broker interface.
+type MockBroker struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrokerMockRecorder
+}
+
+// MockBrokerMockRecorder is the mock recorder for MockBroker.
+type MockBrokerMockRecorder struct {
+	mock *MockBroker
+}
+
+// NewMockBroker creates a new mock instance.
+func NewMockBroker(ctrl *gomock.Controller) *MockBroker {
+	mock := &MockBroker{ctrl: ctrl}
+	mock.recorder = &MockBrokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBroker) EXPECT() *MockBrokerMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockBroker) Subscribe(titles []string) *broker.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", titles)
+	ret0, _ := ret[0].(*broker.Subscription)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockBrokerMockRecorder) Subscribe(titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockBroker)(nil).Subscribe), titles)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockBroker) Unsubscribe(sub *broker.Subscription) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe", sub)
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockBrokerMockRecorder) Unsubscribe(sub interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockBroker)(nil).Unsubscribe), sub)
+}
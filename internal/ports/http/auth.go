@@ -0,0 +1,279 @@
+package http
+
+import (
+	"crypto/subtle"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+const (
+	apiKeyHeader     = "X-API-Key"
+	adminTokenHeader = "X-Admin-Token"
+	usageDayLayout   = time.DateOnly
+)
+
+// WithAPIKeyAuth requires a valid X-API-Key header on every API route and enforces per-key rate limits
+// and daily quotas. Admin endpoints for managing keys are served under /v1/admin when adminToken is set.
+// It cannot be combined with WithJWTAuth.
+func WithAPIKeyAuth(adminToken string) ServerOption {
+	return func(s *Server) {
+		s.apiKeyAuth = true
+		s.adminToken = adminToken
+	}
+}
+
+// keyLimiters keeps a token bucket per API key. Limits are enforced per server instance.
+type keyLimiters struct {
+	mu       sync.Mutex
+	limiters map[int64]*rate.Limiter
+}
+
+func (l *keyLimiters) get(key *entities.APIKey) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limiters == nil {
+		l.limiters = make(map[int64]*rate.Limiter)
+	}
+	limiter, exists := l.limiters[key.ID]
+	if !exists {
+		limiter = rate.NewLimiter(rate.Limit(key.RateLimit), key.Burst)
+		l.limiters[key.ID] = limiter
+	}
+	return limiter
+}
+
+func (srv *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, err := srv.Service.Authenticate(r.Context(), r.Header.Get(apiKeyHeader))
+		if err != nil {
+			srv.logger.WarnContext(r.Context(), "API key rejected", "path", r.URL.Path, "err", err)
+			srv.errProcessing(w, r, err)
+			return
+		}
+
+		reservation := srv.limiters.get(key).Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			reservation.Cancel()
+			srv.logger.WarnContext(r.Context(), "API key rate limit exceeded", "api_key_id", key.ID)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			srv.errProcessing(w, r, errors.Wrapf(entities.ErrRateLimited, "rate limit of %g requests per second exceeded", key.RateLimit))
+			return
+		}
+
+		if err := srv.Service.ConsumeQuota(r.Context(), key); err != nil {
+			srv.errProcessing(w, r, err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (srv *Server) authenticateAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(adminTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(srv.adminToken)) != 1 {
+			srv.logger.WarnContext(r.Context(), "Admin token rejected", "path", r.URL.Path)
+			srv.errProcessing(w, r, errors.Wrap(entities.ErrUnauthorized, "admin token is invalid"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// adminRoutes are authorized by the admin token, or by admin scopes when JWT authentication is enabled.
+func (srv *Server) adminRoutes(r chi.Router) {
+	if srv.jwtKeys != nil {
+		r.Use(srv.authenticateJWT)
+	} else {
+		r.Use(srv.authenticateAdmin)
+	}
+
+	r.Use(srv.timeout)
+
+	read := r.With(srv.requireScope(scopeAdminRead))
+	write := r.With(srv.requireScope(scopeAdminWrite))
+	read.Get("/keys", srv.listAPIKeys)
+	write.Post("/keys", srv.createAPIKey)
+	write.Delete("/keys/{id}", srv.revokeAPIKey)
+	read.Get("/usage", srv.getAPIKeyUsage)
+	read.Get("/quality", srv.getQualityReport)
+	read.Get("/quarantine", srv.listQuarantinedRates)
+	write.Post("/quarantine/{id}/approve", srv.approveQuarantinedRate)
+	write.Post("/quarantine/{id}/discard", srv.discardQuarantinedRate)
+}
+
+// @Summary Create API key
+// @Description Issues a new API key. The plaintext key is only returned in this response, only its hash is stored.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Security BearerAuth
+// @Param request body dto.APIKeyRequestDTO true "Key name, rate limit in requests per second, burst and daily quota (0 is unlimited)"
+// @Success 201 {object} dto.APIKeyDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /admin/keys [post]
+func (srv *Server) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req dto.APIKeyRequestDTO
+	if err := srv.decodeRequest(w, r, &req); err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to decode request", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	key, plaintext, err := srv.Service.CreateAPIKey(r.Context(), req.Name, req.RateLimit, req.Burst, req.DailyQuota)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to create API key", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	keyDTO := srv.toAPIKeyDTO(key)
+	keyDTO.Key = plaintext
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	srv.jsonResponse(w, keyDTO)
+	srv.logger.DebugContext(r.Context(), "Successfully created API key", "api_key_id", key.ID)
+}
+
+// @Summary List API keys
+// @Description Lists all issued API keys, including revoked ones.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Security BearerAuth
+// @Success 200 {object} dto.APIKeysResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /admin/keys [get]
+func (srv *Server) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := srv.Service.ListAPIKeys(r.Context())
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to list API keys", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	dtos := make([]dto.APIKeyDTO, len(keys))
+	for i, key := range keys {
+		dtos[i] = srv.toAPIKeyDTO(key)
+	}
+
+	srv.jsonResponse(w, dto.APIKeysResponseDTO{Keys: dtos})
+	srv.logger.DebugContext(r.Context(), "Successfully listed API keys", "number_of_keys", len(dtos))
+}
+
+// @Summary Revoke API key
+// @Description Revokes an API key. Requests with a revoked key are rejected, its usage is kept.
+// @Tags Admin
+// @Security AdminToken
+// @Security BearerAuth
+// @Param id path int true "API key ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /admin/keys/{id} [delete]
+func (srv *Server) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil || id <= 0 {
+		srv.logger.ErrorContext(r.Context(), "Invalid API key id provided", "id", rawID)
+		srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "invalid API key id"))
+		return
+	}
+
+	if err := srv.Service.RevokeAPIKey(r.Context(), id); err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to revoke API key", "api_key_id", id, "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	srv.logger.DebugContext(r.Context(), "Successfully revoked API key", "api_key_id", id)
+}
+
+// @Summary Get API key usage
+// @Description Reports the number of requests served per API key and day (UTC) for billing.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Security BearerAuth
+// @Param from query string false "First day (YYYY-MM-DD), defaults to 30 days before to"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} dto.APIKeyUsageResponseDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /admin/usage [get]
+func (srv *Server) getAPIKeyUsage(w http.ResponseWriter, r *http.Request) {
+	var from, to time.Time
+	var err error
+	if rawFrom := r.URL.Query().Get("from"); rawFrom != "" {
+		if from, err = time.Parse(usageDayLayout, rawFrom); err != nil {
+			srv.logger.ErrorContext(r.Context(), "Invalid usage start provided", "from", rawFrom, "err", err)
+			srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "invalid from day"))
+			return
+		}
+	}
+	if rawTo := r.URL.Query().Get("to"); rawTo != "" {
+		if to, err = time.Parse(usageDayLayout, rawTo); err != nil {
+			srv.logger.ErrorContext(r.Context(), "Invalid usage end provided", "to", rawTo, "err", err)
+			srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "invalid to day"))
+			return
+		}
+	}
+
+	usage, err := srv.Service.GetAPIKeyUsage(r.Context(), from, to)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to get API key usage", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	dtos := make([]dto.APIKeyUsageDTO, len(usage))
+	for i, entry := range usage {
+		dtos[i] = dto.APIKeyUsageDTO{
+			KeyID:    entry.KeyID,
+			Day:      entry.Day.Format(usageDayLayout),
+			Requests: entry.Requests,
+		}
+	}
+
+	srv.jsonResponse(w, dto.APIKeyUsageResponseDTO{Usage: dtos})
+	srv.logger.DebugContext(r.Context(), "Successfully retrieved API key usage", "number_of_entries", len(dtos))
+}
+
+func (srv *Server) toAPIKeyDTO(key *entities.APIKey) dto.APIKeyDTO {
+	keyDTO := dto.APIKeyDTO{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		RateLimit:  key.RateLimit,
+		Burst:      key.Burst,
+		DailyQuota: key.DailyQuota,
+		CreatedAt:  key.CreatedAt,
+	}
+	if key.Revoked() {
+		revokedAt := key.RevokedAt
+		keyDTO.RevokedAt = &revokedAt
+	}
+	return keyDTO
+}
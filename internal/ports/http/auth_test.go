@@ -0,0 +1,230 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+	myhttp "Cryptoproject/internal/ports/http"
+	mocks "Cryptoproject/internal/ports/http/testdata"
+	"Cryptoproject/pkg/dto"
+)
+
+const adminToken = "admin-secret"
+
+func setupAuthServer(t *testing.T) (*myhttp.Server, *mocks.MockService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+
+	server, err := myhttp.NewServer(":0", mockService, myhttp.WithAPIKeyAuth(adminToken))
+	require.NoError(t, err)
+
+	return server, mockService
+}
+
+func ratesRequest(apiKey string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC", nil)
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+	return req
+}
+
+func TestAuth_MissingKey(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+	mockService.EXPECT().Authenticate(gomock.Any(), "").Return(nil, errors.Wrap(entities.ErrUnauthorized, "API key is missing"))
+
+	rec := serve(server, ratesRequest(""))
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Equal(t, "unauthorized", decodeProblem(t, rec).Code)
+}
+
+func TestAuth_ValidKey(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+
+	key := &entities.APIKey{ID: 1, RateLimit: 10, Burst: 10}
+	mockService.EXPECT().Authenticate(gomock.Any(), "ck_valid").Return(key, nil)
+	mockService.EXPECT().ConsumeQuota(gomock.Any(), key).Return(nil)
+	mockService.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC"}).Return([]*entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: time.Now()},
+	}, nil)
+
+	rec := serve(server, ratesRequest("ck_valid"))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestAuth_LegacyRoutesRequireKey(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+	mockService.EXPECT().Authenticate(gomock.Any(), "").Return(nil, errors.Wrap(entities.ErrUnauthorized, "API key is missing"))
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/rates/last?titles=BTC", nil))
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuth_RateLimited(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+
+	key := &entities.APIKey{ID: 2, RateLimit: 0.01, Burst: 1}
+	mockService.EXPECT().Authenticate(gomock.Any(), "ck_limited").Return(key, nil).Times(2)
+	mockService.EXPECT().ConsumeQuota(gomock.Any(), key).Return(nil).Times(1)
+	mockService.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC"}).Return([]*entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: time.Now()},
+	}, nil).Times(1)
+
+	rec := serve(server, ratesRequest("ck_limited"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(server, ratesRequest("ck_limited"))
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.NotEmpty(t, rec.Header().Get("Retry-After"))
+	require.Equal(t, "rate_limited", decodeProblem(t, rec).Code)
+}
+
+func TestAuth_QuotaExceeded(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+
+	key := &entities.APIKey{ID: 3, RateLimit: 10, Burst: 10, DailyQuota: 100}
+	mockService.EXPECT().Authenticate(gomock.Any(), "ck_quota").Return(key, nil)
+	mockService.EXPECT().ConsumeQuota(gomock.Any(), key).Return(errors.Wrap(entities.ErrRateLimited, "daily quota of 100 requests exceeded"))
+
+	rec := serve(server, ratesRequest("ck_quota"))
+
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "rate_limited", decodeProblem(t, rec).Code)
+}
+
+func TestAuth_AdminTokenRequired(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupAuthServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/keys", nil)
+	req.Header.Set("X-Admin-Token", "wrong")
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuth_AdminDisabledWithoutToken(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	server, err := myhttp.NewServer(":0", mocks.NewMockService(ctrl), myhttp.WithAPIKeyAuth(""))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/keys", nil)
+	req.Header.Set("X-Admin-Token", "")
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAdmin_CreateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+
+	mockService.EXPECT().CreateAPIKey(gomock.Any(), "billing", float64(5), 10, int64(1000)).Return(&entities.APIKey{
+		ID: 4, Name: "billing", Prefix: "ck_abcdefgh", RateLimit: 5, Burst: 10, DailyQuota: 1000,
+	}, "ck_abcdefgh-secret", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/keys",
+		strings.NewReader(`{"name":"billing","rateLimit":5,"burst":10,"dailyQuota":1000}`))
+	req.Header.Set("X-Admin-Token", adminToken)
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var key dto.APIKeyDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&key))
+	require.Equal(t, int64(4), key.ID)
+	require.Equal(t, "ck_abcdefgh-secret", key.Key)
+	require.Nil(t, key.RevokedAt)
+}
+
+func TestAdmin_ListAPIKeysHidesSecrets(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+
+	revokedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService.EXPECT().ListAPIKeys(gomock.Any()).Return([]*entities.APIKey{
+		{ID: 4, Name: "billing", Prefix: "ck_abcdefgh", Hash: "hash", RevokedAt: revokedAt},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/keys", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), "hash")
+	var keys dto.APIKeysResponseDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&keys))
+	require.Len(t, keys.Keys, 1)
+	require.Empty(t, keys.Keys[0].Key)
+	require.Equal(t, revokedAt, *keys.Keys[0].RevokedAt)
+}
+
+func TestAdmin_RevokeAPIKey(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+
+	mockService.EXPECT().RevokeAPIKey(gomock.Any(), int64(4)).Return(nil)
+	mockService.EXPECT().RevokeAPIKey(gomock.Any(), int64(5)).Return(errors.Wrap(entities.ErrNotFound, "active API key 5 not found"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/admin/keys/4", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	require.Equal(t, http.StatusNoContent, serve(server, req).Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/v1/admin/keys/5", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	require.Equal(t, http.StatusNotFound, serve(server, req).Code)
+}
+
+func TestAdmin_GetAPIKeyUsage(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	mockService.EXPECT().GetAPIKeyUsage(gomock.Any(), from, to).Return([]*entities.APIKeyUsage{
+		{KeyID: 4, Day: from, Requests: 120},
+		{KeyID: 4, Day: to, Requests: 80},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/usage?from=2026-03-01&to=2026-03-02", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var usage dto.APIKeyUsageResponseDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&usage))
+	require.Equal(t, []dto.APIKeyUsageDTO{
+		{KeyID: 4, Day: "2026-03-01", Requests: 120},
+		{KeyID: 4, Day: "2026-03-02", Requests: 80},
+	}, usage.Usage)
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/adapters/broker"
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:cryptoproject:problem:"
+
+	// statusClientClosedRequest is the de facto status for requests abandoned by the client.
+	statusClientClosedRequest = 499
+
+	codeInvalidParam = "invalid_param"
+	codeNotFound     = "not_found"
+	codeInternal     = "internal"
+	codeCanceled     = "canceled"
+	codeTimeout      = "timeout"
+	codeSlowConsumer = "slow_consumer"
+	codeUnauthorized = "unauthorized"
+	codeForbidden    = "forbidden"
+	codeRateLimited  = "rate_limited"
+	codeUnavailable  = "unavailable"
+	codeTooLarge     = "too_large"
+	codeConflict     = "conflict"
+	codeUnknown      = "unknown"
+)
+
+// toProblem maps typed errors to a status code and a stable error code. Internal errors and errors of
+// unknown type are reported without their message so that storage and driver details do not leak to clients.
+func toProblem(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, entities.ErrInvalidParam):
+		return http.StatusBadRequest, codeInvalidParam, err.Error()
+	case errors.Is(err, entities.ErrNotFound):
+		return http.StatusNotFound, codeNotFound, err.Error()
+	case errors.Is(err, entities.ErrUnauthorized):
+		return http.StatusUnauthorized, codeUnauthorized, err.Error()
+	case errors.Is(err, entities.ErrForbidden):
+		return http.StatusForbidden, codeForbidden, err.Error()
+	case errors.Is(err, entities.ErrRateLimited):
+		return http.StatusTooManyRequests, codeRateLimited, err.Error()
+	case errors.Is(err, entities.ErrTooLarge):
+		return http.StatusRequestEntityTooLarge, codeTooLarge, err.Error()
+	case errors.Is(err, entities.ErrConflict):
+		return http.StatusConflict, codeConflict, err.Error()
+	case errors.Is(err, entities.ErrUnavailable):
+		return http.StatusServiceUnavailable, codeUnavailable, err.Error()
+	case errors.Is(err, entities.ErrInternal):
+		return http.StatusInternalServerError, codeInternal, "internal error"
+	case errors.Is(err, broker.ErrSlowConsumer):
+		return http.StatusServiceUnavailable, codeSlowConsumer, err.Error()
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest, codeCanceled, "request was canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, codeTimeout, "request timed out"
+	default:
+		return http.StatusInternalServerError, codeUnknown, "unexpected error"
+	}
+}
+
+// newProblem builds the problem details describing err for the given request.
+func newProblem(req *http.Request, err error) dto.ErrorResponseDTO {
+	status, code, detail := toProblem(err)
+
+	title := http.StatusText(status)
+	if status == statusClientClosedRequest {
+		title = "Client Closed Request"
+	}
+
+	return dto.ErrorResponseDTO{
+		Type:      problemTypePrefix + code,
+		Title:     title,
+		Status:    status,
+		Detail:    detail,
+		Instance:  req.URL.Path,
+		Code:      code,
+		RequestID: middleware.GetReqID(req.Context()),
+	}
+}
+
+func (srv *Server) errProcessing(resp http.ResponseWriter, req *http.Request, err error) {
+	problem := newProblem(req, err)
+	if problem.Code == codeInternal || problem.Code == codeUnknown {
+		srv.logger.ErrorContext(req.Context(), "Request failed", "status", problem.Status, "code", problem.Code, "err", err)
+	}
+
+	problemData, err := json.Marshal(&problem)
+	if err != nil {
+		err := errors.Wrapf(entities.ErrInternal, "marshal failure: %v", err)
+		srv.logger.ErrorContext(req.Context(), err.Error())
+		http.Error(resp, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp.Header().Set("Content-Type", problemContentType)
+	resp.WriteHeader(problem.Status)
+	resp.Write(problemData) //nolint:errcheck,gosec //ok
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// liveness only tells that the process serves HTTP, dependencies are checked by readiness.
+func (srv *Server) liveness(w http.ResponseWriter, r *http.Request) {
+	srv.jsonResponse(w, dto.HealthResponseDTO{Status: string(entities.HealthStatusHealthy)})
+}
+
+// readiness reports every dependency. A degraded service still takes traffic, so only an unhealthy one
+// answers 503 and is taken out of rotation.
+func (srv *Server) readiness(w http.ResponseWriter, r *http.Request) {
+	health := srv.Service.Readiness(r.Context())
+
+	response := dto.HealthResponseDTO{
+		Status:       string(health.Status),
+		Dependencies: make(map[string]dto.DependencyHealthDTO, len(health.Dependencies)),
+	}
+	for _, dependency := range health.Dependencies {
+		response.Dependencies[dependency.Name] = dto.DependencyHealthDTO{
+			Status: string(dependency.Status),
+			Detail: dependency.Detail,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if health.Status == entities.HealthStatusUnhealthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		srv.logger.ErrorContext(r.Context(), "Unable to encode readiness response", "err", err)
+	}
+}
@@ -0,0 +1,76 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+func TestHealth_Liveness(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupServer(t)
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"status":"healthy"}`, rec.Body.String())
+}
+
+func TestHealth_Readiness(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		health *entities.Health
+		code   int
+	}{
+		"healthy": {entities.NewHealth(
+			entities.DependencyHealth{Name: "database", Status: entities.HealthStatusHealthy, Detail: "ping took 1ms"},
+		), http.StatusOK},
+		"degraded": {entities.NewHealth(
+			entities.DependencyHealth{Name: "database", Status: entities.HealthStatusHealthy},
+			entities.DependencyHealth{Name: "provider", Status: entities.HealthStatusDegraded, Detail: "circuit open"},
+		), http.StatusOK},
+		"unhealthy": {entities.NewHealth(
+			entities.DependencyHealth{Name: "database", Status: entities.HealthStatusUnhealthy, Detail: "ping failed"},
+		), http.StatusServiceUnavailable},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server, mockService := setupServer(t)
+			mockService.EXPECT().Readiness(gomock.Any()).Return(tc.health)
+
+			rec := serve(server, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			require.Equal(t, tc.code, rec.Code)
+			var response dto.HealthResponseDTO
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+			require.Equal(t, string(tc.health.Status), response.Status)
+			require.Len(t, response.Dependencies, len(tc.health.Dependencies))
+			for _, dependency := range tc.health.Dependencies {
+				require.Equal(t, dto.DependencyHealthDTO{
+					Status: string(dependency.Status),
+					Detail: dependency.Detail,
+				}, response.Dependencies[dependency.Name])
+			}
+		})
+	}
+}
+
+func TestHealth_ProbesSkipAuthentication(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+	mockService.EXPECT().Readiness(gomock.Any()).Return(entities.NewHealth())
+
+	require.Equal(t, http.StatusOK, serve(server, httptest.NewRequest(http.MethodGet, "/healthz", nil)).Code)
+	require.Equal(t, http.StatusOK, serve(server, httptest.NewRequest(http.MethodGet, "/readyz", nil)).Code)
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	scopeRatesRead       = "rates:read"
+	scopePortfoliosRead  = "portfolios:read"
+	scopePortfoliosWrite = "portfolios:write"
+	scopeAdminRead       = "admin:read"
+	scopeAdminWrite      = "admin:write"
+
+	jwtLeeway = 30 * time.Second
+)
+
+var jwtMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+type scopesKey struct{}
+
+// scopeList accepts scopes given either as a JSON array or as a space separated string.
+type scopeList []string
+
+func (s *scopeList) UnmarshalJSON(data []byte) error {
+	var scopes []string
+	if err := json.Unmarshal(data, &scopes); err == nil {
+		*s = scopes
+		return nil
+	}
+
+	var scope string
+	if err := json.Unmarshal(data, &scope); err != nil {
+		return err
+	}
+	*s = strings.Fields(scope)
+	return nil
+}
+
+// tokenClaims reads scopes from the OAuth 2.0 "scope" claim and the "scp" claim used by some identity providers.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Scope scopeList `json:"scope"`
+	Scp   scopeList `json:"scp"`
+}
+
+func (c *tokenClaims) scopes() map[string]struct{} {
+	scopes := make(map[string]struct{}, len(c.Scope)+len(c.Scp))
+	for _, scope := range c.Scope {
+		scopes[scope] = struct{}{}
+	}
+	for _, scope := range c.Scp {
+		scopes[scope] = struct{}{}
+	}
+	return scopes
+}
+
+// WithJWTAuth requires a bearer JWT signed by one of the keys in the key set on every API route and
+// enforces the scopes of each route. Empty issuer or audience are not checked.
+func WithJWTAuth(keys KeySet, issuer, audience string) ServerOption {
+	return func(s *Server) {
+		s.jwtKeys = keys
+		s.jwtIssuer = issuer
+		s.jwtAudience = audience
+	}
+}
+
+func (srv *Server) newJWTParser() *jwt.Parser {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(jwtMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(jwtLeeway),
+	}
+	if srv.jwtIssuer != "" {
+		opts = append(opts, jwt.WithIssuer(srv.jwtIssuer))
+	}
+	if srv.jwtAudience != "" {
+		opts = append(opts, jwt.WithAudience(srv.jwtAudience))
+	}
+	return jwt.NewParser(opts...)
+}
+
+func (srv *Server) authenticateJWT(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || raw == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			srv.errProcessing(w, r, errors.Wrap(entities.ErrUnauthorized, "bearer token is missing"))
+			return
+		}
+
+		claims := &tokenClaims{}
+		_, err := srv.jwtParser.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+			return srv.jwtKeys.Key(r.Context(), kid)
+		})
+		if err != nil {
+			srv.logger.WarnContext(r.Context(), "Bearer token rejected", "path", r.URL.Path, "err", err)
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			srv.errProcessing(w, r, errors.Wrapf(entities.ErrUnauthorized, "invalid bearer token: %v", err))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), scopesKey{}, claims.scopes())))
+	})
+}
+
+// requireScope rejects requests whose bearer token lacks scope. It does nothing unless JWT authentication is enabled.
+func (srv *Server) requireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if srv.jwtKeys == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scopes, _ := r.Context().Value(scopesKey{}).(map[string]struct{})
+			if _, granted := scopes[scope]; !granted {
+				srv.logger.WarnContext(r.Context(), "Bearer token lacks scope", "path", r.URL.Path, "scope", scope)
+				w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+				srv.errProcessing(w, r, errors.Wrapf(entities.ErrForbidden, "scope %s is required", scope))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package http_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+	myhttp "Cryptoproject/internal/ports/http"
+	mocks "Cryptoproject/internal/ports/http/testdata"
+)
+
+const (
+	testIssuer   = "https://issuer.local"
+	testAudience = "rates-api"
+)
+
+type staticKeySet map[string]any
+
+func (ks staticKeySet) Key(_ context.Context, kid string) (any, error) {
+	if key, found := ks[kid]; found {
+		return key, nil
+	}
+	return nil, errors.Wrapf(entities.ErrUnauthorized, "signing key %q is unknown", kid)
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func setupJWTServer(t *testing.T, signingKey *rsa.PrivateKey) (*myhttp.Server, *mocks.MockService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+
+	keys := staticKeySet{"test-key": &signingKey.PublicKey}
+	server, err := myhttp.NewServer(":0", mockService, myhttp.WithJWTAuth(keys, testIssuer, testAudience))
+	require.NoError(t, err)
+
+	return server, mockService
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+
+	base := jwt.MapClaims{
+		"iss": testIssuer,
+		"aud": testAudience,
+		"sub": "service-a",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for name, value := range claims {
+		base[name] = value
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, base)
+	token.Header["kid"] = "test-key"
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func bearerRequest(method, target, token string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestJWT_ValidTokenWithScope(t *testing.T) {
+	t.Parallel()
+
+	key := generateKey(t)
+	server, mockService := setupJWTServer(t, key)
+
+	mockService.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC"}).Return([]*entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: time.Now()},
+	}, nil)
+
+	token := signToken(t, key, jwt.MapClaims{"scope": "rates:read portfolios:read"})
+	rec := serve(server, bearerRequest(http.MethodGet, "/v1/rates/last?titles=BTC", token))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestJWT_ScpArrayClaim(t *testing.T) {
+	t.Parallel()
+
+	key := generateKey(t)
+	server, mockService := setupJWTServer(t, key)
+
+	mockService.EXPECT().DeletePortfolio(gomock.Any(), int64(1)).Return(nil)
+
+	token := signToken(t, key, jwt.MapClaims{"scp": []string{"portfolios:write"}})
+	rec := serve(server, bearerRequest(http.MethodDelete, "/v1/portfolios/1", token))
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestJWT_MissingScope(t *testing.T) {
+	t.Parallel()
+
+	key := generateKey(t)
+	server, _ := setupJWTServer(t, key)
+
+	token := signToken(t, key, jwt.MapClaims{"scope": "rates:read"})
+	rec := serve(server, bearerRequest(http.MethodPost, "/v1/portfolios/", token))
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.Contains(t, rec.Header().Get("WWW-Authenticate"), `scope="portfolios:write"`)
+	require.Equal(t, "forbidden", decodeProblem(t, rec).Code)
+}
+
+func TestJWT_AdminScopes(t *testing.T) {
+	t.Parallel()
+
+	key := generateKey(t)
+	server, mockService := setupJWTServer(t, key)
+
+	mockService.EXPECT().ListAPIKeys(gomock.Any()).Return(nil, nil)
+
+	readToken := signToken(t, key, jwt.MapClaims{"scope": "admin:read"})
+	require.Equal(t, http.StatusOK, serve(server, bearerRequest(http.MethodGet, "/v1/admin/keys", readToken)).Code)
+	require.Equal(t, http.StatusForbidden, serve(server, bearerRequest(http.MethodDelete, "/v1/admin/keys/1", readToken)).Code)
+}
+
+func TestJWT_RejectedTokens(t *testing.T) {
+	t.Parallel()
+
+	key := generateKey(t)
+	otherKey := generateKey(t)
+
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": testIssuer, "aud": testAudience, "exp": time.Now().Add(time.Hour).Unix(), "scope": "rates:read",
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	for name, token := range map[string]string{
+		"missing":         "",
+		"malformed":       "not-a-jwt",
+		"expired":         signToken(t, key, jwt.MapClaims{"scope": "rates:read", "exp": time.Now().Add(-time.Hour).Unix()}),
+		"no expiry":       signToken(t, key, jwt.MapClaims{"scope": "rates:read", "exp": nil}),
+		"wrong issuer":    signToken(t, key, jwt.MapClaims{"scope": "rates:read", "iss": "https://evil.local"}),
+		"wrong audience":  signToken(t, key, jwt.MapClaims{"scope": "rates:read", "aud": "other-api"}),
+		"wrong key":       signToken(t, otherKey, jwt.MapClaims{"scope": "rates:read"}),
+		"symmetric token": hmacToken,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server, _ := setupJWTServer(t, key)
+
+			rec := serve(server, bearerRequest(http.MethodGet, "/v1/rates/last?titles=BTC", token))
+
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+			require.Contains(t, rec.Header().Get("WWW-Authenticate"), "Bearer")
+			require.Equal(t, "unauthorized", decodeProblem(t, rec).Code)
+		})
+	}
+}
+
+func TestJWT_CannotCombineWithAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	_, err := myhttp.NewServer(":0", mocks.NewMockService(ctrl),
+		myhttp.WithAPIKeyAuth("admin"),
+		myhttp.WithJWTAuth(staticKeySet{}, "", ""),
+	)
+
+	require.ErrorIs(t, err, entities.ErrInvalidParam)
+}
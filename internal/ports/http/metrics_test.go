@@ -0,0 +1,82 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/metrics"
+	"Cryptoproject/internal/entities"
+	myhttp "Cryptoproject/internal/ports/http"
+	mocks "Cryptoproject/internal/ports/http/testdata"
+)
+
+func setupMetricsServer(t *testing.T, opts ...myhttp.ServerOption) (*myhttp.Server, *mocks.MockService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+
+	m, err := metrics.NewMetrics()
+	require.NoError(t, err)
+
+	server, err := myhttp.NewServer(":0", mockService, append(opts, myhttp.WithMetrics(m))...)
+	require.NoError(t, err)
+
+	return server, mockService
+}
+
+func TestMetrics_RequestsByRoute(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupMetricsServer(t)
+
+	actualAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService.EXPECT().GetCoinHistory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]*entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: actualAt},
+	}, nil).Times(2)
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/coins/BTC/rates", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/coins/ETH/rates", nil)
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+	require.Equal(t, http.StatusNotModified, serve(server, req).Code)
+
+	require.Equal(t, http.StatusNotFound, serve(server, httptest.NewRequest(http.MethodGet, "/v1/unknown", nil)).Code)
+
+	rec = serve(server, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	require.Contains(t, body, `cryptoproject_http_requests_total{method="GET",route="/v1/coins/{title}/rates",status="200"} 1`)
+	require.Contains(t, body, `cryptoproject_http_requests_total{method="GET",route="/v1/coins/{title}/rates",status="304"} 1`)
+	require.Contains(t, body, `route="/v1/*",status="404"`)
+	require.NotContains(t, body, "/v1/coins/BTC/rates")
+	require.Contains(t, body, `cryptoproject_http_cache_lookups_total{result="hit"} 1`)
+	require.Contains(t, body, `cryptoproject_http_cache_lookups_total{result="miss"} 1`)
+}
+
+func TestMetrics_EndpointSkipsAuthentication(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupMetricsServer(t, myhttp.WithAPIKeyAuth(adminToken))
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "go_goroutines")
+}
+
+func TestMetrics_DisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupServer(t)
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
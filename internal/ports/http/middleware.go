@@ -0,0 +1,145 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/adapters/logging"
+	"Cryptoproject/internal/entities"
+)
+
+const (
+	defaultRequestTimeout = 30 * time.Second
+	defaultMaxBodyBytes   = 1 << 20
+)
+
+// CORSConfig lists what cross-origin browser clients may do. CORS stays disabled without allowed origins.
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// WithRequestTimeout bounds the time a request may take unless a route timeout overrides it.
+// Streaming routes are never bounded.
+func WithRequestTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.requestTimeout = timeout
+	}
+}
+
+// WithRouteTimeout overrides the request timeout of one route, given as its pattern without the
+// version prefix, for example "/portfolio/value".
+func WithRouteTimeout(route string, timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		if s.routeTimeouts == nil {
+			s.routeTimeouts = make(map[string]time.Duration)
+		}
+		s.routeTimeouts[route] = timeout
+	}
+}
+
+// WithMaxBodyBytes limits the size of request bodies.
+func WithMaxBodyBytes(limit int64) ServerOption {
+	return func(s *Server) {
+		s.maxBodyBytes = limit
+	}
+}
+
+func WithCORS(cfg CORSConfig) ServerOption {
+	return func(s *Server) {
+		s.cors = cfg
+	}
+}
+
+// logContext attaches the request ID to every record logged with the request context.
+func logContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := logging.WithAttrs(r.Context(), "request_id", middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (srv *Server) accessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		srv.logger.Log(r.Context(), level, "HTTP request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"route", chi.RouteContext(r.Context()).RoutePattern(),
+			"status", status,
+			"bytes", ww.BytesWritten(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"remote_addr", r.RemoteAddr,
+			"user_agent", r.UserAgent(),
+		)
+	})
+}
+
+// recoverer turns a panicking handler into a 500 problem response instead of a dropped connection.
+func (srv *Server) recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler { //nolint:errorlint //sentinel panic value
+				panic(rec)
+			}
+
+			srv.logger.ErrorContext(r.Context(), "Handler panicked", "panic", rec, "stack", string(debug.Stack()))
+			srv.errProcessing(w, r, errors.Wrap(entities.ErrInternal, "request handler failed"))
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// timeout bounds the request context by the timeout of the matched route.
+func (srv *Server) timeout(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := srv.Router.Find(chi.NewRouteContext(), r.Method, r.URL.Path)
+		timeout, found := srv.routeTimeouts[strings.TrimPrefix(route, "/v1")]
+		if !found {
+			timeout = srv.requestTimeout
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (srv *Server) corsHandler() func(http.Handler) http.Handler {
+	return cors.Handler(cors.Options{
+		AllowedOrigins:   srv.cors.AllowedOrigins,
+		AllowedMethods:   srv.cors.AllowedMethods,
+		AllowedHeaders:   srv.cors.AllowedHeaders,
+		ExposedHeaders:   []string{"ETag", "Last-Modified", "Retry-After", "Deprecation", "Link", middleware.RequestIDHeader},
+		AllowCredentials: srv.cors.AllowCredentials,
+		MaxAge:           int(srv.cors.MaxAge.Seconds()),
+	})
+}
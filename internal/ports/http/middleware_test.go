@@ -0,0 +1,188 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/logging"
+	"Cryptoproject/internal/entities"
+	myhttp "Cryptoproject/internal/ports/http"
+	mocks "Cryptoproject/internal/ports/http/testdata"
+)
+
+func setupServerWith(t *testing.T, opts ...myhttp.ServerOption) (*myhttp.Server, *mocks.MockService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+
+	server, err := myhttp.NewServer(":0", mockService, opts...)
+	require.NoError(t, err)
+
+	return server, mockService
+}
+
+func TestMiddleware_PanicRecovery(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupServer(t)
+	mockService.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC"}).DoAndReturn(
+		func(context.Context, []string) ([]*entities.Coin, error) {
+			panic("nil map write")
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC", nil)
+	req.Header.Set("X-Request-Id", "req-panic")
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	problem := decodeProblem(t, rec)
+	require.Equal(t, "internal", problem.Code)
+	require.Equal(t, "req-panic", problem.RequestID)
+	require.NotContains(t, problem.Detail, "nil map write")
+}
+
+func TestMiddleware_BodyLimit(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupServerWith(t, myhttp.WithMaxBodyBytes(32))
+	body := `{"titles":["` + strings.Repeat("B", 64) + `"]}`
+
+	rec := serve(server, httptest.NewRequest(http.MethodPost, "/v1/rates/last", strings.NewReader(body)))
+	require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	require.Equal(t, "too_large", decodeProblem(t, rec).Code)
+
+	// Without a declared length the limit is enforced while the body is read.
+	req := httptest.NewRequest(http.MethodPost, "/v1/rates/last", io.MultiReader(strings.NewReader(body)))
+	req.ContentLength = -1
+	rec = serve(server, req)
+	require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestMiddleware_RouteTimeout(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupServerWith(t,
+		myhttp.WithRequestTimeout(time.Minute),
+		myhttp.WithRouteTimeout("/rates/last", 20*time.Millisecond),
+	)
+	mockService.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC"}).DoAndReturn(
+		func(ctx context.Context, _ []string) ([]*entities.Coin, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).Times(2)
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC", nil))
+	require.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	require.Equal(t, "timeout", decodeProblem(t, rec).Code)
+
+	// Legacy routes share the timeout of their versioned successor.
+	rec = serve(server, httptest.NewRequest(http.MethodGet, "/rates/last?titles=BTC", nil))
+	require.Equal(t, http.StatusGatewayTimeout, rec.Code)
+}
+
+func TestMiddleware_DefaultTimeout(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupServerWith(t, myhttp.WithRequestTimeout(time.Minute))
+	mockService.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC"}).DoAndReturn(
+		func(ctx context.Context, _ []string) ([]*entities.Coin, error) {
+			deadline, found := ctx.Deadline()
+			require.True(t, found)
+			require.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+			return []*entities.Coin{{Title: "BTC", Cost: 50000, ActualAt: time.Now()}}, nil
+		})
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMiddleware_CORS(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupServerWith(t, myhttp.WithCORS(myhttp.CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{"Content-Type", "X-API-Key"},
+		MaxAge:         10 * time.Minute,
+	}))
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/v1/rates/last", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "X-API-Key")
+		return serve(server, req)
+	}
+
+	rec := preflight("https://app.example.com")
+	require.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+
+	rec = preflight("https://evil.example.com")
+	require.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestMiddleware_CORSDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestMiddleware_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	for name, opt := range map[string]myhttp.ServerOption{
+		"request timeout": myhttp.WithRequestTimeout(0),
+		"route timeout":   myhttp.WithRouteTimeout("/rates/last", -time.Second),
+		"body limit":      myhttp.WithMaxBodyBytes(0),
+	} {
+		_, err := myhttp.NewServer(":0", mocks.NewMockService(ctrl), opt)
+		require.ErrorIs(t, err, entities.ErrInvalidParam, name)
+	}
+}
+
+func TestMiddleware_AccessLog(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := logging.NewLogger(logging.WithFormat(logging.FormatJSON), logging.WithOutput(&buf))
+	require.NoError(t, err)
+
+	server, _ := setupServerWith(t, myhttp.WithLogger(logger))
+	req := httptest.NewRequest(http.MethodGet, "/v1/rates/last", nil)
+	req.Header.Set("X-Request-Id", "req-log")
+	rec := serve(server, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var access map[string]any
+	for decoder := json.NewDecoder(&buf); decoder.More(); {
+		var record map[string]any
+		require.NoError(t, decoder.Decode(&record))
+		require.Equal(t, "req-log", record["request_id"])
+		if record["msg"] == "HTTP request" {
+			access = record
+		}
+	}
+	require.NotNil(t, access)
+	require.Equal(t, "/v1/rates/last", access["route"])
+	require.Equal(t, float64(http.StatusBadRequest), access["status"])
+}
@@ -0,0 +1,328 @@
+package http
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// @Summary Value holdings
+// @Description Values the given holdings (title → quantity) with the latest rates, or with the rates stored
+// @Description at or before the given time. P&L is reported for positions with a cost basis.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Param request body dto.PortfolioValueRequestDTO true "Holdings, optional cost basis per unit and optional valuation time"
+// @Success 200 {object} dto.PortfolioValuationDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /portfolio/value [post]
+func (srv *Server) valuePortfolio(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req dto.PortfolioValueRequestDTO
+	if err := srv.decodeRequest(w, r, &req); err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to decode request", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	valuation, err := srv.Service.ValuePortfolio(r.Context(), srv.toPositions(req.Holdings, req.CostBasis), req.At)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to value portfolio", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	srv.jsonResponse(w, srv.toValuationDTO(0, valuation))
+	srv.logger.DebugContext(r.Context(), "Successfully valued portfolio", "total_value", valuation.TotalValue)
+}
+
+// @Summary List portfolios
+// @Description Lists all saved portfolios.
+// @Tags Portfolios
+// @Produce json
+// @Success 200 {object} dto.PortfoliosResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /portfolios [get]
+func (srv *Server) listPortfolios(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	portfolios, err := srv.Service.ListPortfolios(r.Context())
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to list portfolios", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	dtos := make([]dto.PortfolioDTO, len(portfolios))
+	for i, portfolio := range portfolios {
+		dtos[i] = srv.toPortfolioDTO(portfolio)
+	}
+
+	srv.jsonResponse(w, dto.PortfoliosResponseDTO{Portfolios: dtos})
+	srv.logger.DebugContext(r.Context(), "Successfully listed portfolios", "number_of_portfolios", len(dtos))
+}
+
+// @Summary Create portfolio
+// @Description Saves a new portfolio.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Param request body dto.PortfolioRequestDTO true "Portfolio name, holdings and optional cost basis per unit"
+// @Success 201 {object} dto.PortfolioDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /portfolios [post]
+func (srv *Server) createPortfolio(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req dto.PortfolioRequestDTO
+	if err := srv.decodeRequest(w, r, &req); err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to decode request", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	portfolio, err := srv.Service.CreatePortfolio(r.Context(), req.Name, srv.toPositions(req.Holdings, req.CostBasis))
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to create portfolio", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	srv.jsonResponse(w, srv.toPortfolioDTO(portfolio))
+	srv.logger.DebugContext(r.Context(), "Successfully created portfolio", "portfolio_id", portfolio.ID)
+}
+
+// @Summary Get portfolio
+// @Description Retrieves a saved portfolio.
+// @Tags Portfolios
+// @Produce json
+// @Param id path int true "Portfolio ID"
+// @Success 200 {object} dto.PortfolioDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /portfolios/{id} [get]
+func (srv *Server) getPortfolio(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := srv.portfolioID(r)
+	if err != nil {
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	portfolio, err := srv.Service.GetPortfolio(r.Context(), id)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to get portfolio", "portfolio_id", id, "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	srv.jsonResponse(w, srv.toPortfolioDTO(portfolio))
+}
+
+// @Summary Update portfolio
+// @Description Replaces the name and holdings of a saved portfolio.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Param id path int true "Portfolio ID"
+// @Param request body dto.PortfolioRequestDTO true "Portfolio name, holdings and optional cost basis per unit"
+// @Success 200 {object} dto.PortfolioDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /portfolios/{id} [put]
+func (srv *Server) updatePortfolio(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := srv.portfolioID(r)
+	if err != nil {
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	var req dto.PortfolioRequestDTO
+	if err := srv.decodeRequest(w, r, &req); err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to decode request", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	portfolio, err := srv.Service.UpdatePortfolio(r.Context(), id, req.Name, srv.toPositions(req.Holdings, req.CostBasis))
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to update portfolio", "portfolio_id", id, "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	srv.jsonResponse(w, srv.toPortfolioDTO(portfolio))
+	srv.logger.DebugContext(r.Context(), "Successfully updated portfolio", "portfolio_id", id)
+}
+
+// @Summary Delete portfolio
+// @Description Deletes a saved portfolio.
+// @Tags Portfolios
+// @Param id path int true "Portfolio ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /portfolios/{id} [delete]
+func (srv *Server) deletePortfolio(w http.ResponseWriter, r *http.Request) {
+	id, err := srv.portfolioID(r)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	if err := srv.Service.DeletePortfolio(r.Context(), id); err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to delete portfolio", "portfolio_id", id, "err", err)
+		w.Header().Set("Content-Type", "application/json")
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	srv.logger.DebugContext(r.Context(), "Successfully deleted portfolio", "portfolio_id", id)
+}
+
+// @Summary Value saved portfolio
+// @Description Values a saved portfolio with the latest rates, or with the rates stored at or before the given time.
+// @Tags Portfolios
+// @Produce json
+// @Param id path int true "Portfolio ID"
+// @Param at query string false "RFC 3339 time for a historical valuation"
+// @Success 200 {object} dto.PortfolioValuationDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /portfolios/{id}/value [get]
+func (srv *Server) valueStoredPortfolio(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := srv.portfolioID(r)
+	if err != nil {
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	var at time.Time
+	if rawAt := r.URL.Query().Get("at"); rawAt != "" {
+		at, err = time.Parse(time.RFC3339, rawAt)
+		if err != nil {
+			srv.logger.ErrorContext(r.Context(), "Invalid valuation time provided", "at", rawAt, "err", err)
+			srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "invalid valuation time"))
+			return
+		}
+	}
+
+	portfolio, valuation, err := srv.Service.ValueStoredPortfolio(r.Context(), id, at)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to value portfolio", "portfolio_id", id, "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	srv.jsonResponse(w, srv.toValuationDTO(portfolio.ID, valuation))
+	srv.logger.DebugContext(r.Context(), "Successfully valued portfolio", "portfolio_id", id, "total_value", valuation.TotalValue)
+}
+
+func (srv *Server) portfolioID(r *http.Request) (int64, error) {
+	rawID := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil || id <= 0 {
+		srv.logger.ErrorContext(r.Context(), "Invalid portfolio id provided", "id", rawID)
+		return 0, errors.Wrap(entities.ErrInvalidParam, "invalid portfolio id")
+	}
+	return id, nil
+}
+
+func (srv *Server) toPositions(holdings, costBasis map[string]float64) []entities.Position {
+	titles := make([]string, 0, len(holdings))
+	for title := range holdings {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+
+	positions := make([]entities.Position, len(titles))
+	for i, title := range titles {
+		positions[i] = entities.Position{
+			Title:     title,
+			Quantity:  holdings[title],
+			CostBasis: costBasis[title],
+		}
+	}
+	return positions
+}
+
+func (srv *Server) toPortfolioDTO(portfolio *entities.Portfolio) dto.PortfolioDTO {
+	portfolioDTO := dto.PortfolioDTO{
+		ID:        portfolio.ID,
+		Name:      portfolio.Name,
+		Holdings:  make(map[string]float64, len(portfolio.Positions)),
+		CostBasis: make(map[string]float64),
+		CreatedAt: portfolio.CreatedAt,
+		UpdatedAt: portfolio.UpdatedAt,
+	}
+	for _, position := range portfolio.Positions {
+		portfolioDTO.Holdings[position.Title] = position.Quantity
+		if position.CostBasis > 0 {
+			portfolioDTO.CostBasis[position.Title] = position.CostBasis
+		}
+	}
+	return portfolioDTO
+}
+
+func (srv *Server) toValuationDTO(portfolioID int64, valuation *entities.PortfolioValuation) dto.PortfolioValuationDTO {
+	positions := make([]dto.PositionValuationDTO, len(valuation.Positions))
+	for i, position := range valuation.Positions {
+		positions[i] = dto.PositionValuationDTO{
+			Title:      position.Title,
+			Quantity:   position.Quantity,
+			CostBasis:  position.CostBasis,
+			Price:      position.Price,
+			PriceAt:    position.PriceAt,
+			Value:      position.Value,
+			Cost:       position.Cost,
+			PnL:        position.PnL,
+			PnLPercent: position.PnLPercent,
+		}
+	}
+
+	return dto.PortfolioValuationDTO{
+		PortfolioID: portfolioID,
+		At:          valuation.At,
+		Positions:   positions,
+		TotalValue:  valuation.TotalValue,
+		TotalCost:   valuation.TotalCost,
+		TotalPnL:    valuation.TotalPnL,
+	}
+}
@@ -0,0 +1,198 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+	mocks "Cryptoproject/internal/ports/http/testdata"
+	"Cryptoproject/pkg/dto"
+)
+
+func TestServer_CreatePortfolio(t *testing.T) {
+	t.Parallel()
+
+	positions := []entities.Position{{Title: "BTC", Quantity: 0.5, CostBasis: 40000}}
+	for name, tc := range map[string]struct {
+		body   string
+		expect func(*mocks.MockService)
+		status int
+		code   string
+		detail string
+	}{
+		"created": {
+			body: `{"name":"main","holdings":{"BTC":0.5},"costBasis":{"BTC":40000}}`,
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().CreatePortfolio(gomock.Any(), "main", positions).
+					Return(&entities.Portfolio{ID: 7, Name: "main", Positions: positions}, nil)
+			},
+			status: http.StatusCreated,
+		},
+		"malformed body": {`{"name":`, nil, http.StatusBadRequest, "invalid_param", ""},
+		"name too long": {
+			body: `{"name":"` + strings.Repeat("a", 101) + `","holdings":{"BTC":0.5}}`,
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().CreatePortfolio(gomock.Any(), strings.Repeat("a", 101), gomock.Any()).
+					Return(nil, errors.Wrap(entities.ErrInvalidParam, "invalid portfolio: name cannot be longer than 100 characters"))
+			},
+			status: http.StatusBadRequest,
+			code:   "invalid_param",
+			detail: "invalid portfolio: name cannot be longer than 100 characters: invalid param",
+		},
+		"storage failure": {
+			body: `{"name":"main","holdings":{"BTC":0.5}}`,
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().CreatePortfolio(gomock.Any(), "main", gomock.Any()).
+					Return(nil, errors.Wrap(entities.ErrInternal, "failed to create portfolio"))
+			},
+			status: http.StatusInternalServerError,
+			code:   "internal",
+			detail: "internal error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server, mockService := setupServer(t)
+			if tc.expect != nil {
+				tc.expect(mockService)
+			}
+
+			rec := serve(server, httptest.NewRequest(http.MethodPost, "/v1/portfolios/", strings.NewReader(tc.body)))
+
+			require.Equal(t, tc.status, rec.Code)
+			if tc.status == http.StatusCreated {
+				var portfolio dto.PortfolioDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&portfolio))
+				require.Equal(t, int64(7), portfolio.ID)
+				require.Equal(t, map[string]float64{"BTC": 0.5}, portfolio.Holdings)
+				return
+			}
+			problem := decodeProblem(t, rec)
+			require.Equal(t, tc.code, problem.Code)
+			if tc.detail != "" {
+				require.Equal(t, tc.detail, problem.Detail)
+			}
+		})
+	}
+}
+
+func TestServer_GetPortfolio(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		path   string
+		expect func(*mocks.MockService)
+		status int
+		code   string
+	}{
+		"found": {
+			path: "/v1/portfolios/7",
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().GetPortfolio(gomock.Any(), int64(7)).Return(&entities.Portfolio{ID: 7, Name: "main"}, nil)
+			},
+			status: http.StatusOK,
+		},
+		"non-numeric id": {"/v1/portfolios/main", nil, http.StatusBadRequest, "invalid_param"},
+		"zero id":        {"/v1/portfolios/0", nil, http.StatusBadRequest, "invalid_param"},
+		"not found": {
+			path: "/v1/portfolios/8",
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().GetPortfolio(gomock.Any(), int64(8)).Return(nil, errors.Wrap(entities.ErrNotFound, "portfolio 8 not found"))
+			},
+			status: http.StatusNotFound,
+			code:   "not_found",
+		},
+		"storage failure": {
+			path: "/v1/portfolios/9",
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().GetPortfolio(gomock.Any(), int64(9)).Return(nil, errors.Wrap(entities.ErrInternal, "failed to get portfolio"))
+			},
+			status: http.StatusInternalServerError,
+			code:   "internal",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server, mockService := setupServer(t)
+			if tc.expect != nil {
+				tc.expect(mockService)
+			}
+
+			rec := serve(server, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			require.Equal(t, tc.status, rec.Code)
+			if tc.status == http.StatusOK {
+				var portfolio dto.PortfolioDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&portfolio))
+				require.Equal(t, "main", portfolio.Name)
+				return
+			}
+			problem := decodeProblem(t, rec)
+			require.Equal(t, tc.code, problem.Code)
+			require.Equal(t, tc.path, problem.Instance)
+		})
+	}
+}
+
+func TestServer_DeletePortfolio(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		path   string
+		expect func(*mocks.MockService)
+		status int
+		code   string
+	}{
+		"deleted": {
+			path: "/v1/portfolios/7",
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().DeletePortfolio(gomock.Any(), int64(7)).Return(nil)
+			},
+			status: http.StatusNoContent,
+		},
+		"negative id": {"/v1/portfolios/-7", nil, http.StatusBadRequest, "invalid_param"},
+		"not found": {
+			path: "/v1/portfolios/8",
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().DeletePortfolio(gomock.Any(), int64(8)).Return(errors.Wrap(entities.ErrNotFound, "portfolio 8 not found"))
+			},
+			status: http.StatusNotFound,
+			code:   "not_found",
+		},
+		"storage failure": {
+			path: "/v1/portfolios/9",
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().DeletePortfolio(gomock.Any(), int64(9)).Return(errors.Wrap(entities.ErrInternal, "failed to delete portfolio"))
+			},
+			status: http.StatusInternalServerError,
+			code:   "internal",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server, mockService := setupServer(t)
+			if tc.expect != nil {
+				tc.expect(mockService)
+			}
+
+			rec := serve(server, httptest.NewRequest(http.MethodDelete, tc.path, nil))
+
+			require.Equal(t, tc.status, rec.Code)
+			if tc.status == http.StatusNoContent {
+				require.Zero(t, rec.Body.Len())
+				return
+			}
+			require.Equal(t, tc.code, decodeProblem(t, rec).Code)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// @Summary Get data quality report
+// @Description Scans stored rates for gaps longer than the update interval allows, jumps beyond the configured number of standard deviations and duplicate timestamps.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Security BearerAuth
+// @Param from query string false "Start of the window (RFC 3339), defaults to the quality window before to"
+// @Param to query string false "End of the window (RFC 3339), defaults to now"
+// @Success 200 {object} dto.QualityReportDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /admin/quality [get]
+func (srv *Server) getQualityReport(w http.ResponseWriter, r *http.Request) {
+	var from, to time.Time
+	var err error
+	if rawFrom := r.URL.Query().Get("from"); rawFrom != "" {
+		if from, err = time.Parse(time.RFC3339, rawFrom); err != nil {
+			srv.logger.ErrorContext(r.Context(), "Invalid window start provided", "from", rawFrom, "err", err)
+			srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "invalid from time"))
+			return
+		}
+	}
+	if rawTo := r.URL.Query().Get("to"); rawTo != "" {
+		if to, err = time.Parse(time.RFC3339, rawTo); err != nil {
+			srv.logger.ErrorContext(r.Context(), "Invalid window end provided", "to", rawTo, "err", err)
+			srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "invalid to time"))
+			return
+		}
+	}
+
+	report, err := srv.Service.GetQualityReport(r.Context(), from, to)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to get quality report", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	reportDTO := dto.QualityReportDTO{
+		From:                    report.From,
+		To:                      report.To,
+		ExpectedIntervalSeconds: report.ExpectedInterval.Seconds(),
+		JumpSigma:               report.JumpSigma,
+		Counts:                  make(map[string]int, len(entities.QualityIssueKinds)),
+		Issues:                  make([]dto.QualityIssueDTO, len(report.Issues)),
+	}
+	for _, kind := range entities.QualityIssueKinds {
+		reportDTO.Counts[string(kind)] = 0
+	}
+	for i, issue := range report.Issues {
+		reportDTO.Counts[string(issue.Kind)]++
+		reportDTO.Issues[i] = dto.QualityIssueDTO{
+			Title:    issue.Title,
+			Kind:     string(issue.Kind),
+			From:     issue.From,
+			To:       issue.To,
+			FromCost: issue.FromCost,
+			ToCost:   issue.ToCost,
+			Sigmas:   issue.Sigmas,
+			Count:    issue.Count,
+		}
+	}
+
+	srv.jsonResponse(w, reportDTO)
+	srv.logger.DebugContext(r.Context(), "Successfully retrieved quality report", "number_of_issues", len(report.Issues))
+}
@@ -0,0 +1,60 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+func TestAdmin_GetQualityReport(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	mockService.EXPECT().GetQualityReport(gomock.Any(), from, to).Return(&entities.QualityReport{
+		From: from, To: to, ExpectedInterval: 5 * time.Minute, JumpSigma: 4,
+		Issues: []entities.QualityIssue{
+			{Title: "BTC", Kind: entities.QualityJump, From: from, To: from.Add(5 * time.Minute), FromCost: 60000, ToCost: 600, Sigmas: 9.5},
+			{Title: "ETH", Kind: entities.QualityGap, From: from, To: from.Add(time.Hour)},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/quality?from=2026-03-01T00:00:00Z&to=2026-03-02T00:00:00Z", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var report dto.QualityReportDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+	require.Equal(t, dto.QualityReportDTO{
+		From: from, To: to, ExpectedIntervalSeconds: 300, JumpSigma: 4,
+		Counts: map[string]int{"gap": 1, "jump": 1, "duplicate": 0},
+		Issues: []dto.QualityIssueDTO{
+			{Title: "BTC", Kind: "jump", From: from, To: from.Add(5 * time.Minute), FromCost: 60000, ToCost: 600, Sigmas: 9.5},
+			{Title: "ETH", Kind: "gap", From: from, To: from.Add(time.Hour)},
+		},
+	}, report)
+}
+
+func TestAdmin_GetQualityReport_Errors(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupAuthServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/quality?from=yesterday", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	require.Equal(t, http.StatusBadRequest, serve(server, req).Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/admin/quality", nil)
+	require.Equal(t, http.StatusUnauthorized, serve(server, req).Code)
+}
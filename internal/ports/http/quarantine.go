@@ -0,0 +1,118 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// @Summary List quarantined rates
+// @Description Lists rates held back from storage because they strayed too far from the recent rates of their coin.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Security BearerAuth
+// @Param status query string false "Review status: pending (default), approved or discarded"
+// @Success 200 {object} dto.QuarantinedRatesResponseDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /admin/quarantine [get]
+func (srv *Server) listQuarantinedRates(w http.ResponseWriter, r *http.Request) {
+	status := entities.QuarantinePending
+	if rawStatus := r.URL.Query().Get("status"); rawStatus != "" {
+		var err error
+		if status, err = entities.ParseQuarantineStatus(rawStatus); err != nil {
+			srv.logger.ErrorContext(r.Context(), "Invalid quarantine status provided", "status", rawStatus)
+			srv.errProcessing(w, r, err)
+			return
+		}
+	}
+
+	rates, err := srv.Service.ListQuarantinedRates(r.Context(), status)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to list quarantined rates", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	dtos := make([]dto.QuarantinedRateDTO, len(rates))
+	for i, rate := range rates {
+		dtos[i] = dto.QuarantinedRateDTO{
+			ID:            rate.ID,
+			Title:         rate.Title,
+			Cost:          rate.Cost,
+			ActualAt:      rate.ActualAt,
+			ReferenceCost: rate.ReferenceCost,
+			Deviation:     rate.Deviation(),
+			Status:        string(rate.Status),
+			QuarantinedAt: rate.QuarantinedAt,
+		}
+		if !rate.ReviewedAt.IsZero() {
+			reviewedAt := rate.ReviewedAt
+			dtos[i].ReviewedAt = &reviewedAt
+		}
+	}
+
+	srv.jsonResponse(w, dto.QuarantinedRatesResponseDTO{Rates: dtos})
+	srv.logger.DebugContext(r.Context(), "Successfully listed quarantined rates", "status", status, "number_of_rates", len(dtos))
+}
+
+// @Summary Approve quarantined rate
+// @Description Stores a pending quarantined rate with its original timestamp. When a newer rate was stored in its
+// @Description bucket meanwhile, the newer rate is kept, the quarantined rate stays pending and 409 is returned.
+// @Tags Admin
+// @Security AdminToken
+// @Security BearerAuth
+// @Param id path int true "Quarantined rate ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 409 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /admin/quarantine/{id}/approve [post]
+func (srv *Server) approveQuarantinedRate(w http.ResponseWriter, r *http.Request) {
+	srv.reviewQuarantinedRate(w, r, "approve", srv.Service.ApproveQuarantinedRate)
+}
+
+// @Summary Discard quarantined rate
+// @Description Drops a pending quarantined rate for good.
+// @Tags Admin
+// @Security AdminToken
+// @Security BearerAuth
+// @Param id path int true "Quarantined rate ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /admin/quarantine/{id}/discard [post]
+func (srv *Server) discardQuarantinedRate(w http.ResponseWriter, r *http.Request) {
+	srv.reviewQuarantinedRate(w, r, "discard", srv.Service.DiscardQuarantinedRate)
+}
+
+func (srv *Server) reviewQuarantinedRate(w http.ResponseWriter, r *http.Request, action string, review func(ctx context.Context, id int64) error) {
+	rawID := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil || id <= 0 {
+		srv.logger.ErrorContext(r.Context(), "Invalid quarantined rate id provided", "id", rawID)
+		srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "invalid quarantined rate id"))
+		return
+	}
+
+	if err := review(r.Context(), id); err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to review quarantined rate", "action", action, "quarantined_rate_id", id, "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	srv.logger.DebugContext(r.Context(), "Successfully reviewed quarantined rate", "action", action, "quarantined_rate_id", id)
+}
@@ -0,0 +1,74 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+func TestAdmin_ListQuarantinedRates(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+
+	quarantinedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	reviewedAt := quarantinedAt.Add(time.Hour)
+	mockService.EXPECT().ListQuarantinedRates(gomock.Any(), entities.QuarantineDiscarded).Return([]*entities.QuarantinedRate{{
+		ID: 7, Title: "BTC", Cost: 600, ActualAt: quarantinedAt, ReferenceCost: 60000,
+		Status: entities.QuarantineDiscarded, QuarantinedAt: quarantinedAt, ReviewedAt: reviewedAt,
+	}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/quarantine?status=discarded", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.QuarantinedRatesResponseDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, []dto.QuarantinedRateDTO{{
+		ID: 7, Title: "BTC", Cost: 600, ActualAt: quarantinedAt, ReferenceCost: 60000, Deviation: 0.99,
+		Status: "discarded", QuarantinedAt: quarantinedAt, ReviewedAt: &reviewedAt,
+	}}, resp.Rates)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/admin/quarantine?status=rejected", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	require.Equal(t, http.StatusBadRequest, serve(server, req).Code)
+}
+
+func TestAdmin_ReviewQuarantinedRate(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupAuthServer(t)
+
+	mockService.EXPECT().ApproveQuarantinedRate(gomock.Any(), int64(7)).Return(nil)
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/quarantine/7/approve", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	require.Equal(t, http.StatusNoContent, serve(server, req).Code)
+
+	mockService.EXPECT().DiscardQuarantinedRate(gomock.Any(), int64(8)).
+		Return(errors.Wrap(entities.ErrNotFound, "pending quarantined rate 8 not found"))
+	req = httptest.NewRequest(http.MethodPost, "/v1/admin/quarantine/8/discard", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	require.Equal(t, http.StatusNotFound, serve(server, req).Code)
+
+	mockService.EXPECT().ApproveQuarantinedRate(gomock.Any(), int64(9)).
+		Return(errors.Wrap(entities.ErrConflict, "a rate of BTC newer than quarantined rate 9 is stored in its bucket"))
+	req = httptest.NewRequest(http.MethodPost, "/v1/admin/quarantine/9/approve", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	rec := serve(server, req)
+	require.Equal(t, http.StatusConflict, rec.Code)
+	require.Equal(t, "conflict", decodeProblem(t, rec).Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/admin/quarantine/abc/approve", nil)
+	req.Header.Set("X-Admin-Token", adminToken)
+	require.Equal(t, http.StatusBadRequest, serve(server, req).Code)
+}
@@ -0,0 +1,298 @@
+package http
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+const defaultHistoryLimit = 100
+
+// @Summary Get last stored rates
+// @Description Retrieves the latest stored rates for specified cryptocurrencies without querying the provider.
+// @Description Responses carry an ETag derived from the returned values and Last-Modified of the newest stored rate.
+// @Description If-None-Match is honoured, If-Modified-Since only when If-None-Match is absent.
+// @Tags Coins
+// @Produce json
+// @Param titles query string true "Comma separated coin titles" example(BTC,ETH)
+// @Param If-None-Match header string false "ETag of a previously received response"
+// @Param If-Modified-Since header string false "Last-Modified of a previously received response"
+// @Success 200 {object} dto.StoredRatesResponseDTO
+// @Success 304
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /rates/last [get]
+func (srv *Server) getStoredRates(w http.ResponseWriter, r *http.Request) {
+	titles := srv.queryList(r, "titles")
+	if len(titles) == 0 {
+		srv.logger.ErrorContext(r.Context(), "Empty titles list provided")
+		w.Header().Set("Content-Type", "application/json")
+		srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty"))
+		return
+	}
+
+	coins, err := srv.Service.GetStoredRates(r.Context(), titles)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to get stored rates", "err", err)
+		w.Header().Set("Content-Type", "application/json")
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	var lastModified time.Time
+	dtos := make([]dto.HistoricalCoinDTO, len(coins))
+	for i, coin := range coins {
+		dtos[i] = dto.HistoricalCoinDTO{
+			Title:    coin.Title,
+			Cost:     coin.Cost,
+			ActualAt: coin.ActualAt,
+		}
+		if coin.ActualAt.After(lastModified) {
+			lastModified = coin.ActualAt
+		}
+	}
+
+	// Only a rate at least as new as the stored one replaces it, so the latest rates change with their
+	// newest timestamp and If-Modified-Since can validate them.
+	if srv.notModified(w, r, lastModified, true, dtos) {
+		return
+	}
+
+	srv.jsonResponse(w, dto.StoredRatesResponseDTO{Coins: dtos})
+	srv.logger.DebugContext(r.Context(), "Successfully retrieved stored rates", "number_of_coins", len(dtos))
+}
+
+// @Summary Get aggregate stored rates
+// @Description Aggregates the rates stored within the from/to window for specified cryptocurrencies without querying the provider.
+// @Description Responses carry an ETag derived from the returned values and honour If-None-Match, Last-Modified reports the newest stored rate.
+// @Tags Coins
+// @Produce json
+// @Param titles query string true "Comma separated coin titles" example(BTC,ETH)
+// @Param aggTypes query string true "Comma separated aggregation types" example(MIN,MAX,AVG)
+// @Param from query string false "RFC 3339 start of the window, defaults to the beginning of history"
+// @Param to query string false "RFC 3339 end of the window, defaults to now"
+// @Param If-None-Match header string false "ETag of a previously received response"
+// @Success 200 {object} dto.AggregateResponseDTO
+// @Success 304
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /rates/aggregate [get]
+func (srv *Server) getStoredAggregateRates(w http.ResponseWriter, r *http.Request) {
+	titles := srv.queryList(r, "titles")
+	if len(titles) == 0 {
+		srv.logger.ErrorContext(r.Context(), "Empty titles list provided")
+		w.Header().Set("Content-Type", "application/json")
+		srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty"))
+		return
+	}
+
+	aggTypes := srv.queryList(r, "aggTypes")
+	for _, aggType := range aggTypes {
+		if err := entities.ValidateAggType(aggType); err != nil {
+			srv.logger.ErrorContext(r.Context(), "Unsupported aggregation type", "agg_type", aggType)
+			w.Header().Set("Content-Type", "application/json")
+			srv.errProcessing(w, r, err)
+			return
+		}
+	}
+
+	from, to, err := srv.queryWindow(r)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	stats, err := srv.Service.GetStoredAggregateStats(r.Context(), titles, aggTypes, from, to)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to get stored aggregate stats", "err", err)
+		w.Header().Set("Content-Type", "application/json")
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	var lastModified time.Time
+	dtos := make([]dto.CoinStatsDTO, len(stats))
+	for i, coinStats := range stats {
+		dtos[i] = dto.CoinStatsDTO{
+			Title:     coinStats.Title,
+			Stats:     coinStats.Values,
+			UpdatedAt: coinStats.UpdatedAt,
+		}
+		if coinStats.UpdatedAt.After(lastModified) {
+			lastModified = coinStats.UpdatedAt
+		}
+	}
+
+	if srv.notModified(w, r, lastModified, false, dtos) {
+		return
+	}
+
+	srv.jsonResponse(w, dto.AggregateResponseDTO{Coins: dtos})
+	srv.logger.DebugContext(r.Context(), "Successfully retrieved stored aggregate stats", "number_of_coins", len(dtos), "agg_types", aggTypes)
+}
+
+// @Summary Get coin history
+// @Description Retrieves stored rates of a cryptocurrency within a time window, newest first.
+// @Description Responses carry an ETag derived from the returned values and honour If-None-Match, Last-Modified reports the newest stored rate.
+// @Tags Coins
+// @Produce json
+// @Param title path string true "Coin title" example(BTC)
+// @Param from query string false "RFC 3339 start of the window, defaults to the beginning of history"
+// @Param to query string false "RFC 3339 end of the window, defaults to now"
+// @Param limit query int false "Maximum number of rates, defaults to 100"
+// @Param If-None-Match header string false "ETag of a previously received response"
+// @Success 200 {object} dto.CoinHistoryResponseDTO
+// @Success 304
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /coins/{title}/rates [get]
+func (srv *Server) getCoinHistory(w http.ResponseWriter, r *http.Request) {
+	title := chi.URLParam(r, "title")
+	query := r.URL.Query()
+
+	from, to, err := srv.queryWindow(r)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	limit := defaultHistoryLimit
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		if limit, err = strconv.Atoi(rawLimit); err != nil {
+			srv.logger.ErrorContext(r.Context(), "Invalid limit provided", "limit", rawLimit, "err", err)
+			w.Header().Set("Content-Type", "application/json")
+			srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "invalid limit"))
+			return
+		}
+	}
+
+	history, err := srv.Service.GetCoinHistory(r.Context(), title, from, to, limit)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to get coin history", "title", title, "err", err)
+		w.Header().Set("Content-Type", "application/json")
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	dtos := make([]dto.HistoricalCoinDTO, len(history))
+	for i, coin := range history {
+		dtos[i] = dto.HistoricalCoinDTO{
+			Title:    coin.Title,
+			Cost:     coin.Cost,
+			ActualAt: coin.ActualAt,
+		}
+	}
+
+	if srv.notModified(w, r, history[0].ActualAt, false, dtos) {
+		return
+	}
+
+	srv.jsonResponse(w, dto.CoinHistoryResponseDTO{Title: title, Rates: dtos})
+	srv.logger.DebugContext(r.Context(), "Successfully retrieved coin history", "title", title, "number_of_coins", len(dtos))
+}
+
+// queryList collects values of a query parameter given either repeatedly or comma separated.
+func (srv *Server) queryList(r *http.Request, key string) []string {
+	var values []string
+	for _, value := range r.URL.Query()[key] {
+		values = append(values, strings.Split(value, ",")...)
+	}
+	return srv.removeEmptyStrings(values)
+}
+
+// queryWindow parses the optional RFC 3339 from and to query parameters, zero values leave the window open.
+func (srv *Server) queryWindow(r *http.Request) (from, to time.Time, err error) {
+	query := r.URL.Query()
+	if rawFrom := query.Get("from"); rawFrom != "" {
+		if from, err = time.Parse(time.RFC3339, rawFrom); err != nil {
+			srv.logger.ErrorContext(r.Context(), "Invalid window start provided", "from", rawFrom, "err", err)
+			return time.Time{}, time.Time{}, errors.Wrap(entities.ErrInvalidParam, "invalid from time")
+		}
+	}
+	if rawTo := query.Get("to"); rawTo != "" {
+		if to, err = time.Parse(time.RFC3339, rawTo); err != nil {
+			srv.logger.ErrorContext(r.Context(), "Invalid window end provided", "to", rawTo, "err", err)
+			return time.Time{}, time.Time{}, errors.Wrap(entities.ErrInvalidParam, "invalid to time")
+		}
+	}
+	return from, to, nil
+}
+
+// notModified sets the validators of a response built from stored rates and writes 304 Not Modified
+// when the request preconditions match. Stored rates change without a newer timestamp: backfills and
+// approved quarantined rates add older rows and a later rate in the same bucket replaces the stored cost.
+// The ETag is therefore a hash of the returned values. If-Modified-Since is evaluated only when the caller
+// states that the values change with lastModified and If-None-Match is absent, at the one second
+// resolution of HTTP dates. A zero lastModified is unknown and sets no Last-Modified.
+func (srv *Server) notModified(w http.ResponseWriter, r *http.Request, lastModified time.Time, sinceValid bool, values any) bool {
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+
+	body, err := json.Marshal(values)
+	if err != nil {
+		return false
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write(body)
+	etag := `"` + strconv.FormatUint(hash.Sum64(), 36) + "-" + strconv.Itoa(len(body)) + `"`
+	w.Header().Set("ETag", etag)
+
+	var hit bool
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		hit = etagMatches(ifNoneMatch, etag)
+	} else if sinceValid && !lastModified.IsZero() {
+		hit = notModifiedSince(r.Header.Get("If-Modified-Since"), lastModified)
+	}
+	if srv.metrics != nil {
+		srv.metrics.ObserveCacheLookup(hit)
+	}
+	if !hit {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+func notModifiedSince(ifModifiedSince string, lastModified time.Time) bool {
+	if ifModifiedSince == "" {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -4,23 +4,83 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"Cryptoproject/internal/entities"
 	"Cryptoproject/pkg/dto"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const defaultHeartbeatInterval = 15 * time.Second
+
 type Server struct {
-	HttpServer *http.Server
-	Service    Service
-	Router     *chi.Mux
+	HttpServer        *http.Server
+	Service           Service
+	Broker            Broker
+	Router            *chi.Mux
+	heartbeatInterval time.Duration
+	apiKeyAuth        bool
+	adminToken        string
+	limiters          keyLimiters
+	jwtKeys           KeySet
+	jwtIssuer         string
+	jwtAudience       string
+	jwtParser         *jwt.Parser
+	metrics           Metrics
+	requestTimeout    time.Duration
+	routeTimeouts     map[string]time.Duration
+	maxBodyBytes      int64
+	cors              CORSConfig
+	upgrader          *websocket.Upgrader
+	logger            *slog.Logger
+	// closing is closed when shutdown begins so that long-lived streams end instead of holding it up.
+	closing     chan struct{}
+	closingOnce sync.Once
+}
+
+type ServerOption func(*Server)
+
+// WithHeartbeatInterval sets how often idle streaming connections are pinged.
+func WithHeartbeatInterval(interval time.Duration) ServerOption {
+	return func(s *Server) {
+		s.heartbeatInterval = interval
+	}
 }
 
-func NewServer(addr string, srv Service) (*Server, error) {
+func WithBroker(broker Broker) ServerOption {
+	return func(s *Server) {
+		s.Broker = broker
+	}
+}
+
+// WithMetrics records request metrics and exposes them on /metrics.
+func WithMetrics(metrics Metrics) ServerOption {
+	return func(s *Server) {
+		s.metrics = metrics
+	}
+}
+
+func WithLogger(logger *slog.Logger) ServerOption {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+func NewServer(addr string, srv Service, opts ...ServerOption) (*Server, error) {
 	if addr == "" {
 		return nil, errors.Wrap(entities.ErrInvalidParam, "address cannot be empty")
 	}
@@ -34,37 +94,201 @@ func NewServer(addr string, srv Service) (*Server, error) {
 			Addr:    addr,
 			Handler: router,
 		},
-		Service: srv,
-		Router:  router,
+		Service:           srv,
+		Router:            router,
+		heartbeatInterval: defaultHeartbeatInterval,
+		requestTimeout:    defaultRequestTimeout,
+		maxBodyBytes:      defaultMaxBodyBytes,
+		closing:           make(chan struct{}),
+		logger:            slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(srvInstance)
+	}
+
+	if srvInstance.heartbeatInterval <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "heartbeat interval must be greater than zero")
+	}
+	if srvInstance.requestTimeout <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "request timeout must be greater than zero")
+	}
+	for route, timeout := range srvInstance.routeTimeouts {
+		if timeout <= 0 {
+			return nil, errors.Wrapf(entities.ErrInvalidParam, "timeout of route %s must be greater than zero", route)
+		}
+	}
+	if srvInstance.maxBodyBytes <= 0 {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "body size limit must be greater than zero")
+	}
+	if srvInstance.logger == nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "logger cannot be nil")
+	}
+	if srvInstance.apiKeyAuth && srvInstance.jwtKeys != nil {
+		return nil, errors.Wrap(entities.ErrInvalidParam, "API key and JWT authentication cannot be combined")
+	}
+	if srvInstance.jwtKeys != nil {
+		srvInstance.jwtParser = srvInstance.newJWTParser()
 	}
+	srvInstance.upgrader = srvInstance.newUpgrader()
 
 	// Документация OpenAPI
 	// @Title Cryptocurrency Rates API
 	// @Version 1.0
 	// @Description This API provides cryptocurrency rate information.
+	// @Description Errors are returned as RFC 7807 problem details (application/problem+json).
 	// @Host localhost:8080
-	// @BasePath /
+	// @BasePath /v1
+	// @securityDefinitions.apikey ApiKeyAuth
+	// @in header
+	// @name X-API-Key
+	// @securityDefinitions.apikey AdminToken
+	// @in header
+	// @name X-Admin-Token
+	// @securityDefinitions.apikey BearerAuth
+	// @in header
+	// @name Authorization
+	// @description JWT as "Bearer <token>". Rates, conversion, valuation and streams require the rates:read scope,
+	// @description saved portfolios portfolios:read or portfolios:write, admin endpoints admin:read or admin:write.
 	// @schemes http
 	// @produces json
 	// @consumes json
+	router.Use(middleware.RequestID, requestIDHeader, logContext, srvInstance.accessLog)
+	if len(srvInstance.cors.AllowedOrigins) > 0 {
+		router.Use(srvInstance.corsHandler())
+	}
+	router.Use(otelhttp.NewMiddleware("http.server"), traceRoute)
+	if srvInstance.metrics != nil {
+		router.Use(srvInstance.instrument)
+	}
+	router.Use(srvInstance.recoverer)
+	if srvInstance.metrics != nil {
+		router.Method(http.MethodGet, "/metrics", srvInstance.metrics.Handler())
+	}
+	router.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		srvInstance.errProcessing(w, r, errors.Wrapf(entities.ErrNotFound, "route %s not found", r.URL.Path))
+	})
+
 	router.Get("/ping", srvInstance.pingHandler)
-	srvInstance.Router.Post("/rates/last", srvInstance.getLastRates)
-	srvInstance.Router.Post("/rates/aggregate", srvInstance.getAggregateRates)
+	router.Get("/healthz", srvInstance.liveness)
+	router.Get("/readyz", srvInstance.readiness)
+	router.Route("/v1", func(r chi.Router) {
+		if srvInstance.adminToken != "" || srvInstance.jwtKeys != nil {
+			r.Route("/admin", srvInstance.adminRoutes)
+		}
+		r.Group(srvInstance.routes)
+	})
+	// Unversioned routes are kept for existing clients and point them at /v1.
+	router.Group(func(r chi.Router) {
+		r.Use(deprecated)
+		srvInstance.routes(r)
+	})
 
 	return srvInstance, nil
 }
+func (srv *Server) routes(r chi.Router) {
+	switch {
+	case srv.jwtKeys != nil:
+		r.Use(srv.authenticateJWT)
+	case srv.apiKeyAuth:
+		r.Use(srv.authenticate)
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Use(srv.requireScope(scopeRatesRead))
+		r.Group(func(r chi.Router) {
+			r.Use(srv.timeout)
+			r.Post("/rates/last", srv.getLastRates)
+			r.Post("/rates/aggregate", srv.getAggregateRates)
+			r.Post("/rates/at", srv.getRatesAt)
+			r.Get("/rates/last", srv.getStoredRates)
+			r.Get("/rates/aggregate", srv.getStoredAggregateRates)
+			r.Get("/coins/{title}/rates", srv.getCoinHistory)
+			r.Get("/convert", srv.convert)
+			r.Post("/portfolio/value", srv.valuePortfolio)
+		})
+		// Streams stay open for as long as the client listens, so they have no timeout.
+		if srv.Broker != nil {
+			r.Get("/rates/stream", srv.streamRates)
+			r.Get("/rates/ws", srv.websocketRates)
+		}
+	})
+	r.Route("/portfolios", func(r chi.Router) {
+		r.Use(srv.timeout)
+		read := r.With(srv.requireScope(scopePortfoliosRead))
+		write := r.With(srv.requireScope(scopePortfoliosWrite))
+		read.Get("/", srv.listPortfolios)
+		write.Post("/", srv.createPortfolio)
+		read.Get("/{id}", srv.getPortfolio)
+		write.Put("/{id}", srv.updatePortfolio)
+		write.Delete("/{id}", srv.deletePortfolio)
+		read.Get("/{id}/value", srv.valueStoredPortfolio)
+	})
+}
+
+// requestIDHeader echoes the request ID assigned by middleware.RequestID so clients can quote it.
+func requestIDHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(middleware.RequestIDHeader, middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// instrument records every request under its route pattern rather than its path to keep label cardinality bounded.
+func (srv *Server) instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route := chi.RouteContext(r.Context()).RoutePattern()
+		if route == "" {
+			route = "not_found"
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		srv.metrics.ObserveHTTPRequest(route, r.Method, status, time.Since(start))
+	})
+}
+
+// traceRoute names the server span after the matched route pattern once routing is done, so traces of
+// the same endpoint group together regardless of path parameters.
+func traceRoute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		span := trace.SpanFromContext(r.Context())
+		span.SetAttributes(attribute.String("http.request_id", middleware.GetReqID(r.Context())))
+		if route := chi.RouteContext(r.Context()).RoutePattern(); route != "" {
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+	})
+}
+
+// deprecated marks unversioned routes and links to their /v1 successor.
+func deprecated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "</v1"+r.URL.Path+">; rel=\"successor-version\"")
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (srv *Server) pingHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte(`{"status": "OK"}`))
 }
 func (srv *Server) Start() error {
-	slog.Info("HTTP server started", "addr", srv.HttpServer.Addr)
+	srv.logger.Info("HTTP server started", "addr", srv.HttpServer.Addr)
 	return srv.HttpServer.ListenAndServe()
 }
 
 func (srv *Server) Shutdown(ctx context.Context) error {
-	slog.Info("HTTP server shutting down")
+	srv.logger.Info("HTTP server shutting down")
+	srv.closingOnce.Do(func() { close(srv.closing) })
 	return srv.HttpServer.Shutdown(ctx)
 }
 
@@ -77,28 +301,30 @@ func (srv *Server) Shutdown(ctx context.Context) error {
 // @Success 200 {object} dto.ResponseDTO
 // @Failure 400 {object} dto.ErrorResponseDTO
 // @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /rates/last [post]
 func (srv *Server) getLastRates(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	var req dto.RequestDTO
-	if err := srv.decodeRequest(r, &req); err != nil {
-		slog.Error("Failed to decode request", "err", err)
-		srv.errProcessing(w, err)
+	if err := srv.decodeRequest(w, r, &req); err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to decode request", "err", err)
+		srv.errProcessing(w, r, err)
 		return
 	}
 	req.Titles = srv.removeEmptyStrings(req.Titles)
 
 	if len(req.Titles) == 0 {
-		slog.Error("Empty titles list provided")
-		srv.errProcessing(w, errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty"))
+		srv.logger.ErrorContext(r.Context(), "Empty titles list provided")
+		srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty"))
 		return
 	}
 
 	coins, err := srv.Service.GetLastRates(r.Context(), req.Titles)
 	if err != nil {
-		slog.Error("Failed to get last rates", "err", err)
-		srv.errProcessing(w, err)
+		srv.logger.ErrorContext(r.Context(), "Failed to get last rates", "err", err)
+		srv.errProcessing(w, r, err)
 		return
 	}
 
@@ -115,11 +341,15 @@ func (srv *Server) getLastRates(w http.ResponseWriter, r *http.Request) {
 	}
 
 	srv.jsonResponse(w, responseDTO)
-	slog.Info("Successfully retrieved last rates", "number_of_coins", len(dtos))
+	srv.logger.DebugContext(r.Context(), "Successfully retrieved last rates", "number_of_coins", len(dtos))
 }
 
 // @Summary Get aggregate rates
 // @Description Aggregates rates for specified cryptocurrencies based on given parameters.
+// @Description Supported aggregation types: MIN, MAX, AVG, CHANGE, CHANGE_PCT, STDDEV, FIRST, LAST, MEDIAN, COUNT.
+// @Description When aggTypes is set, every requested statistic is returned per coin instead of a single cost.
+// @Description Statistics cover the rates stored between from and to, an omitted from starts at the beginning of history and an omitted to ends now.
+// @Description CHANGE_PCT is undefined when the first rate is zero: it is omitted from aggTypes statistics and rejected as a single aggType.
 // @Tags Coins
 // @Accept json
 // @Produce json
@@ -127,42 +357,33 @@ func (srv *Server) getLastRates(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} dto.ResponseDTO
 // @Failure 400 {object} dto.ErrorResponseDTO
 // @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /rates/aggregate [post]
 func (srv *Server) getAggregateRates(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	var req dto.RequestDTO
-	if err := srv.decodeRequest(r, &req); err != nil {
-		slog.Error("Failed to decode request", "err", err)
-		srv.errProcessing(w, err)
+	if err := srv.decodeRequest(w, r, &req); err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to decode request", "err", err)
+		srv.errProcessing(w, r, err)
 		return
 	}
 	req.Titles = srv.removeEmptyStrings(req.Titles)
-	if len(req.Titles) == 0 {
-		slog.Error("Empty titles list provided")
-		srv.errProcessing(w, errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty"))
+	if err := srv.validateAggregateRequest(r, req); err != nil {
+		srv.errProcessing(w, r, err)
 		return
 	}
 
-	validTypes := map[string]bool{"MIN": true, "MAX": true, "AVG": true}
-	if !validTypes[req.AggType] {
-		slog.Error("Unsupported aggregation type", "agg_type", req.AggType)
-		srv.errProcessing(w, errors.Wrap(entities.ErrInvalidParam, "invalid aggregation type"))
+	if len(req.AggTypes) > 0 {
+		srv.getAggregateStats(w, r, req)
 		return
 	}
 
-	coins, err := srv.Service.GetAggregateRates(r.Context(), req.Titles, req.AggType)
+	coins, err := srv.Service.GetAggregateRates(r.Context(), req.Titles, req.AggType, req.From, req.To)
 	if err != nil {
-		if errors.Is(err, entities.ErrInvalidParam) {
-			slog.Error("Invalid parameter provided", "err", err)
-			srv.errProcessing(w, err)
-		} else if errors.Is(err, entities.ErrInternal) {
-			slog.Error("Internal service error", "err", err)
-			srv.errProcessing(w, err)
-		} else {
-			slog.Error("Failed to get aggregate rates", "err", err)
-			srv.errProcessing(w, err)
-		}
+		srv.logger.ErrorContext(r.Context(), "Failed to get aggregate rates", "err", err)
+		srv.errProcessing(w, r, err)
 		return
 	}
 
@@ -179,20 +400,242 @@ func (srv *Server) getAggregateRates(w http.ResponseWriter, r *http.Request) {
 	}
 
 	srv.jsonResponse(w, responseDTO)
-	slog.Info("Successfully retrieved aggregate rates", "number_of_coins", len(dtos))
+	srv.logger.DebugContext(r.Context(), "Successfully retrieved aggregate rates", "number_of_coins", len(dtos))
+}
+
+// validateAggregateRequest checks the titles, the aggregation types and the window of an aggregate request
+// before it reaches the service.
+func (srv *Server) validateAggregateRequest(r *http.Request, req dto.RequestDTO) error {
+	if len(req.Titles) == 0 {
+		srv.logger.ErrorContext(r.Context(), "Empty titles list provided")
+		return errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty")
+	}
+
+	aggTypes := req.AggTypes
+	if len(aggTypes) == 0 {
+		aggTypes = []string{req.AggType}
+	}
+	for _, aggType := range aggTypes {
+		if err := entities.ValidateAggType(aggType); err != nil {
+			srv.logger.ErrorContext(r.Context(), "Unsupported aggregation type", "agg_type", aggType)
+			return err
+		}
+	}
+
+	if !req.From.IsZero() && !req.To.IsZero() && req.From.After(req.To) {
+		srv.logger.ErrorContext(r.Context(), "Invalid time window", "from", req.From, "to", req.To)
+		return errors.Wrap(entities.ErrInvalidParam, "from must not be after to")
+	}
+	return nil
+}
+
+func (srv *Server) getAggregateStats(w http.ResponseWriter, r *http.Request, req dto.RequestDTO) {
+	stats, err := srv.Service.GetAggregateStats(r.Context(), req.Titles, req.AggTypes, req.From, req.To)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to get aggregate stats", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	dtos := make([]dto.CoinStatsDTO, len(stats))
+	for i, coinStats := range stats {
+		dtos[i] = dto.CoinStatsDTO{
+			Title:     coinStats.Title,
+			Stats:     coinStats.Values,
+			UpdatedAt: coinStats.UpdatedAt,
+		}
+	}
+
+	srv.jsonResponse(w, dto.AggregateResponseDTO{Coins: dtos})
+	srv.logger.DebugContext(r.Context(), "Successfully retrieved aggregate stats", "number_of_coins", len(dtos), "agg_types", req.AggTypes)
+}
+
+// @Summary Get rates at time
+// @Description Retrieves, per requested cryptocurrency, the nearest stored rate at or before the given time.
+// @Description Rates older than maxStaleness (Go duration, e.g. "15m") relative to the given time are rejected.
+// @Tags Coins
+// @Accept json
+// @Produce json
+// @Param request body dto.RateAtRequestDTO true "Request containing coin titles, lookup time and optional max staleness"
+// @Success 200 {object} dto.HistoricalResponseDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /rates/at [post]
+func (srv *Server) getRatesAt(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req dto.RateAtRequestDTO
+	if err := srv.decodeRequest(w, r, &req); err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to decode request", "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+	req.Titles = srv.removeEmptyStrings(req.Titles)
+
+	if len(req.Titles) == 0 {
+		srv.logger.ErrorContext(r.Context(), "Empty titles list provided")
+		srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty"))
+		return
+	}
+
+	if req.At.IsZero() {
+		srv.logger.ErrorContext(r.Context(), "Empty lookup time provided")
+		srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "lookup time cannot be empty"))
+		return
+	}
+
+	maxStaleness, err := srv.parseMaxStaleness(r, req.MaxStaleness)
+	if err != nil {
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	coins, err := srv.Service.GetRatesAt(r.Context(), req.Titles, req.At, maxStaleness)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to get rates at time", "at", req.At, "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	dtos := make([]dto.HistoricalCoinDTO, len(coins))
+	for i, coin := range coins {
+		dtos[i] = dto.HistoricalCoinDTO{
+			Title:    coin.Title,
+			Cost:     coin.Cost,
+			ActualAt: coin.ActualAt,
+		}
+	}
+
+	srv.jsonResponse(w, dto.HistoricalResponseDTO{At: req.At, Coins: dtos})
+	srv.logger.DebugContext(r.Context(), "Successfully retrieved rates at time", "number_of_coins", len(dtos), "at", req.At)
 }
 
-func (srv *Server) decodeRequest(r *http.Request, decReq any) error {
+// @Summary Convert between coins
+// @Description Converts an amount of one cryptocurrency into another using cross rates of the latest stored prices,
+// @Description or of the prices stored at or before the given time. Fails when either rate is older than maxStaleness.
+// @Tags Coins
+// @Produce json
+// @Param from query string true "Title of the coin to convert from" example(ETH)
+// @Param to query string true "Title of the coin to convert to" example(BTC)
+// @Param amount query number true "Amount of the from coin" example(3.5)
+// @Param at query string false "RFC 3339 time for a historical conversion"
+// @Param maxStaleness query string false "Maximum age of the underlying rates, Go duration (e.g. 15m)"
+// @Success 200 {object} dto.ConversionResponseDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /convert [get]
+func (srv *Server) convert(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+	from := query.Get("from")
+	to := query.Get("to")
+
+	amount, err := strconv.ParseFloat(query.Get("amount"), 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		srv.logger.ErrorContext(r.Context(), "Invalid amount provided", "amount", query.Get("amount"), "err", err)
+		srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "invalid amount"))
+		return
+	}
+	if amount <= 0 {
+		srv.logger.ErrorContext(r.Context(), "Non-positive amount provided", "amount", amount)
+		srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "amount must be greater than zero"))
+		return
+	}
+
+	var at time.Time
+	if rawAt := query.Get("at"); rawAt != "" {
+		at, err = time.Parse(time.RFC3339, rawAt)
+		if err != nil {
+			srv.logger.ErrorContext(r.Context(), "Invalid conversion time provided", "at", rawAt, "err", err)
+			srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "invalid conversion time"))
+			return
+		}
+	}
+
+	maxStaleness, err := srv.parseMaxStaleness(r, query.Get("maxStaleness"))
+	if err != nil {
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	conversion, err := srv.Service.Convert(r.Context(), from, to, amount, at, maxStaleness)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to convert", "from", from, "to", to, "err", err)
+		srv.errProcessing(w, r, err)
+		return
+	}
+
+	rates := []dto.ConversionRateDTO{
+		srv.conversionRateDTO(conversion.From, conversion.At),
+	}
+	if conversion.To.Title != conversion.From.Title {
+		rates = append(rates, srv.conversionRateDTO(conversion.To, conversion.At))
+	}
+
+	srv.jsonResponse(w, dto.ConversionResponseDTO{
+		From:   conversion.From.Title,
+		To:     conversion.To.Title,
+		Amount: conversion.Amount,
+		Rate:   conversion.Rate,
+		Result: conversion.Result,
+		At:     conversion.At,
+		Rates:  rates,
+	})
+	srv.logger.DebugContext(r.Context(), "Successfully converted", "from", from, "to", to, "rate", conversion.Rate)
+}
+
+// parseMaxStaleness parses an optional max staleness, an empty one leaves the service default in place.
+func (srv *Server) parseMaxStaleness(r *http.Request, raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	maxStaleness, err := time.ParseDuration(raw)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Invalid max staleness provided", "max_staleness", raw, "err", err)
+		return 0, errors.Wrap(entities.ErrInvalidParam, "invalid max staleness")
+	}
+	if maxStaleness < 0 {
+		srv.logger.ErrorContext(r.Context(), "Negative max staleness provided", "max_staleness", raw)
+		return 0, errors.Wrap(entities.ErrInvalidParam, "max staleness cannot be negative")
+	}
+	return maxStaleness, nil
+}
+
+func (srv *Server) conversionRateDTO(coin entities.Coin, at time.Time) dto.ConversionRateDTO {
+	return dto.ConversionRateDTO{
+		Title:      coin.Title,
+		Cost:       coin.Cost,
+		ActualAt:   coin.ActualAt,
+		AgeSeconds: at.Sub(coin.ActualAt).Seconds(),
+	}
+}
+
+func (srv *Server) decodeRequest(w http.ResponseWriter, r *http.Request, decReq any) error {
 	if r.Body == nil || r.ContentLength == 0 {
-		return errors.New("empty request body")
+		return errors.Wrap(entities.ErrInvalidParam, "empty request body")
+	}
+	if r.ContentLength > srv.maxBodyBytes {
+		return errors.Wrapf(entities.ErrTooLarge, "request body exceeds %d bytes", srv.maxBodyBytes)
 	}
 
 	v := validator.New()
-	if err := json.NewDecoder(r.Body).Decode(decReq); err != nil {
-		return err
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, srv.maxBodyBytes)).Decode(decReq); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return errors.Wrapf(entities.ErrTooLarge, "request body exceeds %d bytes", srv.maxBodyBytes)
+		}
+		return errors.Wrapf(entities.ErrInvalidParam, "malformed request body: %v", err)
 	}
 	if err := v.Struct(decReq); err != nil {
-		return err
+		return errors.Wrapf(entities.ErrInvalidParam, "invalid request body: %v", err)
 	}
 	return nil
 }
@@ -200,7 +643,7 @@ func (srv *Server) decodeRequest(r *http.Request, decReq any) error {
 func (srv *Server) jsonResponse(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(data); err != nil {
-		slog.Error("Unable to encode response", "err", err)
+		srv.logger.Error("Unable to encode response", "err", err)
 		http.Error(w, "unable to encode response", http.StatusInternalServerError)
 	}
 }
@@ -214,34 +657,3 @@ func (srv *Server) removeEmptyStrings(slices []string) []string {
 	}
 	return result
 }
-func (srv *Server) errProcessing(resp http.ResponseWriter, err error) {
-	statusCode := http.StatusInternalServerError
-	errDTO := dto.ErrorResponseDTO{
-		Code:    statusCode,
-		Message: err.Error(),
-	}
-
-	switch {
-	case errors.Is(err, entities.ErrInvalidParam):
-		errDTO.Code = http.StatusBadRequest
-	case errors.Is(err, entities.ErrInternal):
-		errDTO.Code = http.StatusForbidden
-	case errors.Is(err, entities.ErrNotFound):
-		errDTO.Code = http.StatusNotFound
-	default:
-		errors.Is(err, entities.ErrNotFound)
-		errDTO.Code = http.StatusNotFound
-		errDTO.Message = "coin does not exist or was not found in the provider"
-	}
-
-	errDtoData, err := json.Marshal(&errDTO)
-	if err != nil {
-		err := errors.Wrapf(entities.ErrInternal, "marshal failure: %v", err)
-		slog.Error(err.Error())
-		http.Error(resp, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	resp.WriteHeader(errDTO.Code)
-	resp.Write(errDtoData) //nolint:errcheck,gosec //ok
-}
@@ -0,0 +1,544 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/broker"
+	"Cryptoproject/internal/adapters/client"
+	"Cryptoproject/internal/cases"
+	casesmocks "Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+	myhttp "Cryptoproject/internal/ports/http"
+	mocks "Cryptoproject/internal/ports/http/testdata"
+	"Cryptoproject/pkg/dto"
+)
+
+func setupServer(t *testing.T) (*myhttp.Server, *mocks.MockService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockService(ctrl)
+
+	server, err := myhttp.NewServer(":0", mockService)
+	require.NoError(t, err)
+
+	return server, mockService
+}
+
+func serve(server *myhttp.Server, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	server.Router.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeProblem(t *testing.T, rec *httptest.ResponseRecorder) dto.ErrorResponseDTO {
+	t.Helper()
+
+	require.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	var problem dto.ErrorResponseDTO
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	return problem
+}
+
+func TestServer_ErrorMapping(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		"invalid param": {errors.Wrap(entities.ErrInvalidParam, "bad"), http.StatusBadRequest, "invalid_param", "bad: invalid param"},
+		"not found":     {errors.Wrap(entities.ErrNotFound, "missing"), http.StatusNotFound, "not_found", "missing: not found"},
+		"unauthorized":  {errors.Wrap(entities.ErrUnauthorized, "no key"), http.StatusUnauthorized, "unauthorized", "no key: unauthorized"},
+		"forbidden":     {errors.Wrap(entities.ErrForbidden, "no scope"), http.StatusForbidden, "forbidden", "no scope: forbidden"},
+		"rate limited":  {errors.Wrap(entities.ErrRateLimited, "quota"), http.StatusTooManyRequests, "rate_limited", "quota: rate limited"},
+		"unavailable":   {errors.Wrap(entities.ErrUnavailable, "circuit open"), http.StatusServiceUnavailable, "unavailable", "circuit open: unavailable"},
+		"too large":     {errors.Wrap(entities.ErrTooLarge, "body limit"), http.StatusRequestEntityTooLarge, "too_large", "body limit: too large"},
+		"conflict":      {errors.Wrap(entities.ErrConflict, "newer rate"), http.StatusConflict, "conflict", "newer rate: conflict"},
+		"internal":      {errors.Wrap(entities.ErrInternal, "broken"), http.StatusInternalServerError, "internal", "internal error"},
+		"slow consumer": {broker.ErrSlowConsumer, http.StatusServiceUnavailable, "slow_consumer", broker.ErrSlowConsumer.Error()},
+		"canceled":      {errors.Wrap(context.Canceled, "gone"), 499, "canceled", "request was canceled"},
+		"deadline":      {errors.Wrap(context.DeadlineExceeded, "slow"), http.StatusGatewayTimeout, "timeout", "request timed out"},
+		"unknown":       {errors.New("secret connection string"), http.StatusInternalServerError, "unknown", "unexpected error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server, mockService := setupServer(t)
+			mockService.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC"}).Return(nil, tc.err)
+
+			rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC", nil))
+
+			require.Equal(t, tc.status, rec.Code)
+			problem := decodeProblem(t, rec)
+			require.Equal(t, tc.status, problem.Status)
+			require.Equal(t, tc.code, problem.Code)
+			require.Equal(t, "urn:cryptoproject:problem:"+tc.code, problem.Type)
+			require.Equal(t, tc.detail, problem.Detail)
+			require.Equal(t, "/v1/rates/last", problem.Instance)
+			require.NotEmpty(t, problem.Title)
+		})
+	}
+}
+
+func TestServer_GetRatesAt(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for name, tc := range map[string]struct {
+		body   string
+		expect func(*mocks.MockService)
+		status int
+		code   string
+		detail string
+	}{
+		"success": {
+			body: `{"titles":["BTC",""],"at":"2026-03-01T12:00:00Z","maxStaleness":"15m"}`,
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().GetRatesAt(gomock.Any(), []string{"BTC"}, at, 15*time.Minute).
+					Return([]*entities.Coin{{Title: "BTC", Cost: 50000, ActualAt: at.Add(-time.Minute)}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		"default staleness": {
+			body: `{"titles":["BTC"],"at":"2026-03-01T12:00:00Z"}`,
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().GetRatesAt(gomock.Any(), []string{"BTC"}, at, time.Duration(0)).
+					Return([]*entities.Coin{{Title: "BTC", Cost: 50000, ActualAt: at}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		"empty titles":        {`{"titles":[""],"at":"2026-03-01T12:00:00Z"}`, nil, http.StatusBadRequest, "invalid_param", "titles list cannot be empty: invalid param"},
+		"missing at":          {`{"titles":["BTC"]}`, nil, http.StatusBadRequest, "invalid_param", "lookup time cannot be empty: invalid param"},
+		"malformed at":        {`{"titles":["BTC"],"at":"yesterday"}`, nil, http.StatusBadRequest, "invalid_param", ""},
+		"malformed staleness": {`{"titles":["BTC"],"at":"2026-03-01T12:00:00Z","maxStaleness":"soon"}`, nil, http.StatusBadRequest, "invalid_param", "invalid max staleness: invalid param"},
+		"negative staleness":  {`{"titles":["BTC"],"at":"2026-03-01T12:00:00Z","maxStaleness":"-1m"}`, nil, http.StatusBadRequest, "invalid_param", "max staleness cannot be negative: invalid param"},
+		"not found": {
+			body: `{"titles":["BTC"],"at":"2026-03-01T12:00:00Z"}`,
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().GetRatesAt(gomock.Any(), []string{"BTC"}, at, time.Duration(0)).
+					Return(nil, errors.Wrap(entities.ErrNotFound, `no rate of coin "BTC" at or before the requested time`))
+			},
+			status: http.StatusNotFound,
+			code:   "not_found",
+			detail: `no rate of coin "BTC" at or before the requested time: not found`,
+		},
+		"stale rate": {
+			body: `{"titles":["BTC"],"at":"2026-03-01T12:00:00Z","maxStaleness":"1m"}`,
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().GetRatesAt(gomock.Any(), []string{"BTC"}, at, time.Minute).
+					Return(nil, errors.Wrap(entities.ErrInvalidParam, "rate of coin BTC is older than 1m0s"))
+			},
+			status: http.StatusBadRequest,
+			code:   "invalid_param",
+			detail: "rate of coin BTC is older than 1m0s: invalid param",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server, mockService := setupServer(t)
+			if tc.expect != nil {
+				tc.expect(mockService)
+			}
+
+			rec := serve(server, httptest.NewRequest(http.MethodPost, "/v1/rates/at", strings.NewReader(tc.body)))
+
+			require.Equal(t, tc.status, rec.Code)
+			if tc.status == http.StatusOK {
+				var resp dto.HistoricalResponseDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, at, resp.At)
+				require.Len(t, resp.Coins, 1)
+				require.Equal(t, "BTC", resp.Coins[0].Title)
+				return
+			}
+			problem := decodeProblem(t, rec)
+			require.Equal(t, tc.code, problem.Code)
+			require.Equal(t, "/v1/rates/at", problem.Instance)
+			if tc.detail != "" {
+				require.Equal(t, tc.detail, problem.Detail)
+			}
+		})
+	}
+}
+
+func TestServer_Convert(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	conversion := &entities.Conversion{
+		From:   entities.Coin{Title: "ETH", Cost: 3000, ActualAt: at.Add(-time.Minute)},
+		To:     entities.Coin{Title: "BTC", Cost: 60000, ActualAt: at},
+		Amount: 2,
+		Rate:   0.05,
+		Result: 0.1,
+		At:     at,
+	}
+	for name, tc := range map[string]struct {
+		query  string
+		expect func(*mocks.MockService)
+		status int
+		code   string
+		detail string
+	}{
+		"success": {
+			query: "from=ETH&to=BTC&amount=2&at=2026-03-01T12:00:00Z&maxStaleness=15m",
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().Convert(gomock.Any(), "ETH", "BTC", float64(2), at, 15*time.Minute).Return(conversion, nil)
+			},
+			status: http.StatusOK,
+		},
+		"latest rates": {
+			query: "from=ETH&to=BTC&amount=2",
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().Convert(gomock.Any(), "ETH", "BTC", float64(2), time.Time{}, time.Duration(0)).Return(conversion, nil)
+			},
+			status: http.StatusOK,
+		},
+		"missing amount":      {"from=ETH&to=BTC", nil, http.StatusBadRequest, "invalid_param", "invalid amount: invalid param"},
+		"non-numeric amount":  {"from=ETH&to=BTC&amount=two", nil, http.StatusBadRequest, "invalid_param", "invalid amount: invalid param"},
+		"nan amount":          {"from=ETH&to=BTC&amount=NaN", nil, http.StatusBadRequest, "invalid_param", "invalid amount: invalid param"},
+		"infinite amount":     {"from=ETH&to=BTC&amount=Inf", nil, http.StatusBadRequest, "invalid_param", "invalid amount: invalid param"},
+		"zero amount":         {"from=ETH&to=BTC&amount=0", nil, http.StatusBadRequest, "invalid_param", "amount must be greater than zero: invalid param"},
+		"negative amount":     {"from=ETH&to=BTC&amount=-1.5", nil, http.StatusBadRequest, "invalid_param", "amount must be greater than zero: invalid param"},
+		"malformed at":        {"from=ETH&to=BTC&amount=2&at=yesterday", nil, http.StatusBadRequest, "invalid_param", "invalid conversion time: invalid param"},
+		"malformed staleness": {"from=ETH&to=BTC&amount=2&maxStaleness=soon", nil, http.StatusBadRequest, "invalid_param", "invalid max staleness: invalid param"},
+		"negative staleness":  {"from=ETH&to=BTC&amount=2&maxStaleness=-5m", nil, http.StatusBadRequest, "invalid_param", "max staleness cannot be negative: invalid param"},
+		"unknown coin": {
+			query: "from=ETH&to=XYZ&amount=2",
+			expect: func(m *mocks.MockService) {
+				m.EXPECT().Convert(gomock.Any(), "ETH", "XYZ", float64(2), time.Time{}, time.Duration(0)).
+					Return(nil, errors.Wrap(entities.ErrNotFound, `no stored rate for coin "XYZ"`))
+			},
+			status: http.StatusNotFound,
+			code:   "not_found",
+			detail: `no stored rate for coin "XYZ": not found`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server, mockService := setupServer(t)
+			if tc.expect != nil {
+				tc.expect(mockService)
+			}
+
+			rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/convert?"+tc.query, nil))
+
+			require.Equal(t, tc.status, rec.Code)
+			if tc.status == http.StatusOK {
+				var resp dto.ConversionResponseDTO
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				require.Equal(t, 0.1, resp.Result)
+				require.Len(t, resp.Rates, 2)
+				require.Equal(t, float64(60), resp.Rates[0].AgeSeconds)
+				return
+			}
+			problem := decodeProblem(t, rec)
+			require.Equal(t, tc.code, problem.Code)
+			require.Equal(t, tc.detail, problem.Detail)
+			require.Equal(t, "/v1/convert", problem.Instance)
+		})
+	}
+}
+
+func TestServer_ErrorCarriesRequestID(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/rates/last", nil)
+	req.Header.Set("X-Request-Id", "req-42")
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "req-42", rec.Header().Get("X-Request-Id"))
+	require.Equal(t, "req-42", decodeProblem(t, rec).RequestID)
+}
+
+func TestServer_ErrorGeneratesRequestID(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupServer(t)
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/rates/last", nil))
+
+	problem := decodeProblem(t, rec)
+	require.NotEmpty(t, problem.RequestID)
+	require.Equal(t, rec.Header().Get("X-Request-Id"), problem.RequestID)
+}
+
+func TestServer_MalformedBody(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/rates/last", strings.NewReader("{"))
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "invalid_param", decodeProblem(t, rec).Code)
+}
+
+func TestServer_UnknownRoute(t *testing.T) {
+	t.Parallel()
+
+	server, _ := setupServer(t)
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/unknown", nil))
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, "not_found", decodeProblem(t, rec).Code)
+}
+
+func TestServer_LastRates_UnknownCoins(t *testing.T) {
+	t.Parallel()
+
+	// The provider answers requests for coins it knows none of with 200 and an error document.
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Response":"Error","Message":"cccagg_or_exchange market does not exist for this coin pair (XYZ-USD)","Type":2}`))
+	}))
+	t.Cleanup(provider.Close)
+
+	rateClient, err := client.NewClient(client.WithBaseURL(provider.URL + "/"))
+	require.NoError(t, err)
+	service, err := cases.NewService(casesmocks.NewMockStorage(gomock.NewController(t)), rateClient)
+	require.NoError(t, err)
+	server, err := myhttp.NewServer(":0", service)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/rates/last", strings.NewReader(`{"titles":["XYZ","ABC"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := serve(server, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	problem := decodeProblem(t, rec)
+	require.Equal(t, "not_found", problem.Code)
+	require.Contains(t, problem.Detail, "coins XYZ, ABC do not exist or were not found in the provider")
+	require.Equal(t, entities.CircuitClosed, rateClient.CircuitState())
+}
+
+func TestServer_InternalErrorDetailsHidden(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupServer(t)
+
+	mockService.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC"}).
+		Return(nil, errors.Wrap(entities.ErrInternal, `failed to query coins: ERROR: relation "coins" does not exist (SQLSTATE 42P01)`))
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC", nil))
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	problem := decodeProblem(t, rec)
+	require.Equal(t, "internal error", problem.Detail)
+	require.NotContains(t, rec.Body.String(), "SQLSTATE")
+}
+
+func TestServer_VersionedAndLegacyRoutes(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupServer(t)
+
+	actualAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC"}).Return([]*entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: actualAt},
+	}, nil).Times(2)
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Header().Get("Deprecation"))
+
+	rec = serve(server, httptest.NewRequest(http.MethodGet, "/rates/last?titles=BTC", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "true", rec.Header().Get("Deprecation"))
+	require.Equal(t, `</v1/rates/last>; rel="successor-version"`, rec.Header().Get("Link"))
+}
+
+func TestServer_GetStoredRates_NotModified(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupServer(t)
+
+	actualAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC", "ETH"}).Return([]*entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: actualAt},
+		{Title: "ETH", Cost: 3000, ActualAt: actualAt.Add(-time.Minute)},
+	}, nil).Times(6)
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC,ETH", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	require.Equal(t, actualAt.Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC&titles=ETH", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = serve(server, req)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Zero(t, rec.Body.Len())
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC,ETH", nil)
+	req.Header.Set("If-Modified-Since", actualAt.Format(http.TimeFormat))
+	rec = serve(server, req)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC,ETH", nil)
+	req.Header.Set("If-Modified-Since", actualAt.Add(-time.Second).Format(http.TimeFormat))
+	rec = serve(server, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC,ETH", nil)
+	req.Header.Set("If-None-Match", `"stale"`)
+	req.Header.Set("If-Modified-Since", actualAt.Format(http.TimeFormat))
+	rec = serve(server, req)
+	require.Equal(t, http.StatusOK, rec.Code, "If-None-Match takes precedence over If-Modified-Since")
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC,ETH", nil)
+	req.Header.Set("If-Modified-Since", "yesterday")
+	rec = serve(server, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServer_GetStoredRates_UnknownLastModified(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupServer(t)
+
+	mockService.EXPECT().GetStoredRates(gomock.Any(), []string{"BTC"}).Return([]*entities.Coin{
+		{Title: "BTC", Cost: 50000},
+	}, nil).Times(2)
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Header().Get("Last-Modified"))
+	require.NotEmpty(t, rec.Header().Get("ETag"))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/rates/last?titles=BTC", nil)
+	req.Header.Set("If-Modified-Since", time.Now().UTC().Format(http.TimeFormat))
+	rec = serve(server, req)
+	require.Equal(t, http.StatusOK, rec.Code, "an unknown modification time never validates a cached copy")
+}
+
+func TestServer_AggregateRates_Window(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupServer(t)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	mockService.EXPECT().GetAggregateRates(gomock.Any(), []string{"BTC"}, "CHANGE", from, to).Return([]*entities.Coin{
+		{Title: "BTC", Cost: 1500},
+	}, nil)
+	mockService.EXPECT().GetStoredAggregateStats(gomock.Any(), []string{"BTC"}, []string{"CHANGE", "COUNT"}, from, to).Return([]*entities.CoinStats{
+		{Title: "BTC", Values: map[string]float64{"CHANGE": 1500, "COUNT": 24}, UpdatedAt: to},
+	}, nil)
+
+	body := `{"titles":["BTC"],"aggType":"CHANGE","from":"2026-03-01T00:00:00Z","to":"2026-03-02T00:00:00Z"}`
+	rec := serve(server, httptest.NewRequest(http.MethodPost, "/v1/rates/aggregate", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(server, httptest.NewRequest(http.MethodGet, "/v1/rates/aggregate?titles=BTC&aggTypes=CHANGE,COUNT&from=2026-03-01T00:00:00Z&to=2026-03-02T00:00:00Z", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(server, httptest.NewRequest(http.MethodGet, "/v1/rates/aggregate?titles=BTC&aggTypes=CHANGE&from=yesterday", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "invalid from time: invalid param", decodeProblem(t, rec).Detail)
+}
+
+func TestServer_AggregateRates_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		body   string
+		detail string
+	}{
+		"empty titles":      {`{"titles":[""],"aggType":"MAX"}`, "titles list cannot be empty: invalid param"},
+		"unknown agg type":  {`{"titles":["BTC"],"aggType":"SUM"}`, `invalid aggregation type "SUM": invalid param`},
+		"unknown agg types": {`{"titles":["BTC"],"aggTypes":["MIN","SUM"]}`, `invalid aggregation type "SUM": invalid param`},
+		"inverted window":   {`{"titles":["BTC"],"aggType":"MAX","from":"2026-03-02T00:00:00Z","to":"2026-03-01T00:00:00Z"}`, "from must not be after to: invalid param"},
+		"inverted stats":    {`{"titles":["BTC"],"aggTypes":["MAX"],"from":"2026-03-02T00:00:00Z","to":"2026-03-01T00:00:00Z"}`, "from must not be after to: invalid param"},
+		"malformed from":    {`{"titles":["BTC"],"aggType":"MAX","from":"yesterday"}`, ""},
+		"malformed to":      {`{"titles":["BTC"],"aggType":"MAX","to":"2026-03-01"}`, ""},
+		"non-string from":   {`{"titles":["BTC"],"aggType":"MAX","from":1772323200}`, ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server, _ := setupServer(t)
+
+			rec := serve(server, httptest.NewRequest(http.MethodPost, "/v1/rates/aggregate", strings.NewReader(tc.body)))
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			problem := decodeProblem(t, rec)
+			require.Equal(t, "invalid_param", problem.Code)
+			if tc.detail != "" {
+				require.Equal(t, tc.detail, problem.Detail)
+			} else {
+				require.Contains(t, problem.Detail, "malformed request body")
+			}
+		})
+	}
+}
+
+func TestServer_GetCoinHistory_ChangedWithoutNewerRate(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupServer(t)
+
+	actualAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	stored := []*entities.Coin{{Title: "BTC", Cost: 50000, ActualAt: actualAt}}
+	backfilled := append(stored, &entities.Coin{Title: "BTC", Cost: 49000, ActualAt: actualAt.Add(-time.Hour)})
+	replaced := []*entities.Coin{{Title: "BTC", Cost: 50100, ActualAt: actualAt}, backfilled[1]}
+	gomock.InOrder(
+		mockService.EXPECT().GetCoinHistory(gomock.Any(), "BTC", gomock.Any(), gomock.Any(), gomock.Any()).Return(stored, nil),
+		mockService.EXPECT().GetCoinHistory(gomock.Any(), "BTC", gomock.Any(), gomock.Any(), gomock.Any()).Return(backfilled, nil),
+		mockService.EXPECT().GetCoinHistory(gomock.Any(), "BTC", gomock.Any(), gomock.Any(), gomock.Any()).Return(replaced, nil),
+	)
+
+	rec := serve(server, httptest.NewRequest(http.MethodGet, "/v1/coins/BTC/rates", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/coins/BTC/rates", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = serve(server, req)
+	require.Equal(t, http.StatusOK, rec.Code, "backfilled older rates change the representation")
+	require.Equal(t, actualAt.Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+	require.NotEqual(t, etag, rec.Header().Get("ETag"))
+	etag = rec.Header().Get("ETag")
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/coins/BTC/rates", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = serve(server, req)
+	require.Equal(t, http.StatusOK, rec.Code, "a replaced cost within the bucket changes the representation")
+}
+
+func TestServer_GetCoinHistory_IgnoresIfModifiedSince(t *testing.T) {
+	t.Parallel()
+
+	server, mockService := setupServer(t)
+
+	actualAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService.EXPECT().GetCoinHistory(gomock.Any(), "BTC", gomock.Any(), gomock.Any(), gomock.Any()).Return([]*entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: actualAt},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/coins/BTC/rates", nil)
+	req.Header.Set("If-Modified-Since", actualAt.Format(http.TimeFormat))
+	rec := serve(server, req)
+	require.Equal(t, http.StatusOK, rec.Code, "backfills change the history without a newer rate")
+}
@@ -2,11 +2,59 @@ package http
 
 import (
 	"context"
+	"net/http"
+	"time"
 
+	"Cryptoproject/internal/adapters/broker"
 	"Cryptoproject/internal/entities"
 )
 
+//go:generate mockgen -source=service.go -destination=./testdata/service.go -package=testdata
 type Service interface {
 	GetLastRates(ctx context.Context, title []string) ([]*entities.Coin, error)
-	GetAggregateRates(ctx context.Context, title []string, aggType string) ([]*entities.Coin, error)
+	GetAggregateRates(ctx context.Context, title []string, aggType string, from, to time.Time) ([]*entities.Coin, error)
+	GetAggregateStats(ctx context.Context, title []string, aggTypes []string, from, to time.Time) ([]*entities.CoinStats, error)
+	GetRatesAt(ctx context.Context, title []string, at time.Time, maxStaleness time.Duration) ([]*entities.Coin, error)
+	GetStoredRates(ctx context.Context, title []string) ([]*entities.Coin, error)
+	GetStoredAggregateStats(ctx context.Context, title []string, aggTypes []string, from, to time.Time) ([]*entities.CoinStats, error)
+	GetCoinHistory(ctx context.Context, title string, from, to time.Time, limit int) ([]*entities.Coin, error)
+	Convert(ctx context.Context, from, to string, amount float64, at time.Time, maxStaleness time.Duration) (*entities.Conversion, error)
+
+	ValuePortfolio(ctx context.Context, positions []entities.Position, at time.Time) (*entities.PortfolioValuation, error)
+	CreatePortfolio(ctx context.Context, name string, positions []entities.Position) (*entities.Portfolio, error)
+	GetPortfolio(ctx context.Context, id int64) (*entities.Portfolio, error)
+	ListPortfolios(ctx context.Context) ([]*entities.Portfolio, error)
+	UpdatePortfolio(ctx context.Context, id int64, name string, positions []entities.Position) (*entities.Portfolio, error)
+	DeletePortfolio(ctx context.Context, id int64) error
+	ValueStoredPortfolio(ctx context.Context, id int64, at time.Time) (*entities.Portfolio, *entities.PortfolioValuation, error)
+
+	Authenticate(ctx context.Context, plaintext string) (*entities.APIKey, error)
+	ConsumeQuota(ctx context.Context, key *entities.APIKey) error
+	CreateAPIKey(ctx context.Context, name string, rateLimit float64, burst int, dailyQuota int64) (*entities.APIKey, string, error)
+	ListAPIKeys(ctx context.Context) ([]*entities.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+	GetAPIKeyUsage(ctx context.Context, from, to time.Time) ([]*entities.APIKeyUsage, error)
+	GetQualityReport(ctx context.Context, from, to time.Time) (*entities.QualityReport, error)
+	ListQuarantinedRates(ctx context.Context, status entities.QuarantineStatus) ([]*entities.QuarantinedRate, error)
+	ApproveQuarantinedRate(ctx context.Context, id int64) error
+	DiscardQuarantinedRate(ctx context.Context, id int64) error
+
+	Readiness(ctx context.Context) *entities.Health
+}
+
+type Broker interface {
+	Subscribe(titles []string) *broker.Subscription
+	AddTitles(sub *broker.Subscription, titles []string) []string
+	RemoveTitles(sub *broker.Subscription, titles []string) []string
+	Unsubscribe(sub *broker.Subscription)
+}
+
+type KeySet interface {
+	Key(ctx context.Context, kid string) (any, error)
+}
+
+type Metrics interface {
+	Handler() http.Handler
+	ObserveHTTPRequest(route, method string, status int, duration time.Duration)
+	ObserveCacheLookup(hit bool)
 }
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"Cryptoproject/internal/entities"
+	"Cryptoproject/pkg/dto"
+)
+
+// @Summary Stream rates
+// @Description Streams Server-Sent Events with a "price" event for every stored rate of the requested cryptocurrencies.
+// @Description Subscribers that do not keep up receive an "error" event and are disconnected.
+// @Tags Coins
+// @Produce text/event-stream
+// @Param titles query string true "Comma separated coin titles" example(BTC,ETH)
+// @Success 200 {object} dto.HistoricalCoinDTO "price event payload"
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /rates/stream [get]
+func (srv *Server) streamRates(w http.ResponseWriter, r *http.Request) {
+	titles := srv.removeEmptyStrings(strings.Split(r.URL.Query().Get("titles"), ","))
+	if len(titles) == 0 {
+		srv.logger.ErrorContext(r.Context(), "Empty titles list provided")
+		w.Header().Set("Content-Type", "application/json")
+		srv.errProcessing(w, r, errors.Wrap(entities.ErrInvalidParam, "titles list cannot be empty"))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		srv.logger.ErrorContext(r.Context(), "Streaming is not supported by the response writer")
+		w.Header().Set("Content-Type", "application/json")
+		srv.errProcessing(w, r, errors.Wrap(entities.ErrInternal, "streaming is not supported"))
+		return
+	}
+
+	sub := srv.Broker.Subscribe(titles)
+	defer srv.Broker.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprint(w, ": subscribed\n\n")
+	flusher.Flush()
+
+	srv.logger.InfoContext(r.Context(), "Rates stream opened", "titles", titles)
+
+	heartbeat := time.NewTicker(srv.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	var eventID uint64
+	for {
+		select {
+		case <-r.Context().Done():
+			srv.logger.InfoContext(r.Context(), "Rates stream closed by client", "titles", titles)
+			return
+		case <-srv.closing:
+			srv.logger.InfoContext(r.Context(), "Rates stream closed by server shutdown", "titles", titles)
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case coin, ok := <-sub.Events():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					srv.logger.ErrorContext(r.Context(), "Rates stream subscriber dropped", "titles", titles, "err", err)
+					data, _ := json.Marshal(newProblem(r, err))
+					_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+					flusher.Flush()
+				}
+				return
+			}
+
+			data, err := json.Marshal(dto.HistoricalCoinDTO{
+				Title:    coin.Title,
+				Cost:     coin.Cost,
+				ActualAt: coin.ActualAt,
+			})
+			if err != nil {
+				srv.logger.ErrorContext(r.Context(), "Unable to encode stream event", "err", err)
+				continue
+			}
+
+			eventID++
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: price\ndata: %s\n\n", eventID, data); err != nil {
+				srv.logger.ErrorContext(r.Context(), "Failed to write stream event", "err", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package http_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/broker"
+	"Cryptoproject/internal/cases"
+	mocks "Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+	myhttp "Cryptoproject/internal/ports/http"
+	"Cryptoproject/pkg/dto"
+)
+
+func setupStreamServer(t *testing.T, b *broker.Broker, opts ...myhttp.ServerOption) string {
+	t.Helper()
+
+	service, err := cases.NewService(mocks.NewMockStorage(gomock.NewController(t)), &fakeProvider{})
+	require.NoError(t, err)
+
+	server, err := myhttp.NewServer(":0", service, append(opts, myhttp.WithBroker(b))...)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(server.Router)
+	t.Cleanup(ts.Close)
+
+	return ts.URL + "/v1/rates/stream"
+}
+
+// openStream connects to the stream and returns a reader positioned after the subscription comment,
+// so the subscriber is registered in the broker once it returns.
+func openStream(t *testing.T, url string) *bufio.Reader {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	require.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	reader := bufio.NewReader(resp.Body)
+	require.Equal(t, ": subscribed\n\n", readFrame(t, reader))
+	return reader
+}
+
+// readFrame reads one event stream frame including its terminating blank line.
+func readFrame(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+
+	var frame strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		frame.WriteString(line)
+		if line == "\n" {
+			return frame.String()
+		}
+	}
+}
+
+func TestStreamRates_PriceEvent(t *testing.T) {
+	t.Parallel()
+
+	b, err := broker.NewBroker()
+	require.NoError(t, err)
+	reader := openStream(t, setupStreamServer(t, b)+"?titles=BTC")
+
+	actualAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	b.Publish(context.Background(), []entities.Coin{
+		{Title: "ETH", Cost: 3000, ActualAt: actualAt},
+		{Title: "BTC", Cost: 50000, ActualAt: actualAt},
+	})
+
+	require.Equal(t, "id: 1\nevent: price\ndata: {\"title\":\"BTC\",\"cost\":50000,\"actualAt\":\"2025-01-02T03:04:05Z\"}\n\n",
+		readFrame(t, reader), "only the subscribed coin is streamed")
+}
+
+func TestStreamRates_Heartbeat(t *testing.T) {
+	t.Parallel()
+
+	b, err := broker.NewBroker()
+	require.NoError(t, err)
+	reader := openStream(t, setupStreamServer(t, b, myhttp.WithHeartbeatInterval(20*time.Millisecond))+"?titles=BTC")
+
+	for i := 0; i < 2; i++ {
+		require.Equal(t, ": ping\n\n", readFrame(t, reader))
+	}
+}
+
+func TestStreamRates_SlowConsumer(t *testing.T) {
+	t.Parallel()
+
+	b, err := broker.NewBroker(broker.WithBufferSize(1))
+	require.NoError(t, err)
+	reader := openStream(t, setupStreamServer(t, b)+"?titles=BTC")
+
+	// A single publish fills the buffer of one faster than the handler can drain it.
+	coins := make([]entities.Coin, 1000)
+	for i := range coins {
+		coins[i] = entities.Coin{Title: "BTC", Cost: float64(i + 1)}
+	}
+	b.Publish(context.Background(), coins)
+
+	var frame string
+	for {
+		frame = readFrame(t, reader)
+		if !strings.HasPrefix(frame, "id: ") {
+			break
+		}
+	}
+
+	data, found := strings.CutPrefix(frame, "event: error\ndata: ")
+	require.True(t, found, "unexpected frame %q", frame)
+	var problem dto.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(data)), &problem))
+	require.Equal(t, "slow_consumer", problem.Code)
+	require.Equal(t, http.StatusServiceUnavailable, problem.Status)
+
+	_, err = reader.ReadString('\n')
+	require.Error(t, err, "the stream is closed after the error event")
+}
+
+func TestStreamRates_EmptyTitles(t *testing.T) {
+	t.Parallel()
+
+	b, err := broker.NewBroker()
+	require.NoError(t, err)
+
+	resp, err := http.Get(setupStreamServer(t, b) + "?titles=,") //nolint:noctx //ok
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	var problem dto.ErrorResponseDTO
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	require.Equal(t, "invalid_param", problem.Code)
+}
@@ -0,0 +1,601 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	broker "Cryptoproject/internal/adapters/broker"
+	entities "Cryptoproject/internal/entities"
+	context "context"
+	http "net/http"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// ApproveQuarantinedRate mocks base method.
+func (m *MockService) ApproveQuarantinedRate(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveQuarantinedRate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveQuarantinedRate indicates an expected call of ApproveQuarantinedRate.
+func (mr *MockServiceMockRecorder) ApproveQuarantinedRate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveQuarantinedRate", reflect.TypeOf((*MockService)(nil).ApproveQuarantinedRate), ctx, id)
+}
+
+// Authenticate mocks base method.
+func (m *MockService) Authenticate(ctx context.Context, plaintext string) (*entities.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, plaintext)
+	ret0, _ := ret[0].(*entities.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockServiceMockRecorder) Authenticate(ctx, plaintext interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockService)(nil).Authenticate), ctx, plaintext)
+}
+
+// ConsumeQuota mocks base method.
+func (m *MockService) ConsumeQuota(ctx context.Context, key *entities.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeQuota", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeQuota indicates an expected call of ConsumeQuota.
+func (mr *MockServiceMockRecorder) ConsumeQuota(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeQuota", reflect.TypeOf((*MockService)(nil).ConsumeQuota), ctx, key)
+}
+
+// Convert mocks base method.
+func (m *MockService) Convert(ctx context.Context, from, to string, amount float64, at time.Time, maxStaleness time.Duration) (*entities.Conversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Convert", ctx, from, to, amount, at, maxStaleness)
+	ret0, _ := ret[0].(*entities.Conversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Convert indicates an expected call of Convert.
+func (mr *MockServiceMockRecorder) Convert(ctx, from, to, amount, at, maxStaleness interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Convert", reflect.TypeOf((*MockService)(nil).Convert), ctx, from, to, amount, at, maxStaleness)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockService) CreateAPIKey(ctx context.Context, name string, rateLimit float64, burst int, dailyQuota int64) (*entities.APIKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, name, rateLimit, burst, dailyQuota)
+	ret0, _ := ret[0].(*entities.APIKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockServiceMockRecorder) CreateAPIKey(ctx, name, rateLimit, burst, dailyQuota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockService)(nil).CreateAPIKey), ctx, name, rateLimit, burst, dailyQuota)
+}
+
+// CreatePortfolio mocks base method.
+func (m *MockService) CreatePortfolio(ctx context.Context, name string, positions []entities.Position) (*entities.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePortfolio", ctx, name, positions)
+	ret0, _ := ret[0].(*entities.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePortfolio indicates an expected call of CreatePortfolio.
+func (mr *MockServiceMockRecorder) CreatePortfolio(ctx, name, positions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePortfolio", reflect.TypeOf((*MockService)(nil).CreatePortfolio), ctx, name, positions)
+}
+
+// DeletePortfolio mocks base method.
+func (m *MockService) DeletePortfolio(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePortfolio", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePortfolio indicates an expected call of DeletePortfolio.
+func (mr *MockServiceMockRecorder) DeletePortfolio(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePortfolio", reflect.TypeOf((*MockService)(nil).DeletePortfolio), ctx, id)
+}
+
+// DiscardQuarantinedRate mocks base method.
+func (m *MockService) DiscardQuarantinedRate(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardQuarantinedRate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardQuarantinedRate indicates an expected call of DiscardQuarantinedRate.
+func (mr *MockServiceMockRecorder) DiscardQuarantinedRate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardQuarantinedRate", reflect.TypeOf((*MockService)(nil).DiscardQuarantinedRate), ctx, id)
+}
+
+// GetAPIKeyUsage mocks base method.
+func (m *MockService) GetAPIKeyUsage(ctx context.Context, from, to time.Time) ([]*entities.APIKeyUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyUsage", ctx, from, to)
+	ret0, _ := ret[0].([]*entities.APIKeyUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyUsage indicates an expected call of GetAPIKeyUsage.
+func (mr *MockServiceMockRecorder) GetAPIKeyUsage(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyUsage", reflect.TypeOf((*MockService)(nil).GetAPIKeyUsage), ctx, from, to)
+}
+
+// GetAggregateRates mocks base method.
+func (m *MockService) GetAggregateRates(ctx context.Context, title []string, aggType string, from, to time.Time) ([]*entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregateRates", ctx, title, aggType, from, to)
+	ret0, _ := ret[0].([]*entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregateRates indicates an expected call of GetAggregateRates.
+func (mr *MockServiceMockRecorder) GetAggregateRates(ctx, title, aggType, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateRates", reflect.TypeOf((*MockService)(nil).GetAggregateRates), ctx, title, aggType, from, to)
+}
+
+// GetAggregateStats mocks base method.
+func (m *MockService) GetAggregateStats(ctx context.Context, title, aggTypes []string, from, to time.Time) ([]*entities.CoinStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregateStats", ctx, title, aggTypes, from, to)
+	ret0, _ := ret[0].([]*entities.CoinStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregateStats indicates an expected call of GetAggregateStats.
+func (mr *MockServiceMockRecorder) GetAggregateStats(ctx, title, aggTypes, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateStats", reflect.TypeOf((*MockService)(nil).GetAggregateStats), ctx, title, aggTypes, from, to)
+}
+
+// GetCoinHistory mocks base method.
+func (m *MockService) GetCoinHistory(ctx context.Context, title string, from, to time.Time, limit int) ([]*entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoinHistory", ctx, title, from, to, limit)
+	ret0, _ := ret[0].([]*entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoinHistory indicates an expected call of GetCoinHistory.
+func (mr *MockServiceMockRecorder) GetCoinHistory(ctx, title, from, to, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinHistory", reflect.TypeOf((*MockService)(nil).GetCoinHistory), ctx, title, from, to, limit)
+}
+
+// GetLastRates mocks base method.
+func (m *MockService) GetLastRates(ctx context.Context, title []string) ([]*entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastRates", ctx, title)
+	ret0, _ := ret[0].([]*entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastRates indicates an expected call of GetLastRates.
+func (mr *MockServiceMockRecorder) GetLastRates(ctx, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastRates", reflect.TypeOf((*MockService)(nil).GetLastRates), ctx, title)
+}
+
+// GetPortfolio mocks base method.
+func (m *MockService) GetPortfolio(ctx context.Context, id int64) (*entities.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortfolio", ctx, id)
+	ret0, _ := ret[0].(*entities.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortfolio indicates an expected call of GetPortfolio.
+func (mr *MockServiceMockRecorder) GetPortfolio(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortfolio", reflect.TypeOf((*MockService)(nil).GetPortfolio), ctx, id)
+}
+
+// GetQualityReport mocks base method.
+func (m *MockService) GetQualityReport(ctx context.Context, from, to time.Time) (*entities.QualityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQualityReport", ctx, from, to)
+	ret0, _ := ret[0].(*entities.QualityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQualityReport indicates an expected call of GetQualityReport.
+func (mr *MockServiceMockRecorder) GetQualityReport(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQualityReport", reflect.TypeOf((*MockService)(nil).GetQualityReport), ctx, from, to)
+}
+
+// GetRatesAt mocks base method.
+func (m *MockService) GetRatesAt(ctx context.Context, title []string, at time.Time, maxStaleness time.Duration) ([]*entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatesAt", ctx, title, at, maxStaleness)
+	ret0, _ := ret[0].([]*entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatesAt indicates an expected call of GetRatesAt.
+func (mr *MockServiceMockRecorder) GetRatesAt(ctx, title, at, maxStaleness interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatesAt", reflect.TypeOf((*MockService)(nil).GetRatesAt), ctx, title, at, maxStaleness)
+}
+
+// GetStoredAggregateStats mocks base method.
+func (m *MockService) GetStoredAggregateStats(ctx context.Context, title, aggTypes []string, from, to time.Time) ([]*entities.CoinStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStoredAggregateStats", ctx, title, aggTypes, from, to)
+	ret0, _ := ret[0].([]*entities.CoinStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStoredAggregateStats indicates an expected call of GetStoredAggregateStats.
+func (mr *MockServiceMockRecorder) GetStoredAggregateStats(ctx, title, aggTypes, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoredAggregateStats", reflect.TypeOf((*MockService)(nil).GetStoredAggregateStats), ctx, title, aggTypes, from, to)
+}
+
+// GetStoredRates mocks base method.
+func (m *MockService) GetStoredRates(ctx context.Context, title []string) ([]*entities.Coin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStoredRates", ctx, title)
+	ret0, _ := ret[0].([]*entities.Coin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStoredRates indicates an expected call of GetStoredRates.
+func (mr *MockServiceMockRecorder) GetStoredRates(ctx, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoredRates", reflect.TypeOf((*MockService)(nil).GetStoredRates), ctx, title)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockService) ListAPIKeys(ctx context.Context) ([]*entities.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx)
+	ret0, _ := ret[0].([]*entities.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockServiceMockRecorder) ListAPIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockService)(nil).ListAPIKeys), ctx)
+}
+
+// ListPortfolios mocks base method.
+func (m *MockService) ListPortfolios(ctx context.Context) ([]*entities.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPortfolios", ctx)
+	ret0, _ := ret[0].([]*entities.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPortfolios indicates an expected call of ListPortfolios.
+func (mr *MockServiceMockRecorder) ListPortfolios(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPortfolios", reflect.TypeOf((*MockService)(nil).ListPortfolios), ctx)
+}
+
+// ListQuarantinedRates mocks base method.
+func (m *MockService) ListQuarantinedRates(ctx context.Context, status entities.QuarantineStatus) ([]*entities.QuarantinedRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQuarantinedRates", ctx, status)
+	ret0, _ := ret[0].([]*entities.QuarantinedRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQuarantinedRates indicates an expected call of ListQuarantinedRates.
+func (mr *MockServiceMockRecorder) ListQuarantinedRates(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuarantinedRates", reflect.TypeOf((*MockService)(nil).ListQuarantinedRates), ctx, status)
+}
+
+// Readiness mocks base method.
+func (m *MockService) Readiness(ctx context.Context) *entities.Health {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Readiness", ctx)
+	ret0, _ := ret[0].(*entities.Health)
+	return ret0
+}
+
+// Readiness indicates an expected call of Readiness.
+func (mr *MockServiceMockRecorder) Readiness(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Readiness", reflect.TypeOf((*MockService)(nil).Readiness), ctx)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockService) RevokeAPIKey(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockServiceMockRecorder) RevokeAPIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockService)(nil).RevokeAPIKey), ctx, id)
+}
+
+// UpdatePortfolio mocks base method.
+func (m *MockService) UpdatePortfolio(ctx context.Context, id int64, name string, positions []entities.Position) (*entities.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePortfolio", ctx, id, name, positions)
+	ret0, _ := ret[0].(*entities.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePortfolio indicates an expected call of UpdatePortfolio.
+func (mr *MockServiceMockRecorder) UpdatePortfolio(ctx, id, name, positions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePortfolio", reflect.TypeOf((*MockService)(nil).UpdatePortfolio), ctx, id, name, positions)
+}
+
+// ValuePortfolio mocks base method.
+func (m *MockService) ValuePortfolio(ctx context.Context, positions []entities.Position, at time.Time) (*entities.PortfolioValuation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValuePortfolio", ctx, positions, at)
+	ret0, _ := ret[0].(*entities.PortfolioValuation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValuePortfolio indicates an expected call of ValuePortfolio.
+func (mr *MockServiceMockRecorder) ValuePortfolio(ctx, positions, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValuePortfolio", reflect.TypeOf((*MockService)(nil).ValuePortfolio), ctx, positions, at)
+}
+
+// ValueStoredPortfolio mocks base method.
+func (m *MockService) ValueStoredPortfolio(ctx context.Context, id int64, at time.Time) (*entities.Portfolio, *entities.PortfolioValuation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValueStoredPortfolio", ctx, id, at)
+	ret0, _ := ret[0].(*entities.Portfolio)
+	ret1, _ := ret[1].(*entities.PortfolioValuation)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ValueStoredPortfolio indicates an expected call of ValueStoredPortfolio.
+func (mr *MockServiceMockRecorder) ValueStoredPortfolio(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValueStoredPortfolio", reflect.TypeOf((*MockService)(nil).ValueStoredPortfolio), ctx, id, at)
+}
+
+// MockBroker is a mock of Broker interface.
+type MockBroker struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrokerMockRecorder
+}
+
+// MockBrokerMockRecorder is the mock recorder for MockBroker.
+type MockBrokerMockRecorder struct {
+	mock *MockBroker
+}
+
+// NewMockBroker creates a new mock instance.
+func NewMockBroker(ctrl *gomock.Controller) *MockBroker {
+	mock := &MockBroker{ctrl: ctrl}
+	mock.recorder = &MockBrokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBroker) EXPECT() *MockBrokerMockRecorder {
+	return m.recorder
+}
+
+// AddTitles mocks base method.
+func (m *MockBroker) AddTitles(sub *broker.Subscription, titles []string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTitles", sub, titles)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// AddTitles indicates an expected call of AddTitles.
+func (mr *MockBrokerMockRecorder) AddTitles(sub, titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTitles", reflect.TypeOf((*MockBroker)(nil).AddTitles), sub, titles)
+}
+
+// RemoveTitles mocks base method.
+func (m *MockBroker) RemoveTitles(sub *broker.Subscription, titles []string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTitles", sub, titles)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// RemoveTitles indicates an expected call of RemoveTitles.
+func (mr *MockBrokerMockRecorder) RemoveTitles(sub, titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTitles", reflect.TypeOf((*MockBroker)(nil).RemoveTitles), sub, titles)
+}
+
+// Subscribe mocks base method.
+func (m *MockBroker) Subscribe(titles []string) *broker.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", titles)
+	ret0, _ := ret[0].(*broker.Subscription)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockBrokerMockRecorder) Subscribe(titles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockBroker)(nil).Subscribe), titles)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockBroker) Unsubscribe(sub *broker.Subscription) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe", sub)
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockBrokerMockRecorder) Unsubscribe(sub interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockBroker)(nil).Unsubscribe), sub)
+}
+
+// MockKeySet is a mock of KeySet interface.
+type MockKeySet struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeySetMockRecorder
+}
+
+// MockKeySetMockRecorder is the mock recorder for MockKeySet.
+type MockKeySetMockRecorder struct {
+	mock *MockKeySet
+}
+
+// NewMockKeySet creates a new mock instance.
+func NewMockKeySet(ctrl *gomock.Controller) *MockKeySet {
+	mock := &MockKeySet{ctrl: ctrl}
+	mock.recorder = &MockKeySetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeySet) EXPECT() *MockKeySetMockRecorder {
+	return m.recorder
+}
+
+// Key mocks base method.
+func (m *MockKeySet) Key(ctx context.Context, kid string) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Key", ctx, kid)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Key indicates an expected call of Key.
+func (mr *MockKeySetMockRecorder) Key(ctx, kid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Key", reflect.TypeOf((*MockKeySet)(nil).Key), ctx, kid)
+}
+
+// MockMetrics is a mock of Metrics interface.
+type MockMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsMockRecorder
+}
+
+// MockMetricsMockRecorder is the mock recorder for MockMetrics.
+type MockMetricsMockRecorder struct {
+	mock *MockMetrics
+}
+
+// NewMockMetrics creates a new mock instance.
+func NewMockMetrics(ctrl *gomock.Controller) *MockMetrics {
+	mock := &MockMetrics{ctrl: ctrl}
+	mock.recorder = &MockMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetrics) EXPECT() *MockMetricsMockRecorder {
+	return m.recorder
+}
+
+// Handler mocks base method.
+func (m *MockMetrics) Handler() http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handler")
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// Handler indicates an expected call of Handler.
+func (mr *MockMetricsMockRecorder) Handler() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handler", reflect.TypeOf((*MockMetrics)(nil).Handler))
+}
+
+// ObserveCacheLookup mocks base method.
+func (m *MockMetrics) ObserveCacheLookup(hit bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveCacheLookup", hit)
+}
+
+// ObserveCacheLookup indicates an expected call of ObserveCacheLookup.
+func (mr *MockMetricsMockRecorder) ObserveCacheLookup(hit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveCacheLookup", reflect.TypeOf((*MockMetrics)(nil).ObserveCacheLookup), hit)
+}
+
+// ObserveHTTPRequest mocks base method.
+func (m *MockMetrics) ObserveHTTPRequest(route, method string, status int, duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveHTTPRequest", route, method, status, duration)
+}
+
+// ObserveHTTPRequest indicates an expected call of ObserveHTTPRequest.
+func (mr *MockMetricsMockRecorder) ObserveHTTPRequest(route, method, status, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveHTTPRequest", reflect.TypeOf((*MockMetrics)(nil).ObserveHTTPRequest), route, method, status, duration)
+}
@@ -0,0 +1,54 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"Cryptoproject/internal/entities"
+)
+
+// TestTracing_ServerSpan is not parallel because it swaps the global tracer provider.
+func TestTracing_ServerSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	server, mockService := setupServer(t)
+	mockService.EXPECT().GetCoinHistory(gomock.Any(), "BTC", gomock.Any(), gomock.Any(), gomock.Any()).Return([]*entities.Coin{
+		{Title: "BTC", Cost: 50000, ActualAt: time.Now()},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/coins/BTC/rates", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("X-Request-Id", "req-7")
+	require.Equal(t, http.StatusOK, serve(server, req).Code)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	require.Equal(t, "GET /v1/coins/{title}/rates", span.Name())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+
+	attrs := make(map[string]string)
+	for _, attr := range span.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	require.Equal(t, "/v1/coins/{title}/rates", attrs["http.route"])
+	require.Equal(t, "req-7", attrs["http.request_id"])
+}
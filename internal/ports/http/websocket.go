@@ -0,0 +1,185 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"Cryptoproject/internal/adapters/broker"
+	"Cryptoproject/pkg/dto"
+)
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsMaxMessageSize = 4096
+	wsRepliesBuffer  = 8
+
+	wsActionSubscribe   = "subscribe"
+	wsActionUnsubscribe = "unsubscribe"
+
+	wsTypeSubscribed   = "subscribed"
+	wsTypeUnsubscribed = "unsubscribed"
+	wsTypePrice        = "price"
+	wsTypeError        = "error"
+)
+
+func (srv *Server) newUpgrader() *websocket.Upgrader {
+	return &websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     srv.checkOrigin,
+	}
+}
+
+// checkOrigin accepts clients without an Origin header, same-origin pages and the origins allowed for
+// CORS, matched the way the CORS middleware matches them: case-insensitively, "*" allowing any origin
+// and a single "*" inside an origin standing for any substring.
+func (srv *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+	for _, allowed := range srv.cors.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+		if prefix, suffix, found := strings.Cut(allowed, "*"); found &&
+			len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// @Summary Subscribe to rates over WebSocket
+// @Description Upgrades to a WebSocket. Clients send {"action":"subscribe"|"unsubscribe","titles":[...]} messages
+// @Description and receive "subscribed"/"unsubscribed" acknowledgements, a "price" message for every stored rate
+// @Description of the subscribed cryptocurrencies, and "error" messages. The server pings idle connections.
+// @Tags Coins
+// @Param request body dto.WebSocketRequestDTO false "Client message"
+// @Success 101 {object} dto.WebSocketMessageDTO "Server message"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /rates/ws [get]
+func (srv *Server) websocketRates(w http.ResponseWriter, r *http.Request) {
+	conn, err := srv.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		srv.logger.ErrorContext(r.Context(), "Failed to upgrade to websocket", "err", err)
+		return
+	}
+	defer conn.Close() //nolint:errcheck //ok
+
+	sub := srv.Broker.Subscribe(nil)
+	defer srv.Broker.Unsubscribe(sub)
+
+	replies := make(chan dto.WebSocketMessageDTO, wsRepliesBuffer)
+	done := make(chan struct{})
+	quit := make(chan struct{})
+	defer close(quit)
+	go srv.websocketReadLoop(conn, sub, replies, done, quit)
+
+	srv.websocketWriteLoop(conn, sub, replies, done)
+}
+
+func (srv *Server) websocketReadLoop(
+	conn *websocket.Conn,
+	sub *broker.Subscription,
+	replies chan<- dto.WebSocketMessageDTO,
+	done chan<- struct{},
+	quit <-chan struct{},
+) {
+	defer close(done)
+
+	pongWait := 2 * srv.heartbeatInterval
+	conn.SetReadLimit(wsMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		var req dto.WebSocketRequestDTO
+		if err := conn.ReadJSON(&req); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				srv.logger.Error("Websocket read failed", "err", err)
+			}
+			return
+		}
+
+		var reply dto.WebSocketMessageDTO
+		titles := srv.removeEmptyStrings(req.Titles)
+		switch {
+		case len(titles) == 0:
+			reply = dto.WebSocketMessageDTO{Type: wsTypeError, Message: "titles list cannot be empty"}
+		case req.Action == wsActionSubscribe:
+			reply = dto.WebSocketMessageDTO{Type: wsTypeSubscribed, Titles: srv.Broker.AddTitles(sub, titles)}
+		case req.Action == wsActionUnsubscribe:
+			reply = dto.WebSocketMessageDTO{Type: wsTypeUnsubscribed, Titles: srv.Broker.RemoveTitles(sub, titles)}
+		default:
+			reply = dto.WebSocketMessageDTO{Type: wsTypeError, Message: "unsupported action " + req.Action}
+		}
+
+		select {
+		case replies <- reply:
+		case <-quit:
+			return
+		}
+	}
+}
+
+func (srv *Server) websocketWriteLoop(conn *websocket.Conn, sub *broker.Subscription, replies <-chan dto.WebSocketMessageDTO, done <-chan struct{}) {
+	heartbeat := time.NewTicker(srv.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var msg dto.WebSocketMessageDTO
+		select {
+		case <-done:
+			return
+		case <-srv.closing:
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"))
+			return
+		case <-heartbeat.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				srv.logger.Error("Websocket ping failed", "err", err)
+				return
+			}
+			continue
+		case msg = <-replies:
+		case coin, ok := <-sub.Events():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					srv.logger.Error("Websocket subscriber dropped", "err", err)
+					_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+					_ = conn.WriteJSON(dto.WebSocketMessageDTO{Type: wsTypeError, Message: err.Error()})
+				}
+				return
+			}
+			msg = dto.WebSocketMessageDTO{
+				Type: wsTypePrice,
+				Coin: &dto.HistoricalCoinDTO{
+					Title:    coin.Title,
+					Cost:     coin.Cost,
+					ActualAt: coin.ActualAt,
+				},
+			}
+		}
+
+		_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+		if err := conn.WriteJSON(msg); err != nil {
+			srv.logger.Error("Websocket write failed", "err", err)
+			return
+		}
+	}
+}
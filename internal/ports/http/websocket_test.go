@@ -0,0 +1,327 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"Cryptoproject/internal/adapters/broker"
+	"Cryptoproject/internal/cases"
+	mocks "Cryptoproject/internal/cases/testdata"
+	"Cryptoproject/internal/entities"
+	myhttp "Cryptoproject/internal/ports/http"
+	"Cryptoproject/pkg/dto"
+)
+
+type fakeProvider struct {
+	rates map[string]float64
+}
+
+func (p *fakeProvider) GetActualRates(_ context.Context, titles []string) ([]entities.Coin, error) {
+	coins := make([]entities.Coin, 0, len(titles))
+	for _, title := range titles {
+		if cost, exists := p.rates[title]; exists {
+			coins = append(coins, entities.Coin{Title: title, Cost: cost})
+		}
+	}
+	return coins, nil
+}
+
+func setupWebSocketServer(t *testing.T, opts ...myhttp.ServerOption) (*cases.Service, string) {
+	t.Helper()
+
+	b, err := broker.NewBroker()
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockStorage := mocks.NewMockStorage(ctrl)
+	mockStorage.EXPECT().GetCoinsList(gomock.Any()).Return([]string{"BTC", "ETH"}, nil).AnyTimes()
+	mockStorage.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	provider := &fakeProvider{rates: map[string]float64{"BTC": 50000, "ETH": 3000}}
+
+	service, err := cases.NewService(mockStorage, provider, cases.WithPublisher(b))
+	require.NoError(t, err)
+
+	server, err := myhttp.NewServer(":0", service, append(opts, myhttp.WithBroker(b))...)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(server.Router)
+	t.Cleanup(ts.Close)
+
+	return service, "ws" + strings.TrimPrefix(ts.URL, "http") + "/rates/ws"
+}
+
+func subscribe(url string, titles ...string) (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.WriteJSON(dto.WebSocketRequestDTO{Action: "subscribe", Titles: titles}); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	var ack dto.WebSocketMessageDTO
+	if err := conn.ReadJSON(&ack); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if ack.Type != "subscribed" {
+		_ = conn.Close()
+		return nil, errors.Errorf("unexpected acknowledgement %q", ack.Type)
+	}
+
+	return conn, nil
+}
+
+func dialAndSubscribe(t *testing.T, url string, titles ...string) *websocket.Conn {
+	t.Helper()
+
+	conn, err := subscribe(url, titles...)
+	require.NoError(t, err)
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) dto.WebSocketMessageDTO {
+	t.Helper()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+	var msg dto.WebSocketMessageDTO
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func TestWebSocket_SubscribeUnsubscribe(t *testing.T) {
+	t.Parallel()
+
+	service, url := setupWebSocketServer(t)
+
+	conn := dialAndSubscribe(t, url, "BTC", "ETH")
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(dto.WebSocketRequestDTO{Action: "unsubscribe", Titles: []string{"BTC"}}))
+	msg := readMessage(t, conn)
+	require.Equal(t, "unsubscribed", msg.Type)
+	require.Equal(t, []string{"ETH"}, msg.Titles)
+
+	require.NoError(t, service.UpdateRates(context.Background()))
+
+	msg = readMessage(t, conn)
+	require.Equal(t, "price", msg.Type)
+	require.Equal(t, "ETH", msg.Coin.Title)
+	require.Equal(t, float64(3000), msg.Coin.Cost)
+	require.False(t, msg.Coin.ActualAt.IsZero())
+}
+
+func TestWebSocket_InvalidMessage(t *testing.T) {
+	t.Parallel()
+
+	_, url := setupWebSocketServer(t)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(dto.WebSocketRequestDTO{Action: "subscribe"}))
+	msg := readMessage(t, conn)
+	require.Equal(t, "error", msg.Type)
+	require.Equal(t, "titles list cannot be empty", msg.Message)
+
+	require.NoError(t, conn.WriteJSON(dto.WebSocketRequestDTO{Action: "replay", Titles: []string{"BTC"}}))
+	msg = readMessage(t, conn)
+	require.Equal(t, "error", msg.Type)
+	require.Equal(t, "unsupported action replay", msg.Message)
+}
+
+func TestWebSocket_CheckOrigin(t *testing.T) {
+	t.Parallel()
+
+	_, url := setupWebSocketServer(t, myhttp.WithCORS(myhttp.CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com", "https://*.trusted.example"},
+	}))
+	host := strings.TrimPrefix(url, "ws://")
+	host = host[:strings.IndexByte(host, '/')]
+
+	tests := map[string]struct {
+		origin  string
+		allowed bool
+	}{
+		"no origin":        {"", true},
+		"same origin":      {"http://" + host, true},
+		"allowed origin":   {"https://APP.example.com", true},
+		"wildcard origin":  {"https://eu.trusted.example", true},
+		"foreign origin":   {"https://evil.example.com", false},
+		"lookalike origin": {"https://app.example.com.evil.example", false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			header := http.Header{}
+			if tc.origin != "" {
+				header.Set("Origin", tc.origin)
+			}
+			conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+			if tc.allowed {
+				require.NoError(t, err)
+				_ = conn.Close()
+				return
+			}
+			require.ErrorIs(t, err, websocket.ErrBadHandshake)
+			require.Equal(t, http.StatusForbidden, resp.StatusCode)
+		})
+	}
+}
+
+func TestWebSocket_CheckOriginWithoutCORS(t *testing.T) {
+	t.Parallel()
+
+	_, url := setupWebSocketServer(t)
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": []string{"https://app.example.com"}})
+	require.ErrorIs(t, err, websocket.ErrBadHandshake, "only same-origin pages may connect without allowed origins")
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestWebSocket_Heartbeat(t *testing.T) {
+	t.Parallel()
+
+	_, url := setupWebSocketServer(t, myhttp.WithHeartbeatInterval(20*time.Millisecond))
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	pings := make(chan struct{}, 10)
+	conn.SetPingHandler(func(appData string) error {
+		select {
+		case pings <- struct{}{}:
+		default:
+		}
+		return conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(time.Second))
+	})
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-pings:
+		case <-time.After(5 * time.Second):
+			t.Fatal("heartbeat ping was not received")
+		}
+	}
+}
+
+func TestWebSocket_LoadManySubscribers(t *testing.T) {
+	t.Parallel()
+
+	subscribers := 2000
+	if testing.Short() {
+		subscribers = 100
+	}
+
+	service, url := setupWebSocketServer(t)
+
+	conns := make([]*websocket.Conn, subscribers)
+	titles := []string{"BTC", "ETH"}
+
+	var wg sync.WaitGroup
+	dialErrs := make(chan error, subscribers)
+	dialers := make(chan struct{}, 64)
+	for i := range conns {
+		wg.Add(1)
+		dialers <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-dialers }()
+
+			conn, err := subscribe(url, titles[i%len(titles)])
+			if err != nil {
+				dialErrs <- err
+				return
+			}
+			conns[i] = conn
+		}(i)
+	}
+	wg.Wait()
+	close(dialErrs)
+	defer func() {
+		for _, conn := range conns {
+			if conn != nil {
+				_ = conn.Close()
+			}
+		}
+	}()
+
+	for err := range dialErrs {
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, service.UpdateRates(context.Background()))
+
+	errs := make(chan error, subscribers)
+	for i, conn := range conns {
+		wg.Add(1)
+		go func(i int, conn *websocket.Conn) {
+			defer wg.Done()
+			_ = conn.SetReadDeadline(time.Now().Add(30 * time.Second))
+			var msg dto.WebSocketMessageDTO
+			if err := conn.ReadJSON(&msg); err != nil {
+				errs <- err
+				return
+			}
+			if msg.Type != "price" || msg.Coin == nil || msg.Coin.Title != titles[i%len(titles)] {
+				errs <- errors.Errorf("unexpected message %+v for subscriber %d", msg, i)
+			}
+		}(i, conn)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func TestWebSocket_ClosedOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	b, err := broker.NewBroker()
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	service, err := cases.NewService(mocks.NewMockStorage(ctrl), &fakeProvider{}, cases.WithPublisher(b))
+	require.NoError(t, err)
+
+	server, err := myhttp.NewServer(":0", service, myhttp.WithBroker(b))
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(server.Router)
+	t.Cleanup(ts.Close)
+
+	conn := dialAndSubscribe(t, "ws"+strings.TrimPrefix(ts.URL, "http")+"/rates/ws", "BTC")
+	defer conn.Close() //nolint:errcheck //ok
+
+	require.NoError(t, server.Shutdown(context.Background()))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "unexpected error: %v", err)
+}
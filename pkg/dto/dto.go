@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // ResponseDTO model contains a collection of CoinDTO objects representing the final response.
 // swagger:model
 type ResponseDTO struct {
@@ -14,15 +16,274 @@ type CoinDTO struct {
 }
 
 // ErrorResponseDTO model defines the format of an error response when something goes wrong.
+// It follows RFC 7807 problem details and is served as application/problem+json.
 // swagger:model
 type ErrorResponseDTO struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	RequestID string `json:"requestId,omitempty"`
 }
 
 // RequestDTO model specifies the input data needed for retrieving rates.
 // swagger:model
 type RequestDTO struct {
-	Titles  []string `json:"titles"`
-	AggType string   `json:"aggType"`
+	Titles   []string  `json:"titles"`
+	AggType  string    `json:"aggType"`
+	AggTypes []string  `json:"aggTypes"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+}
+
+// AggregateResponseDTO model contains per-coin statistics for a multi aggregation request.
+// swagger:model
+type AggregateResponseDTO struct {
+	Coins []CoinStatsDTO `json:"coins"`
+}
+
+// CoinStatsDTO model represents the requested statistics of a single cryptocurrency keyed by aggregation type.
+// Statistics that are undefined, such as CHANGE_PCT from a zero first rate, are omitted.
+// swagger:model
+type CoinStatsDTO struct {
+	Title     string             `json:"title"`
+	Stats     map[string]float64 `json:"stats"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// RateAtRequestDTO model specifies the input data needed for retrieving rates at a point in time.
+// swagger:model
+type RateAtRequestDTO struct {
+	Titles       []string  `json:"titles"`
+	At           time.Time `json:"at"`
+	MaxStaleness string    `json:"maxStaleness"`
+}
+
+// HistoricalResponseDTO model contains the stored rates found at or before the requested time.
+// swagger:model
+type HistoricalResponseDTO struct {
+	At    time.Time           `json:"at"`
+	Coins []HistoricalCoinDTO `json:"coins"`
+}
+
+// HistoricalCoinDTO model represents a stored rate of a single cryptocurrency together with its timestamp.
+// swagger:model
+type HistoricalCoinDTO struct {
+	Title    string    `json:"title"`
+	Cost     float64   `json:"cost"`
+	ActualAt time.Time `json:"actualAt"`
+}
+
+// StoredRatesResponseDTO model contains the latest stored rates together with their timestamps.
+// swagger:model
+type StoredRatesResponseDTO struct {
+	Coins []HistoricalCoinDTO `json:"coins"`
+}
+
+// CoinHistoryResponseDTO model contains stored rates of a single cryptocurrency, newest first.
+// swagger:model
+type CoinHistoryResponseDTO struct {
+	Title string              `json:"title"`
+	Rates []HistoricalCoinDTO `json:"rates"`
+}
+
+// ConversionResponseDTO model contains the result of a cross-rate conversion and the rates it was based on.
+// swagger:model
+type ConversionResponseDTO struct {
+	From   string              `json:"from"`
+	To     string              `json:"to"`
+	Amount float64             `json:"amount"`
+	Rate   float64             `json:"rate"`
+	Result float64             `json:"result"`
+	At     time.Time           `json:"at"`
+	Rates  []ConversionRateDTO `json:"rates"`
+}
+
+// ConversionRateDTO model describes an underlying stored rate used by a conversion and its age.
+// swagger:model
+type ConversionRateDTO struct {
+	Title      string    `json:"title"`
+	Cost       float64   `json:"cost"`
+	ActualAt   time.Time `json:"actualAt"`
+	AgeSeconds float64   `json:"ageSeconds"`
+}
+
+// PortfolioValueRequestDTO model specifies holdings (title → quantity) to be valued, optionally at a past time.
+// swagger:model
+type PortfolioValueRequestDTO struct {
+	Holdings  map[string]float64 `json:"holdings"`
+	CostBasis map[string]float64 `json:"costBasis"`
+	At        time.Time          `json:"at"`
+}
+
+// PortfolioRequestDTO model specifies a portfolio to be saved.
+// swagger:model
+type PortfolioRequestDTO struct {
+	Name      string             `json:"name" maxLength:"100"`
+	Holdings  map[string]float64 `json:"holdings"`
+	CostBasis map[string]float64 `json:"costBasis"`
+}
+
+// PortfolioDTO model represents a saved portfolio.
+// swagger:model
+type PortfolioDTO struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	Holdings  map[string]float64 `json:"holdings"`
+	CostBasis map[string]float64 `json:"costBasis"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// PortfoliosResponseDTO model contains a collection of saved portfolios.
+// swagger:model
+type PortfoliosResponseDTO struct {
+	Portfolios []PortfolioDTO `json:"portfolios"`
+}
+
+// PortfolioValuationDTO model contains per-position and total values of a portfolio.
+// swagger:model
+type PortfolioValuationDTO struct {
+	PortfolioID int64                  `json:"portfolioId,omitempty"`
+	At          time.Time              `json:"at"`
+	Positions   []PositionValuationDTO `json:"positions"`
+	TotalValue  float64                `json:"totalValue"`
+	TotalCost   float64                `json:"totalCost"`
+	TotalPnL    float64                `json:"totalPnl"`
+}
+
+// PositionValuationDTO model represents the value and P&L of a single portfolio position.
+// swagger:model
+type PositionValuationDTO struct {
+	Title      string    `json:"title"`
+	Quantity   float64   `json:"quantity"`
+	CostBasis  float64   `json:"costBasis"`
+	Price      float64   `json:"price"`
+	PriceAt    time.Time `json:"priceAt"`
+	Value      float64   `json:"value"`
+	Cost       float64   `json:"cost"`
+	PnL        float64   `json:"pnl"`
+	PnLPercent float64   `json:"pnlPercent"`
+}
+
+// WebSocketRequestDTO model specifies a message sent by a WebSocket client.
+// Action is one of "subscribe" or "unsubscribe".
+// swagger:model
+type WebSocketRequestDTO struct {
+	Action string   `json:"action"`
+	Titles []string `json:"titles"`
+}
+
+// WebSocketMessageDTO model specifies a message pushed to a WebSocket client.
+// Type is one of "subscribed", "unsubscribed", "price" or "error".
+// swagger:model
+type WebSocketMessageDTO struct {
+	Type    string             `json:"type"`
+	Titles  []string           `json:"titles,omitempty"`
+	Coin    *HistoricalCoinDTO `json:"coin,omitempty"`
+	Message string             `json:"message,omitempty"`
+}
+
+// APIKeyRequestDTO model specifies an API key to be issued.
+// RateLimit is in requests per second, a zero DailyQuota means unlimited.
+// swagger:model
+type APIKeyRequestDTO struct {
+	Name       string  `json:"name"`
+	RateLimit  float64 `json:"rateLimit"`
+	Burst      int     `json:"burst"`
+	DailyQuota int64   `json:"dailyQuota"`
+}
+
+// APIKeyDTO model represents an issued API key. Key holds the plaintext key and is only returned on creation.
+// swagger:model
+type APIKeyDTO struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Key        string     `json:"key,omitempty"`
+	RateLimit  float64    `json:"rateLimit"`
+	Burst      int        `json:"burst"`
+	DailyQuota int64      `json:"dailyQuota"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// APIKeysResponseDTO model contains a collection of issued API keys.
+// swagger:model
+type APIKeysResponseDTO struct {
+	Keys []APIKeyDTO `json:"keys"`
+}
+
+// APIKeyUsageDTO model represents the number of requests made with an API key on a day.
+// swagger:model
+type APIKeyUsageDTO struct {
+	KeyID    int64  `json:"keyId"`
+	Day      string `json:"day"`
+	Requests int64  `json:"requests"`
+}
+
+// APIKeyUsageResponseDTO model contains API key usage counters within a window of days.
+// swagger:model
+type APIKeyUsageResponseDTO struct {
+	Usage []APIKeyUsageDTO `json:"usage"`
+}
+
+// QualityIssueDTO model represents a suspicious stretch of stored rates: a gap, a jump or a duplicate timestamp.
+// swagger:model
+type QualityIssueDTO struct {
+	Title    string    `json:"title"`
+	Kind     string    `json:"kind" example:"gap"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	FromCost float64   `json:"fromCost,omitempty"`
+	ToCost   float64   `json:"toCost,omitempty"`
+	Sigmas   float64   `json:"sigmas,omitempty"`
+	Count    int       `json:"count,omitempty"`
+}
+
+// QualityReportDTO model contains the data quality issues found among the rates stored within a window.
+// swagger:model
+type QualityReportDTO struct {
+	From                    time.Time         `json:"from"`
+	To                      time.Time         `json:"to"`
+	ExpectedIntervalSeconds float64           `json:"expectedIntervalSeconds"`
+	JumpSigma               float64           `json:"jumpSigma"`
+	Counts                  map[string]int    `json:"counts"`
+	Issues                  []QualityIssueDTO `json:"issues"`
+}
+
+// QuarantinedRateDTO model represents a rate held back from storage as an outlier, awaiting review.
+// swagger:model
+type QuarantinedRateDTO struct {
+	ID            int64      `json:"id"`
+	Title         string     `json:"title"`
+	Cost          float64    `json:"cost"`
+	ActualAt      time.Time  `json:"actualAt"`
+	ReferenceCost float64    `json:"referenceCost"`
+	Deviation     float64    `json:"deviation" example:"0.75"`
+	Status        string     `json:"status" example:"pending"`
+	QuarantinedAt time.Time  `json:"quarantinedAt"`
+	ReviewedAt    *time.Time `json:"reviewedAt,omitempty"`
+}
+
+// QuarantinedRatesResponseDTO model contains quarantined rates of one review status, newest first.
+// swagger:model
+type QuarantinedRatesResponseDTO struct {
+	Rates []QuarantinedRateDTO `json:"rates"`
+}
+
+// DependencyHealthDTO model represents the state of a single dependency in a readiness check.
+// swagger:model
+type DependencyHealthDTO struct {
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// HealthResponseDTO model represents the overall health of the service and a breakdown per dependency.
+// swagger:model
+type HealthResponseDTO struct {
+	Status       string                         `json:"status"`
+	Dependencies map[string]DependencyHealthDTO `json:"dependencies,omitempty"`
 }